@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/app"
+)
+
+// reencrypt moves every stored password to ENCRYPTION_PRIMARY_KEY, after
+// which the other keys can be removed from ENCRYPTION_KEYS
+func main() {
+	var envFile string
+	flag.StringVar(&envFile, "env", "", "Path to environment file")
+	flag.Parse()
+
+	config, err := app.NewConfig(envFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := app.ReencryptPasswords(context.Background(), config)
+	log.Printf("Re-encrypted %d passwords, %d already current, %d failed",
+		result.Reencrypted, result.Current, result.Failed)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
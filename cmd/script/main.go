@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/wodbuster"
 	"github.com/joho/godotenv"
 )
@@ -46,38 +47,38 @@ func main() {
 		log.Fatalf("Failed to not remember browser: %v", err)
 	}
 
-	classes, err := client.GetAvailableClassesOnly("L")
+	classes, err := client.GetAvailableClassesOnly(models.DayMonday)
 	if err != nil {
 		log.Fatalf("Failed to get available classes: %v", err)
 	}
 	fmt.Println(classes)
 
-	err = client.BookClassOnly("L", "Wod", "07:00")
+	err = client.BookClassOnly(models.DayMonday, "Wod", "07:00")
 	if err != nil {
 		log.Fatalf("Failed to book class: %v", err)
 	}
 
 	// ===============================
-	classes, err = client.GetAvailableClassesOnly("X")
+	classes, err = client.GetAvailableClassesOnly(models.DayWednesday)
 	if err != nil {
 		log.Fatalf("Failed to get available classes: %v", err)
 	}
 	fmt.Println(classes)
 
-	err = client.BookClassOnly("X", "Wod", "07:00")
+	err = client.BookClassOnly(models.DayWednesday, "Wod", "07:00")
 	if err != nil {
 		log.Fatalf("Failed to book class: %v", err)
 	}
 
 	// ===============================
 
-	classes, err = client.GetAvailableClassesOnly("V")
+	classes, err = client.GetAvailableClassesOnly(models.DayFriday)
 	if err != nil {
 		log.Fatalf("Failed to get available classes: %v", err)
 	}
 	fmt.Println(classes)
 
-	err = client.BookClassOnly("V", "Wod", "07:00")
+	err = client.BookClassOnly(models.DayFriday, "Wod", "07:00")
 	if err != nil {
 		log.Fatalf("Failed to book class: %v", err)
 	}
@@ -105,7 +106,7 @@ func main() {
 
 	// 	defer client.Close()
 
-	// 	err = client.BookClass(context.Background(), user, pass, "X", "Wod", "20:30")
+	// 	err = client.BookClass(context.Background(), user, pass, models.DayWednesday, "Wod", "20:30")
 	// 	if err != nil {
 	// 		log.Fatalf("Failed to book class: %v", err)
 	// 	}
@@ -120,7 +121,7 @@ func main() {
 
 		defer client.Close()
 
-		err = client.BookClass(context.Background(), user, pass, "L", "Wod", "07:00")
+		err = client.BookClass(context.Background(), user, pass, models.DayMonday, "Wod", "07:00")
 		if err != nil {
 			log.Fatalf("Failed to book class: %v", err)
 		}
@@ -135,7 +136,7 @@ func main() {
 
 		defer client.Close()
 
-		err = client.BookClass(context.Background(), user, pass, "X", "Wod", "07:00")
+		err = client.BookClass(context.Background(), user, pass, models.DayWednesday, "Wod", "07:00")
 		if err != nil {
 			log.Fatalf("Failed to book class: %v", err)
 		}
@@ -150,7 +151,7 @@ func main() {
 
 		defer client.Close()
 
-		err = client.BookClass(context.Background(), user, pass, "V", "Wod", "07:00")
+		err = client.BookClass(context.Background(), user, pass, models.DayFriday, "Wod", "07:00")
 		if err != nil {
 			log.Fatalf("Failed to book class: %v", err)
 		}
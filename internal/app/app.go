@@ -8,8 +8,10 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Booking timezones must resolve in minimal containers
 
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/health"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram"
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
@@ -21,6 +23,7 @@ type App struct {
 	manager          *usecase.Manager
 	bookingScheduler *usecase.BookingScheduler
 	storage          usecase.Storage
+	clientPool       closableClientPool
 	logger           *slog.Logger
 	config           *Config
 	healthChecker    *health.Checker
@@ -45,40 +48,59 @@ func New(config *Config) (*App, error) {
 		Level: slog.LevelInfo,
 	}))
 
-	// Initialize storage
-	var store usecase.Storage
-	var err error
+	keyring, err := config.Keyring()
+	if err != nil {
+		return nil, err
+	}
 
-	switch config.StorageType {
-	case "mongodb":
-		store, err = storage.NewMongoStorage(config.MongoURI, config.MongoDB)
-		if err != nil {
-			return nil, fmt.Errorf("failed to initialize MongoDB storage: %w", err)
-		}
-	case "memory":
-		store = storage.NewMemoryStorage()
-	default:
-		return nil, fmt.Errorf("unsupported storage type: %s", config.StorageType)
+	store, err := newStorage(config)
+	if err != nil {
+		return nil, err
 	}
 
-	// Initialize WODBuster client with headless mode and anti-detection
-	client, err := wodbuster.NewClient(config.WODBusterURL,
-		wodbuster.WithLogger(logger),
-		wodbuster.WithHeadlessMode(true),
-	)
+	clientPool, err := newWODBusterClientPool(config, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingWindow, err := usecase.NewBookingWindowPolicy(usecase.BookingWindowConfig{
+		Mode:        config.BookingWindowMode,
+		Timezone:    config.BookingTimezone,
+		OpenWeekday: config.BookingOpenWeekday,
+		OpenTime:    config.BookingOpenTime,
+		OpensBefore: config.BookingOpensBefore,
+		RunLead:     config.BookingRunLead,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create WODBuster client: %w", err)
+		return nil, fmt.Errorf("failed to configure booking window: %w", err)
 	}
 
 	// Create booking scheduler with simplified dependencies
-	bookingScheduler := usecase.NewBookingScheduler(store, client, logger)
+	schedulerOpts := []usecase.SchedulerOption{
+		usecase.WithBurst(config.BookingBurstWindow, config.BookingBurstEvery),
+		usecase.WithRetries(config.BookingMaxRetries, config.BookingRetryWindow),
+		usecase.WithErrorClassifier(classifyWODBusterError),
+		usecase.WithLimits(models.BookingLimits{
+			MaxPerWeek: config.BookingMaxPerWeek,
+			MaxPerDay:  config.BookingMaxPerDay,
+		}, config.BookingClassDuration),
+	}
+	if config.WaitlistEnabled {
+		schedulerOpts = append(schedulerOpts, usecase.WithWaitlist(config.WaitlistCheckInterval, config.WaitlistCutoff))
+	}
+	if config.SessionKeepAliveEnabled {
+		schedulerOpts = append(schedulerOpts, usecase.WithKeepAlive(config.SessionKeepAliveLead))
+	}
+	credentials := usecase.NewEncryptedCredentialProvider(keyring, store, logger)
+	bookingScheduler := usecase.NewBookingScheduler(store, clientPool, credentials, bookingWindow, logger, schedulerOpts...)
 
 	// Create manager with all dependencies injected
 	manager := usecase.NewManager(
 		store,
-		client,
-		config.EncryptionKey,
+		clientPool,
+		keyring,
 		bookingScheduler,
+		newClassCatalogue(config),
 		logger,
 	)
 
@@ -87,6 +109,7 @@ func New(config *Config) (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Telegram bot: %w", err)
 	}
+	bookingScheduler.SetNotifier(bot)
 
 	// Create health checker
 	healthChecker := health.NewChecker(store, logger, config.Version)
@@ -96,12 +119,76 @@ func New(config *Config) (*App, error) {
 		manager:          manager,
 		bookingScheduler: bookingScheduler,
 		storage:          store,
+		clientPool:       clientPool,
 		logger:           logger,
 		config:           config,
 		healthChecker:    healthChecker,
 	}, nil
 }
 
+// newStorage creates the configured storage
+func newStorage(config *Config) (usecase.Storage, error) {
+	switch config.StorageType {
+	case "mongodb":
+		store, err := storage.NewMongoStorage(config.MongoURI, config.MongoDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize MongoDB storage: %w", err)
+		}
+		return store, nil
+	case "memory":
+		return storage.NewMemoryStorage(), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", config.StorageType)
+	}
+}
+
+// closableClientPool is a client pool holding resources released on shutdown
+type closableClientPool interface {
+	usecase.ClientPool
+	Close()
+}
+
+// newWODBusterClientPool creates the pool that logins, bookings and timetable
+// reads draw their clients from, for the configured client type. Each client
+// has a session of its own, so chats never share one.
+func newWODBusterClientPool(config *Config, logger *slog.Logger) (closableClientPool, error) {
+	switch config.ClientType {
+	case "browser":
+		// Every client runs in its own isolated browser context
+		pool, err := wodbuster.NewPool(config.WODBusterURL, config.BrowserPoolSize,
+			wodbuster.WithPoolLogger(logger),
+			wodbuster.WithPoolHeadlessMode(true),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create browser pool: %w", err)
+		}
+		return &browserClientPool{pool: pool}, nil
+	case "http":
+		return newHTTPClientPool(config.WODBusterURL, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported client type: %s", config.ClientType)
+	}
+}
+
+// newClassCatalogue creates the catalogue of the configured gym from the class
+// types known to the WODBuster client and the configured aliases
+func newClassCatalogue(config *Config) *usecase.ClassCatalogue {
+	known := make([]string, 0, len(wodbuster.ClassTypes))
+	for _, classType := range wodbuster.ClassTypes {
+		known = append(known, string(classType))
+	}
+
+	aliases := make(map[string]string, len(wodbuster.ClassTypeAliases)+len(config.ClassTypeAliases))
+	for alias, classType := range wodbuster.ClassTypeAliases {
+		aliases[alias] = string(classType)
+	}
+	for alias, classType := range config.ClassTypeAliases {
+		aliases[alias] = classType
+	}
+
+	return usecase.NewClassCatalogue(config.WODBusterURL, known, aliases, config.ClassCatalogueTTL)
+}
+
 func (a *App) Start(ctx context.Context) error {
 	a.logger.Info("Starting WODBuster Bot",
 		"version", a.config.Version,
@@ -116,7 +203,7 @@ func (a *App) Start(ctx context.Context) error {
 		}
 	}()
 
-	// Start booking scheduler at app level (Saturday cronjob)
+	// Start booking scheduler at app level
 	if err := a.bookingScheduler.Start(); err != nil {
 		a.logger.Error("Failed to start booking scheduler", "error", err)
 		return fmt.Errorf("failed to start booking scheduler: %w", err)
@@ -131,6 +218,7 @@ func (a *App) Stop() {
 
 	// Stop booking scheduler
 	a.bookingScheduler.Stop()
+	a.clientPool.Close()
 
 	// Stop bot
 	if err := a.bot.Stop(); err != nil {
@@ -148,8 +236,8 @@ func (a *App) Stop() {
 }
 
 func (a *App) Execute() error {
-	// Start the Saturday booking scheduler
-	a.logger.Info("Starting Saturday booking scheduler...")
+	// Start the booking scheduler
+	a.logger.Info("Starting booking scheduler...")
 	if err := a.bookingScheduler.Start(); err != nil {
 		a.logger.Error("Failed to start booking scheduler", "error", err)
 		return err
@@ -211,6 +299,7 @@ func (a *App) shutdown(ctx context.Context) error {
 		// Stop booking scheduler
 		a.logger.Info("Stopping booking scheduler...")
 		a.bookingScheduler.Stop()
+		a.clientPool.Close()
 
 		// Stop bot
 		a.logger.Info("Stopping bot...")
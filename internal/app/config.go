@@ -1,15 +1,34 @@
 package app
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 )
 
+// defaultEncryptionKeyID is the ID of the key set by ENCRYPTION_KEY
+const defaultEncryptionKeyID = "default"
+
+// devEncryptionKey is the ENCRYPTION_KEY used when none is set. It is public,
+// so the bot refuses to run with it in production.
+const devEncryptionKey = "dev-only-insecure-key-0123456789"
+
+// oldDefaultEncryptionKey was the ENCRYPTION_KEY used when none was set before
+// devEncryptionKey. It is 31 bytes long, not a valid AES key, so no password
+// was ever encrypted with it and there is nothing to migrate; it is public all
+// the same, so it is refused in production too.
+const oldDefaultEncryptionKey = "your-32-character-secret-key123"
+
+var ErrDevEncryptionKey = errors.New("the public default encryption keys can't be used in production, set ENCRYPTION_KEY or ENCRYPTION_KEYS")
+
 type Config struct {
 	TelegramToken string `envconfig:"TELEGRAM_BOT_TOKEN" default:"your_bot_token_here"`
 	Env           string `envconfig:"APP_ENV" default:"prod"`
@@ -17,13 +36,50 @@ type Config struct {
 	LoggerLevel   slog.Level `envconfig:"LOGGING_LEVEL" default:"DEBUG"`
 	WODBusterURL  string     `envconfig:"WODBUSTER_URL" default:"https://wodbuster.com"`
 
+	// WODBuster client configuration
+	ClientType      string `envconfig:"CLIENT_TYPE" default:"browser"` // "browser" (chromedp) or "http"
+	BrowserPoolSize int    `envconfig:"BROWSER_POOL_SIZE" default:"4"` // Concurrent browser contexts for scheduled bookings
+
+	// Booking window configuration
+	BookingWindowMode  string        `envconfig:"BOOKING_WINDOW_MODE" default:"weekly"`     // "weekly" or "rolling"
+	BookingTimezone    string        `envconfig:"BOOKING_TIMEZONE" default:"Europe/Madrid"` // Timezone of the box
+	BookingOpenWeekday string        `envconfig:"BOOKING_OPEN_WEEKDAY" default:"Saturday"`  // Weekly: day the next week opens
+	BookingOpenTime    string        `envconfig:"BOOKING_OPEN_TIME" default:"12:00"`        // Weekly: time the next week opens
+	BookingOpensBefore time.Duration `envconfig:"BOOKING_OPENS_BEFORE" default:"48h"`       // Rolling: how long before a class it opens
+	BookingRunLead     time.Duration `envconfig:"BOOKING_RUN_LEAD" default:"5m"`            // How early the bot gets ready before opening
+	BookingBurstWindow time.Duration `envconfig:"BOOKING_BURST_WINDOW" default:"30s"`       // How long booking attempts are fired once open
+	BookingBurstEvery  time.Duration `envconfig:"BOOKING_BURST_INTERVAL" default:"250ms"`   // Pause between booking attempts
+	BookingMaxRetries  int           `envconfig:"BOOKING_MAX_RETRIES" default:"3"`          // Retries of a failed booking
+	BookingRetryWindow time.Duration `envconfig:"BOOKING_RETRY_WINDOW" default:"2m"`        // How long after opening failed bookings are retried
+
+	// Waitlist mode: full classes are joined on the site's waiting list or watched for a free spot
+	WaitlistEnabled       bool          `envconfig:"WAITLIST_ENABLED" default:"true"`
+	WaitlistCheckInterval time.Duration `envconfig:"WAITLIST_CHECK_INTERVAL" default:"5m"` // How often full classes are checked
+	WaitlistCutoff        time.Duration `envconfig:"WAITLIST_CUTOFF" default:"2h"`         // How long before the class the bot stops waiting
+
+	// Session keep-alive: stored sessions are checked, and logged in again if they died, ahead of booking
+	SessionKeepAliveEnabled bool          `envconfig:"SESSION_KEEPALIVE_ENABLED" default:"true"`
+	SessionKeepAliveLead    time.Duration `envconfig:"SESSION_KEEPALIVE_LEAD" default:"2h"` // How long before each booking window sessions are checked
+
+	// Booking limits of the users who haven't set their own with /limits, 0 means no limit
+	BookingMaxPerWeek    int           `envconfig:"BOOKING_MAX_PER_WEEK" default:"0"`
+	BookingMaxPerDay     int           `envconfig:"BOOKING_MAX_PER_DAY" default:"0"`
+	BookingClassDuration time.Duration `envconfig:"BOOKING_CLASS_DURATION" default:"1h"` // How long classes last, so overlapping ones aren't both booked
+
+	// Class type catalogue: the gym's class types are read from its timetable
+	ClassCatalogueTTL time.Duration     `envconfig:"CLASS_CATALOGUE_TTL" default:"24h"` // How often the timetable is read again
+	ClassTypeAliases  map[string]string `envconfig:"CLASS_TYPE_ALIASES"`                // Extra aliases, e.g. "crossfit:Wod,legs:Pierna/Gluteo"
+
 	// MongoDB configuration
 	MongoURI    string `envconfig:"MONGO_URI" default:"mongodb://localhost:27017"`
 	MongoDB     string `envconfig:"MONGO_DB" default:"wodbuster"`
 	StorageType string `envconfig:"STORAGE_TYPE" default:"memory"` // "memory" or "mongodb"
 
-	// Security configuration
-	EncryptionKey string `envconfig:"ENCRYPTION_KEY" default:"your-32-character-secret-key123"`
+	// Security configuration: stored passwords are encrypted with the primary of
+	// the keys, and can be decrypted with any of them while they are rotated
+	EncryptionKey        string            `envconfig:"ENCRYPTION_KEY" default:"dev-only-insecure-key-0123456789"` // Key with ID "default"
+	EncryptionKeys       map[string]string `envconfig:"ENCRYPTION_KEYS"`                                           // More keys by ID, e.g. "2025:key,2026:key"
+	EncryptionPrimaryKey string            `envconfig:"ENCRYPTION_PRIMARY_KEY" default:"default"`                  // ID of the key new passwords are encrypted with
 
 	// Health check configuration
 	HealthCheckPort string `envconfig:"HEALTH_CHECK_PORT" default:"8080"`
@@ -68,3 +124,30 @@ func (c *Config) LoadDotEnv(configFile string) error {
 func (c *Config) ParseEnv() error {
 	return envconfig.Process("", c)
 }
+
+// Keyring returns the keyring of the configured encryption keys. ENCRYPTION_KEY
+// is left out when ENCRYPTION_KEYS is set and it is the development key, so it
+// doesn't have to be unset to retire it.
+func (c *Config) Keyring() (*utils.Keyring, error) {
+	keys := make(map[string]string, len(c.EncryptionKeys)+1)
+	for id, key := range c.EncryptionKeys {
+		keys[id] = key
+	}
+	if _, ok := keys[defaultEncryptionKeyID]; !ok && (len(keys) == 0 || c.EncryptionKey != devEncryptionKey) {
+		keys[defaultEncryptionKeyID] = c.EncryptionKey
+	}
+
+	if c.Env == "prod" {
+		for _, key := range keys {
+			if key == devEncryptionKey || key == oldDefaultEncryptionKey {
+				return nil, ErrDevEncryptionKey
+			}
+		}
+	}
+
+	keyring, err := utils.NewKeyring(c.EncryptionPrimaryKey, keys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption keys: %w", err)
+	}
+	return keyring, nil
+}
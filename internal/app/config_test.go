@@ -0,0 +1,75 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Keyring(t *testing.T) {
+	const (
+		oldKey = "0123456789abcdef0123456789abcdef"
+		newKey = "fedcba9876543210fedcba9876543210"
+	)
+
+	tests := []struct {
+		name        string
+		config      Config
+		wantPrimary string
+		wantErr     error
+	}{
+		{
+			name:        "development key outside production",
+			config:      Config{Env: "dev", EncryptionKey: devEncryptionKey, EncryptionPrimaryKey: "default"},
+			wantPrimary: "default",
+		},
+		{
+			name:    "development key in production",
+			config:  Config{Env: "prod", EncryptionKey: devEncryptionKey, EncryptionPrimaryKey: "default"},
+			wantErr: ErrDevEncryptionKey,
+		},
+		{
+			name:    "old default key in production",
+			config:  Config{Env: "prod", EncryptionKey: oldDefaultEncryptionKey, EncryptionPrimaryKey: "default"},
+			wantErr: ErrDevEncryptionKey,
+		},
+		{
+			name: "old default key among the keys in production",
+			config: Config{Env: "prod", EncryptionKey: devEncryptionKey,
+				EncryptionKeys: map[string]string{"2026": newKey, "old": oldDefaultEncryptionKey}, EncryptionPrimaryKey: "2026"},
+			wantErr: ErrDevEncryptionKey,
+		},
+		{
+			name: "rotating away from ENCRYPTION_KEY",
+			config: Config{Env: "prod", EncryptionKey: oldKey,
+				EncryptionKeys: map[string]string{"2026": newKey}, EncryptionPrimaryKey: "2026"},
+			wantPrimary: "2026",
+		},
+		{
+			name: "ENCRYPTION_KEY left unset once retired",
+			config: Config{Env: "prod", EncryptionKey: devEncryptionKey,
+				EncryptionKeys: map[string]string{"2026": newKey}, EncryptionPrimaryKey: "2026"},
+			wantPrimary: "2026",
+		},
+		{
+			name: "unknown primary key",
+			config: Config{Env: "prod", EncryptionKey: oldKey,
+				EncryptionKeys: map[string]string{"2026": newKey}, EncryptionPrimaryKey: "2027"},
+			wantErr: utils.ErrUnknownKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring, err := tt.config.Keyring()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPrimary, keyring.Primary())
+		})
+	}
+}
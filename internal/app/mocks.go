@@ -0,0 +1,231 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package app
+
+import (
+	"context"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// newMockclosableClientPool creates a new instance of mockclosableClientPool. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockclosableClientPool(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockclosableClientPool {
+	mock := &mockclosableClientPool{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockclosableClientPool is an autogenerated mock type for the closableClientPool type
+type mockclosableClientPool struct {
+	mock.Mock
+}
+
+type mockclosableClientPool_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockclosableClientPool) EXPECT() *mockclosableClientPool_Expecter {
+	return &mockclosableClientPool_Expecter{mock: &_m.Mock}
+}
+
+// Acquire provides a mock function for the type mockclosableClientPool
+func (_mock *mockclosableClientPool) Acquire(ctx context.Context) (usecase.APIClient, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acquire")
+	}
+
+	var r0 usecase.APIClient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (usecase.APIClient, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) usecase.APIClient); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(usecase.APIClient)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockclosableClientPool_Acquire_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acquire'
+type mockclosableClientPool_Acquire_Call struct {
+	*mock.Call
+}
+
+// Acquire is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockclosableClientPool_Expecter) Acquire(ctx interface{}) *mockclosableClientPool_Acquire_Call {
+	return &mockclosableClientPool_Acquire_Call{Call: _e.mock.On("Acquire", ctx)}
+}
+
+func (_c *mockclosableClientPool_Acquire_Call) Run(run func(ctx context.Context)) *mockclosableClientPool_Acquire_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockclosableClientPool_Acquire_Call) Return(aPIClient usecase.APIClient, err error) *mockclosableClientPool_Acquire_Call {
+	_c.Call.Return(aPIClient, err)
+	return _c
+}
+
+func (_c *mockclosableClientPool_Acquire_Call) RunAndReturn(run func(ctx context.Context) (usecase.APIClient, error)) *mockclosableClientPool_Acquire_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Close provides a mock function for the type mockclosableClientPool
+func (_mock *mockclosableClientPool) Close() {
+	_mock.Called()
+	return
+}
+
+// mockclosableClientPool_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type mockclosableClientPool_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *mockclosableClientPool_Expecter) Close() *mockclosableClientPool_Close_Call {
+	return &mockclosableClientPool_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *mockclosableClientPool_Close_Call) Run(run func()) *mockclosableClientPool_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockclosableClientPool_Close_Call) Return() *mockclosableClientPool_Close_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockclosableClientPool_Close_Call) RunAndReturn(run func()) *mockclosableClientPool_Close_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Release provides a mock function for the type mockclosableClientPool
+func (_mock *mockclosableClientPool) Release(client usecase.APIClient) {
+	_mock.Called(client)
+	return
+}
+
+// mockclosableClientPool_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type mockclosableClientPool_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - client usecase.APIClient
+func (_e *mockclosableClientPool_Expecter) Release(client interface{}) *mockclosableClientPool_Release_Call {
+	return &mockclosableClientPool_Release_Call{Call: _e.mock.On("Release", client)}
+}
+
+func (_c *mockclosableClientPool_Release_Call) Run(run func(client usecase.APIClient)) *mockclosableClientPool_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 usecase.APIClient
+		if args[0] != nil {
+			arg0 = args[0].(usecase.APIClient)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *mockclosableClientPool_Release_Call) Return() *mockclosableClientPool_Release_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockclosableClientPool_Release_Call) RunAndReturn(run func(client usecase.APIClient)) *mockclosableClientPool_Release_Call {
+	_c.Run(run)
+	return _c
+}
+
+// WarmUp provides a mock function for the type mockclosableClientPool
+func (_mock *mockclosableClientPool) WarmUp(ctx context.Context, count int) error {
+	ret := _mock.Called(ctx, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WarmUp")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, count)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockclosableClientPool_WarmUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WarmUp'
+type mockclosableClientPool_WarmUp_Call struct {
+	*mock.Call
+}
+
+// WarmUp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - count int
+func (_e *mockclosableClientPool_Expecter) WarmUp(ctx interface{}, count interface{}) *mockclosableClientPool_WarmUp_Call {
+	return &mockclosableClientPool_WarmUp_Call{Call: _e.mock.On("WarmUp", ctx, count)}
+}
+
+func (_c *mockclosableClientPool_WarmUp_Call) Run(run func(ctx context.Context, count int)) *mockclosableClientPool_WarmUp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockclosableClientPool_WarmUp_Call) Return(err error) *mockclosableClientPool_WarmUp_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockclosableClientPool_WarmUp_Call) RunAndReturn(run func(ctx context.Context, count int) error) *mockclosableClientPool_WarmUp_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/wodbuster"
+)
+
+// browserClientPool adapts wodbuster.Pool to the usecase.ClientPool interface
+type browserClientPool struct {
+	pool *wodbuster.Pool
+}
+
+func (p *browserClientPool) Acquire(ctx context.Context) (usecase.APIClient, error) {
+	client, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *browserClientPool) Release(client usecase.APIClient) {
+	if browserClient, ok := client.(*wodbuster.Client); ok {
+		p.pool.Release(browserClient)
+	}
+}
+
+func (p *browserClientPool) WarmUp(ctx context.Context, count int) error {
+	return p.pool.WarmUp(ctx, count)
+}
+
+func (p *browserClientPool) Close() {
+	p.pool.Close()
+}
+
+// httpClientPool hands out HTTP clients with their own cookie jar. They share
+// one transport so the connections opened by WarmUp are reused at booking time.
+type httpClientPool struct {
+	baseURL   string
+	transport *http.Transport
+	logger    *slog.Logger
+}
+
+func newHTTPClientPool(baseURL string, logger *slog.Logger) *httpClientPool {
+	return &httpClientPool{
+		baseURL:   baseURL,
+		transport: http.DefaultTransport.(*http.Transport).Clone(),
+		logger:    logger,
+	}
+}
+
+func (p *httpClientPool) Acquire(_ context.Context) (usecase.APIClient, error) {
+	client, err := wodbuster.NewHTTPClient(p.baseURL,
+		wodbuster.WithHTTPLogger(p.logger),
+		wodbuster.WithHTTPTransport(p.transport),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *httpClientPool) Release(_ usecase.APIClient) {}
+
+// WarmUp opens count connections to WODBuster ahead of the booking window
+func (p *httpClientPool) WarmUp(ctx context.Context, count int) error {
+	errs := make(chan error, count)
+
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodHead, p.baseURL, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp, err := p.transport.RoundTrip(req)
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+func (p *httpClientPool) Close() {
+	p.transport.CloseIdleConnections()
+}
+
+// classifyWODBusterError maps the errors of the wodbuster clients to the kinds
+// the booking scheduler reacts to
+func classifyWODBusterError(err error) usecase.BookingErrorKind {
+	switch {
+	case errors.Is(err, wodbuster.ErrSessionExpired):
+		return usecase.BookingErrorSessionExpired
+	case errors.Is(err, wodbuster.ErrClassFull):
+		return usecase.BookingErrorClassFull
+	case errors.Is(err, wodbuster.ErrClassNotBookable), errors.Is(err, wodbuster.ErrRequestRejected):
+		return usecase.BookingErrorNotOpenYet
+	case errors.Is(err, wodbuster.ErrNavigationTimeout):
+		return usecase.BookingErrorTransient
+	case errors.Is(err, wodbuster.ErrInvalidCredentials), errors.Is(err, wodbuster.ErrClassNotFound):
+		return usecase.BookingErrorPermanent
+	}
+	return usecase.ClassifyBookingError(err)
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+)
+
+// ReencryptPasswords re-encrypts every stored password with the primary
+// encryption key, so the other keys can be removed from the configuration. It
+// saves whole users, so it should run while the bot is stopped.
+func ReencryptPasswords(ctx context.Context, config *Config) (usecase.ReencryptResult, error) {
+	keyring, err := config.Keyring()
+	if err != nil {
+		return usecase.ReencryptResult{}, err
+	}
+
+	store, err := newStorage(config)
+	if err != nil {
+		return usecase.ReencryptResult{}, err
+	}
+	if mongoStorage, ok := store.(*storage.MongoStorage); ok {
+		defer func() {
+			if err := mongoStorage.Close(); err != nil {
+				config.Logger.Error("Failed to close MongoDB connection", "error", err)
+			}
+		}()
+	}
+
+	result, err := usecase.ReencryptPasswords(ctx, store, keyring, config.Logger)
+	if err != nil {
+		return result, fmt.Errorf("failed to re-encrypt some passwords: %w", err)
+	}
+	return result, nil
+}
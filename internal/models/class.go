@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ClassSchedule is a class on the gym's timetable
+type ClassSchedule struct {
+	Day       Day    `json:"day"`
+	Hour      string `json:"hour"`       // Time in format HH:MM (e.g., "07:00")
+	ClassType string `json:"class_type"` // Class name as shown on the timetable (e.g., Wod, Open box, HYROX)
+	Available bool   `json:"available"`  // Whether the class has available spots
+}
+
+// ClassRequest is one class of a batch booked within a single session
+type ClassRequest struct {
+	Date         time.Time // When the class starts
+	ClassType    string
+	Hour         string             // Time in format HH:MM
+	Alternatives []ClassAlternative // Classes of the same day booked, in order, when this one can't be
+}
+
+// ClassResult is the outcome of booking one class of a batch. A class that is
+// neither booked nor failed could not be booked yet.
+type ClassResult struct {
+	ClassRequest
+	Booked            bool
+	BookedAlternative *ClassAlternative // The alternative booked instead of the class, if any
+	Err               error
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDay = errors.New("invalid day")
+
+// Day is a day of the week, stored by its English name (e.g. "Monday"). It is
+// the day vocabulary shared by the bot's commands, storage and the WODBuster
+// clients.
+type Day string
+
+const (
+	DayMonday    Day = "Monday"
+	DayTuesday   Day = "Tuesday"
+	DayWednesday Day = "Wednesday"
+	DayThursday  Day = "Thursday"
+	DayFriday    Day = "Friday"
+	DaySaturday  Day = "Saturday"
+	DaySunday    Day = "Sunday"
+)
+
+// Days lists the days of the week from Monday, like WODBuster's calendar
+var Days = []Day{DayMonday, DayTuesday, DayWednesday, DayThursday, DayFriday, DaySaturday, DaySunday}
+
+// dayNames maps the accepted spellings, lowercase and without accents, to days.
+// Single letters are WODBuster's calendar tabs, so "m" is Martes and "x" is
+// Miércoles.
+var dayNames = map[string]Day{
+	"monday": DayMonday, "mon": DayMonday, "lunes": DayMonday, "lun": DayMonday, "l": DayMonday,
+	"tuesday": DayTuesday, "tue": DayTuesday, "tues": DayTuesday, "martes": DayTuesday, "mar": DayTuesday, "m": DayTuesday,
+	"wednesday": DayWednesday, "wed": DayWednesday, "miercoles": DayWednesday, "mie": DayWednesday, "x": DayWednesday,
+	"thursday": DayThursday, "thu": DayThursday, "thur": DayThursday, "thurs": DayThursday, "jueves": DayThursday, "jue": DayThursday, "j": DayThursday,
+	"friday": DayFriday, "fri": DayFriday, "viernes": DayFriday, "vie": DayFriday, "v": DayFriday,
+	"saturday": DaySaturday, "sat": DaySaturday, "sabado": DaySaturday, "sab": DaySaturday, "s": DaySaturday,
+	"sunday": DaySunday, "sun": DaySunday, "domingo": DaySunday, "dom": DaySunday, "d": DaySunday,
+}
+
+// relativeDays maps the accepted relative words to their offset from today
+var relativeDays = map[string]int{
+	"today":    0,
+	"hoy":      0,
+	"tomorrow": 1,
+	"manana":   1,
+}
+
+// calendarTabs are the abbreviations of WODBuster's calendar tabs
+var calendarTabs = map[Day]string{
+	DayMonday:    "L", // Lunes
+	DayTuesday:   "M", // Martes
+	DayWednesday: "X", // Miércoles
+	DayThursday:  "J", // Jueves
+	DayFriday:    "V", // Viernes
+	DaySaturday:  "S", // Sábado
+	DaySunday:    "D", // Domingo
+}
+
+// removeAccents folds the accented letters of Spanish day names
+var removeAccents = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ñ", "n")
+
+// ParseDay parses an English or Spanish day name or abbreviation, a WODBuster
+// calendar tab letter, or "today"/"tomorrow" ("hoy"/"mañana") relative to now.
+// Case and accents are ignored.
+func ParseDay(input string, now time.Time) (Day, error) {
+	name := removeAccents.Replace(strings.ToLower(strings.TrimSpace(input)))
+
+	if day, ok := dayNames[name]; ok {
+		return day, nil
+	}
+	if offset, ok := relativeDays[name]; ok {
+		return DayOf(now.AddDate(0, 0, offset).Weekday()), nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidDay, input)
+}
+
+// DayOf returns the day of a time.Weekday
+func DayOf(weekday time.Weekday) Day {
+	return Days[(int(weekday)+6)%7]
+}
+
+// Valid reports whether the day is one of the canonical days
+func (d Day) Valid() bool {
+	_, ok := calendarTabs[d]
+	return ok
+}
+
+// Weekday returns the time.Weekday of the day, false if it is not valid
+func (d Day) Weekday() (time.Weekday, bool) {
+	for i, day := range Days {
+		if day == d {
+			return time.Weekday((i + 1) % 7), true
+		}
+	}
+	return time.Sunday, false
+}
+
+// CalendarTab returns the abbreviation of the day's tab on WODBuster's
+// calendar (L, M, X, J, V, S or D), empty for an invalid day
+func (d Day) CalendarTab() string {
+	return calendarTabs[d]
+}
+
+func (d Day) String() string {
+	return string(d)
+}
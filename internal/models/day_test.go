@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDay(t *testing.T) {
+	// A Sunday, so "tomorrow" wraps around to the start of the week
+	now := time.Date(2025, 8, 17, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    Day
+		wantErr bool
+	}{
+		{"english lowercase", "monday", DayMonday, false},
+		{"english title case", "Monday", DayMonday, false},
+		{"english uppercase", "MONDAY", DayMonday, false},
+		{"english abbreviation", "wed", DayWednesday, false},
+		{"english long abbreviation", "thurs", DayThursday, false},
+		{"spanish", "lunes", DayMonday, false},
+		{"spanish with accent", "Miércoles", DayWednesday, false},
+		{"spanish without accent", "sabado", DaySaturday, false},
+		{"spanish abbreviation", "vie", DayFriday, false},
+		{"calendar tab martes", "M", DayTuesday, false},
+		{"calendar tab miercoles", "x", DayWednesday, false},
+		{"surrounding whitespace", "  sunday ", DaySunday, false},
+		{"today", "today", DaySunday, false},
+		{"tomorrow", "tomorrow", DayMonday, false},
+		{"spanish tomorrow", "mañana", DayMonday, false},
+		{"empty", "", "", true},
+		{"whitespace only", "   ", "", true},
+		{"unknown", "funday", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDay(tt.input, now)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidDay)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDay_Weekday(t *testing.T) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		got, ok := DayOf(weekday).Weekday()
+		assert.True(t, ok)
+		assert.Equal(t, weekday, got)
+	}
+
+	_, ok := Day("Funday").Weekday()
+	assert.False(t, ok)
+}
+
+func TestDay_CalendarTab(t *testing.T) {
+	assert.Equal(t, "L", DayMonday.CalendarTab())
+	assert.Equal(t, "M", DayTuesday.CalendarTab())
+	assert.Equal(t, "X", DayWednesday.CalendarTab())
+	assert.Equal(t, "S", DaySaturday.CalendarTab())
+	assert.Equal(t, "D", DaySunday.CalendarTab())
+	assert.Empty(t, Day("Funday").CalendarTab())
+	assert.False(t, Day("monday").Valid(), "only canonical names are valid")
+}
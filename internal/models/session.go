@@ -0,0 +1,92 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// UserSession represents a persistent browser session with WODBuster: every
+// cookie the site set, so the session can be restored without logging in.
+// Email/password are stored in User model, not here
+type UserSession struct {
+	ChatID        int64           `bson:"chat_id" json:"chat_id"`
+	Cookies       []SessionCookie `bson:"cookies" json:"cookies"`
+	LastLoginTime time.Time       `bson:"last_login_time" json:"last_login_time"`
+	ExpiresAt     time.Time       `bson:"expires_at" json:"expires_at"` // Latest cookie expiry, zero if none expires
+	IsValid       bool            `bson:"is_valid" json:"is_valid"`
+	CreatedAt     time.Time       `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time       `bson:"updated_at" json:"updated_at"`
+}
+
+// SessionCookie represents a browser cookie for session persistence
+type SessionCookie struct {
+	Name     string    `bson:"name" json:"name"`
+	Value    string    `bson:"value" json:"value"`
+	Domain   string    `bson:"domain" json:"domain"`
+	Path     string    `bson:"path" json:"path"`
+	Expires  time.Time `bson:"expires" json:"expires"` // Zero for a cookie that lasts as long as the browser
+	Secure   bool      `bson:"secure" json:"secure"`
+	HttpOnly bool      `bson:"http_only" json:"http_only"`
+}
+
+// NewUserSession creates the session of a login from the cookies it set
+func NewUserSession(chatID int64, cookies []*http.Cookie, now time.Time) UserSession {
+	session := UserSession{
+		ChatID:        chatID,
+		Cookies:       make([]SessionCookie, 0, len(cookies)),
+		LastLoginTime: now,
+		IsValid:       len(cookies) > 0,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	for _, cookie := range cookies {
+		session.Cookies = append(session.Cookies, SessionCookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   cookie.Domain,
+			Path:     cookie.Path,
+			Expires:  cookie.Expires,
+			Secure:   cookie.Secure,
+			HttpOnly: cookie.HttpOnly,
+		})
+	}
+	session.ExpiresAt = SessionExpiry(cookies)
+	return session
+}
+
+// SessionExpiry returns when the last of the cookies expires, zero if none does
+func SessionExpiry(cookies []*http.Cookie) time.Time {
+	var expiresAt time.Time
+	for _, cookie := range cookies {
+		if cookie.Expires.After(expiresAt) {
+			expiresAt = cookie.Expires
+		}
+	}
+	return expiresAt
+}
+
+// Valid reports whether the session may still be restored. Only restoring it
+// tells for sure, since the site can end a session before its cookies expire.
+func (s UserSession) Valid(now time.Time) bool {
+	return s.IsValid &&
+		len(s.Cookies) > 0 &&
+		(s.ExpiresAt.IsZero() || now.Before(s.ExpiresAt))
+}
+
+// HTTPCookies returns the cookies of the session to restore it with
+func (s UserSession) HTTPCookies() []*http.Cookie {
+	cookies := make([]*http.Cookie, 0, len(s.Cookies))
+	for _, cookie := range s.Cookies {
+		cookies = append(cookies, &http.Cookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Domain:   cookie.Domain,
+			Path:     cookie.Path,
+			Expires:  cookie.Expires,
+			Secure:   cookie.Secure,
+			HttpOnly: cookie.HttpOnly,
+		})
+	}
+	return cookies
+}
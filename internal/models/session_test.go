@@ -0,0 +1,52 @@
+package models
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSession_Valid(t *testing.T) {
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		cookies []*http.Cookie
+		at      time.Time
+		want    bool
+	}{
+		{
+			name:    "before the latest cookie expires",
+			cookies: []*http.Cookie{{Name: ".WBAuth", Expires: now.Add(time.Hour)}, {Name: "other", Expires: now.Add(time.Minute)}},
+			at:      now.Add(30 * time.Minute),
+			want:    true,
+		},
+		{
+			name:    "after every cookie expired",
+			cookies: []*http.Cookie{{Name: ".WBAuth", Expires: now.Add(time.Hour)}},
+			at:      now.Add(2 * time.Hour),
+			want:    false,
+		},
+		{
+			name:    "cookies lasting as long as the browser",
+			cookies: []*http.Cookie{{Name: "ASP.NET_SessionId"}},
+			at:      now.Add(24 * time.Hour),
+			want:    true,
+		},
+		{
+			name: "no cookies",
+			at:   now,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewUserSession(42, tt.cookies, now)
+			assert.Equal(t, tt.want, session.Valid(tt.at))
+			assert.Len(t, session.HTTPCookies(), len(tt.cookies))
+		})
+	}
+}
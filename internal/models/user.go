@@ -5,12 +5,17 @@ import (
 	"time"
 )
 
+// DateLayout is the format used for calendar dates stored on schedules and attempts
+const DateLayout = "2006-01-02"
+
 type User struct {
 	ChatID                int64                  `json:"chat_id" bson:"chat_id"`
 	IsAuthenticated       bool                   `json:"is_authenticated" bson:"is_authenticated"`
 	Email                 string                 `json:"email" bson:"email"`
 	Password              string                 `json:"password" bson:"password"`
 	ClassBookingSchedules []ClassBookingSchedule `json:"class_booking_schedules" bson:"class_booking_schedules"`
+	// BookingLimits are the user's own limits, nil means the bot's defaults
+	BookingLimits *BookingLimits `json:"booking_limits,omitempty" bson:"booking_limits,omitempty"`
 	// Session data - simplified to store only the essential WODBuster session cookie
 	WODBusterSessionCookie *http.Cookie `json:"wodbuster_session_cookie,omitempty" bson:"wodbuster_session_cookie,omitempty"`
 	SessionExpiresAt       time.Time    `json:"session_expires_at,omitempty" bson:"session_expires_at,omitempty"`
@@ -20,37 +25,104 @@ type User struct {
 	UpdatedAt              time.Time    `json:"updated_at" bson:"updated_at"`
 }
 
+// ClassBookingSchedule is a standing weekly rule: the class is booked every week
+// between StartDate and EndDate, except for the dates listed in SkipDates and
+// while it is paused
 type ClassBookingSchedule struct {
-	ID        string `json:"id" bson:"id"`                 // Unique identifier for the class booking
-	ClassType string `json:"class_type" bson:"class_type"` // e.g., "WOD", "Open"
-	Day       string `json:"day" bson:"day"`               // e.g., "Monday", "Tuesday"
-	Hour      string `json:"hour" bson:"hour"`             // e.g., "10:00"
+	ID        string   `json:"id" bson:"id"`                                     // Unique identifier for the class booking
+	ClassType string   `json:"class_type" bson:"class_type"`                     // e.g., "WOD", "Open"
+	Day       Day      `json:"day" bson:"day"`                                   // e.g., "Monday", "Tuesday"
+	Hour      string   `json:"hour" bson:"hour"`                                 // e.g., "10:00"
+	StartDate string   `json:"start_date,omitempty" bson:"start_date,omitempty"` // First class date to book (YYYY-MM-DD), empty means now
+	EndDate   string   `json:"end_date,omitempty" bson:"end_date,omitempty"`     // Last class date to book (YYYY-MM-DD), empty means forever
+	SkipDates []string `json:"skip_dates,omitempty" bson:"skip_dates,omitempty"` // Class dates (YYYY-MM-DD) that must not be booked
+	// A paused rule books no class until PausedUntil (YYYY-MM-DD) has passed,
+	// or until it is resumed when PausedUntil is empty
+	Paused      bool   `json:"paused,omitempty" bson:"paused,omitempty"`
+	PausedUntil string `json:"paused_until,omitempty" bson:"paused_until,omitempty"`
+	// Alternatives are booked, in order, when the class can't be booked
+	Alternatives []ClassAlternative `json:"alternatives,omitempty" bson:"alternatives,omitempty"`
+}
+
+// BookingLimits caps how many classes are booked for a user, e.g. as allowed by
+// their gym plan. Zero means no limit.
+type BookingLimits struct {
+	MaxPerWeek int `json:"max_per_week,omitempty" bson:"max_per_week,omitempty"` // Classes from Monday to Sunday
+	MaxPerDay  int `json:"max_per_day,omitempty" bson:"max_per_day,omitempty"`
+}
+
+// ClassAlternative is another class on the same day a booking rule falls back to
+type ClassAlternative struct {
+	Hour      string `json:"hour" bson:"hour"`             // e.g., "08:00"
+	ClassType string `json:"class_type" bson:"class_type"` // e.g., "Open box"
+}
+
+// String describes the alternative for users, e.g. "Open box at 08:00"
+func (a ClassAlternative) String() string {
+	return a.ClassType + " at " + a.Hour
+}
+
+// AppliesOn reports whether the rule should book the class taking place on classDate
+func (c ClassBookingSchedule) AppliesOn(classDate time.Time) bool {
+	date := classDate.Format(DateLayout)
+
+	if c.PausedOn(classDate) {
+		return false
+	}
+	if c.StartDate != "" && date < c.StartDate {
+		return false
+	}
+	if c.EndDate != "" && date > c.EndDate {
+		return false
+	}
+	for _, skip := range c.SkipDates {
+		if skip == date {
+			return false
+		}
+	}
+	return true
+}
+
+// PausedOn reports whether the rule's pause covers the class taking place on classDate
+func (c ClassBookingSchedule) PausedOn(classDate time.Time) bool {
+	return c.Paused && (c.PausedUntil == "" || classDate.Format(DateLayout) <= c.PausedUntil)
 }
 
 // BookingAttempt tracks booking attempts - NO sensitive data stored here
 // Use ChatID to lookup user credentials from User model when needed
 type BookingAttempt struct {
 	ID          string    `bson:"_id" json:"id"`
-	ChatID      int64     `bson:"chat_id" json:"chat_id"` // Only reference to user
-	Day         string    `bson:"day" json:"day"`
+	ChatID      int64     `bson:"chat_id" json:"chat_id"`                             // Only reference to user
+	ScheduleID  string    `bson:"schedule_id,omitempty" json:"schedule_id,omitempty"` // Rule that produced this attempt
+	ClassDate   string    `bson:"class_date,omitempty" json:"class_date,omitempty"`   // Date of the class (YYYY-MM-DD)
+	Day         Day       `bson:"day" json:"day"`
 	Hour        string    `bson:"hour" json:"hour"`
 	ClassType   string    `bson:"class_type" json:"class_type"`
-	Status      string    `bson:"status" json:"status"` // pending, success, failed, expired
+	Status      string    `bson:"status" json:"status"` // pending, active, success, failed, expired, skipped, cancelled, waitlisted, monitoring, rejected, paused
 	AttemptTime time.Time `bson:"attempt_time" json:"attempt_time"`
 	ErrorMsg    string    `bson:"error_msg,omitempty" json:"error_msg,omitempty"`
 	RetryCount  int       `bson:"retry_count" json:"retry_count"`
-	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+	// Alternatives of the rule, and the one booked instead of the class, if any
+	Alternatives      []ClassAlternative `bson:"alternatives,omitempty" json:"alternatives,omitempty"`
+	BookedAlternative *ClassAlternative  `bson:"booked_alternative,omitempty" json:"booked_alternative,omitempty"`
+	// Timing measured by the booking run
+	ClockSkewMs  int64     `bson:"clock_skew_ms,omitempty" json:"clock_skew_ms,omitempty"` // Server clock minus local clock
+	LatencyMs    int64     `bson:"latency_ms,omitempty" json:"latency_ms,omitempty"`       // From the window opening to the booking confirmation
+	BookingTries int       `bson:"booking_tries,omitempty" json:"booking_tries,omitempty"` // Booking attempts fired during the burst
+	CreatedAt    time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `bson:"updated_at" json:"updated_at"`
 }
 
 // BookingWindow represents when booking becomes available
 type BookingWindow struct {
-	Day           string        `json:"day"`
-	Hour          string        `json:"hour"`
-	ClassType     string        `json:"class_type"`
-	OpensAt       time.Time     `json:"opens_at"`       // When booking opens
-	TimeRemaining time.Duration `json:"time_remaining"` // Time until booking opens
-	IsOpen        bool          `json:"is_open"`        // Whether booking is currently open
+	Day           Day                `json:"day"`
+	Hour          string             `json:"hour"`
+	ClassType     string             `json:"class_type"`
+	Alternatives  []ClassAlternative `json:"alternatives,omitempty"` // Classes of the same day to fall back to
+	ClassStart    time.Time          `json:"class_start"`            // When the class starts
+	OpensAt       time.Time          `json:"opens_at"`               // When booking opens
+	TimeRemaining time.Duration      `json:"time_remaining"`         // Time until booking opens
+	IsOpen        bool               `json:"is_open"`                // Whether booking is currently open
 }
 
 // Helper methods for session management
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 
 type MemoryStorage struct {
 	users    map[int64]models.User
+	sessions map[int64]models.UserSession
 	bookings map[string]models.BookingAttempt
 	mu       sync.RWMutex
 }
@@ -18,6 +20,7 @@ type MemoryStorage struct {
 func NewMemoryStorage() *MemoryStorage {
 	return &MemoryStorage{
 		users:    make(map[int64]models.User),
+		sessions: make(map[int64]models.UserSession),
 		bookings: make(map[string]models.BookingAttempt),
 	}
 }
@@ -39,6 +42,18 @@ func (m *MemoryStorage) GetUser(ctx context.Context, chatID int64) (models.User,
 	return user, exists
 }
 
+func (m *MemoryStorage) GetAllUsers(ctx context.Context) ([]models.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	users := make([]models.User, 0, len(m.users))
+	for _, user := range m.users {
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
 func (m *MemoryStorage) SaveClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -67,6 +82,49 @@ func (m *MemoryStorage) SaveClassBookingSchedule(ctx context.Context, chatID int
 	return nil
 }
 
+func (m *MemoryStorage) UpdateClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, exists := m.users[chatID]
+	if !exists {
+		return fmt.Errorf("user with chat ID %d not found", chatID)
+	}
+
+	i := slices.IndexFunc(user.ClassBookingSchedules, func(existingClass models.ClassBookingSchedule) bool {
+		return existingClass.ID == class.ID
+	})
+	if i < 0 {
+		return fmt.Errorf("class booking schedule %s of user %d not found", class.ID, chatID)
+	}
+
+	// Work on a copy so schedules handed out earlier are not modified
+	user.ClassBookingSchedules = slices.Clone(user.ClassBookingSchedules)
+	user.ClassBookingSchedules[i] = class
+	user.UpdatedAt = time.Now()
+	m.users[chatID] = user
+	return nil
+}
+
+func (m *MemoryStorage) DeleteClassBookingSchedule(ctx context.Context, chatID int64, scheduleID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, exists := m.users[chatID]
+	if !exists {
+		return fmt.Errorf("user with chat ID %d not found", chatID)
+	}
+
+	// Work on a copy so schedules handed out earlier are not modified
+	user.ClassBookingSchedules = slices.DeleteFunc(slices.Clone(user.ClassBookingSchedules), func(class models.ClassBookingSchedule) bool {
+		return class.ID == scheduleID
+	})
+
+	user.UpdatedAt = time.Now()
+	m.users[chatID] = user
+	return nil
+}
+
 func (m *MemoryStorage) GetClassBookingSchedules(ctx context.Context, chatID int64) ([]models.ClassBookingSchedule, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -79,6 +137,41 @@ func (m *MemoryStorage) GetClassBookingSchedules(ctx context.Context, chatID int
 	return user.ClassBookingSchedules, true
 }
 
+// UserSession methods
+func (m *MemoryStorage) SaveUserSession(ctx context.Context, session models.UserSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session.UpdatedAt = time.Now()
+	m.sessions[session.ChatID] = session
+	return nil
+}
+
+func (m *MemoryStorage) GetUserSession(ctx context.Context, chatID int64) (models.UserSession, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session, exists := m.sessions[chatID]
+	return session, exists
+}
+
+func (m *MemoryStorage) InvalidateUserSession(ctx context.Context, chatID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// An invalid session is kept even if none was stored, so the user's old
+	// session cookie isn't tried either
+	session, exists := m.sessions[chatID]
+	if !exists {
+		session = models.UserSession{ChatID: chatID, CreatedAt: time.Now()}
+	}
+
+	session.IsValid = false
+	session.UpdatedAt = time.Now()
+	m.sessions[chatID] = session
+	return nil
+}
+
 // BookingAttempt methods
 func (m *MemoryStorage) SaveBookingAttempt(ctx context.Context, attempt models.BookingAttempt) error {
 	m.mu.Lock()
@@ -89,6 +182,14 @@ func (m *MemoryStorage) SaveBookingAttempt(ctx context.Context, attempt models.B
 	return nil
 }
 
+func (m *MemoryStorage) GetBookingAttempt(ctx context.Context, attemptID string) (models.BookingAttempt, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	booking, exists := m.bookings[attemptID]
+	return booking, exists
+}
+
 func (m *MemoryStorage) GetAllPendingBookings(ctx context.Context) ([]models.BookingAttempt, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -103,6 +204,20 @@ func (m *MemoryStorage) GetAllPendingBookings(ctx context.Context) ([]models.Boo
 	return pending, nil
 }
 
+func (m *MemoryStorage) GetBookingsByStatus(ctx context.Context, status string) ([]models.BookingAttempt, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var bookings []models.BookingAttempt
+	for _, booking := range m.bookings {
+		if booking.Status == status {
+			bookings = append(bookings, booking)
+		}
+	}
+
+	return bookings, nil
+}
+
 func (m *MemoryStorage) UpdateBookingStatus(ctx context.Context, attemptID string, status string, errorMsg string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -119,3 +234,20 @@ func (m *MemoryStorage) UpdateBookingStatus(ctx context.Context, attemptID strin
 
 	return nil
 }
+
+func (m *MemoryStorage) UpdateBookingRetry(ctx context.Context, attemptID string, retryCount int, errorMsg string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	booking, exists := m.bookings[attemptID]
+	if !exists {
+		return fmt.Errorf("booking attempt %s not found", attemptID)
+	}
+
+	booking.RetryCount = retryCount
+	booking.ErrorMsg = errorMsg
+	booking.UpdatedAt = time.Now()
+	m.bookings[attemptID] = booking
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
@@ -16,6 +17,7 @@ type MongoStorage struct {
 	client             *mongo.Client
 	database           *mongo.Database
 	usersCollection    *mongo.Collection
+	sessionsCollection *mongo.Collection
 	bookingsCollection *mongo.Collection
 }
 
@@ -27,12 +29,14 @@ func NewMongoStorage(uri, dbName string) (*MongoStorage, error) {
 
 	database := client.Database(dbName)
 	usersCollection := database.Collection("users")
+	sessionsCollection := database.Collection("sessions")
 	bookingsCollection := database.Collection("booking_attempts")
 
 	return &MongoStorage{
 		client:             client,
 		database:           database,
 		usersCollection:    usersCollection,
+		sessionsCollection: sessionsCollection,
 		bookingsCollection: bookingsCollection,
 	}, nil
 }
@@ -70,6 +74,21 @@ func (m *MongoStorage) GetUser(ctx context.Context, chatID int64) (models.User,
 	return user, true
 }
 
+func (m *MongoStorage) GetAllUsers(ctx context.Context) ([]models.User, error) {
+	cursor, err := m.usersCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
 func (m *MongoStorage) SaveClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
 	user, exists := m.GetUser(ctx, chatID)
 	if !exists {
@@ -93,6 +112,38 @@ func (m *MongoStorage) SaveClassBookingSchedule(ctx context.Context, chatID int6
 	return m.SaveUser(ctx, user)
 }
 
+func (m *MongoStorage) UpdateClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
+	result, err := m.usersCollection.UpdateOne(
+		ctx,
+		bson.M{"chat_id": chatID, "class_booking_schedules.id": class.ID},
+		bson.M{"$set": bson.M{
+			"class_booking_schedules.$": class,
+			"updated_at":                time.Now(),
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update class booking schedule: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("class booking schedule %s of user %d not found", class.ID, chatID)
+	}
+
+	return nil
+}
+
+func (m *MongoStorage) DeleteClassBookingSchedule(ctx context.Context, chatID int64, scheduleID string) error {
+	user, exists := m.GetUser(ctx, chatID)
+	if !exists {
+		return fmt.Errorf("user with chat ID %d not found", chatID)
+	}
+
+	user.ClassBookingSchedules = slices.DeleteFunc(user.ClassBookingSchedules, func(class models.ClassBookingSchedule) bool {
+		return class.ID == scheduleID
+	})
+
+	return m.SaveUser(ctx, user)
+}
+
 func (m *MongoStorage) GetClassBookingSchedules(ctx context.Context, chatID int64) ([]models.ClassBookingSchedule, bool) {
 	user, exists := m.GetUser(ctx, chatID)
 	if !exists {
@@ -103,6 +154,49 @@ func (m *MongoStorage) GetClassBookingSchedules(ctx context.Context, chatID int6
 }
 
 // BookingAttempt methods
+func (m *MongoStorage) SaveUserSession(ctx context.Context, session models.UserSession) error {
+	session.UpdatedAt = time.Now()
+
+	_, err := m.sessionsCollection.ReplaceOne(
+		ctx,
+		bson.M{"chat_id": session.ChatID},
+		session,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save user session: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MongoStorage) GetUserSession(ctx context.Context, chatID int64) (models.UserSession, bool) {
+	var session models.UserSession
+	err := m.sessionsCollection.FindOne(ctx, bson.M{"chat_id": chatID}).Decode(&session)
+	if err != nil {
+		return models.UserSession{}, false
+	}
+
+	return session, true
+}
+
+func (m *MongoStorage) InvalidateUserSession(ctx context.Context, chatID int64) error {
+	update := bson.M{
+		"$set": bson.M{
+			"is_valid":   false,
+			"updated_at": time.Now(),
+		},
+	}
+
+	// Upserted so the user's old session cookie isn't tried either
+	_, err := m.sessionsCollection.UpdateOne(ctx, bson.M{"chat_id": chatID}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to invalidate user session: %w", err)
+	}
+
+	return nil
+}
+
 func (m *MongoStorage) SaveBookingAttempt(ctx context.Context, attempt models.BookingAttempt) error {
 	attempt.UpdatedAt = time.Now()
 
@@ -119,6 +213,16 @@ func (m *MongoStorage) SaveBookingAttempt(ctx context.Context, attempt models.Bo
 	return nil
 }
 
+func (m *MongoStorage) GetBookingAttempt(ctx context.Context, attemptID string) (models.BookingAttempt, bool) {
+	var attempt models.BookingAttempt
+	err := m.bookingsCollection.FindOne(ctx, bson.M{"_id": attemptID}).Decode(&attempt)
+	if err != nil {
+		return models.BookingAttempt{}, false
+	}
+
+	return attempt, true
+}
+
 func (m *MongoStorage) GetAllPendingBookings(ctx context.Context) ([]models.BookingAttempt, error) {
 	cursor, err := m.bookingsCollection.Find(ctx, bson.M{"status": "pending"})
 	if err != nil {
@@ -134,6 +238,21 @@ func (m *MongoStorage) GetAllPendingBookings(ctx context.Context) ([]models.Book
 	return bookings, nil
 }
 
+func (m *MongoStorage) GetBookingsByStatus(ctx context.Context, status string) ([]models.BookingAttempt, error) {
+	cursor, err := m.bookingsCollection.Find(ctx, bson.M{"status": status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s bookings: %w", status, err)
+	}
+	defer cursor.Close(ctx)
+
+	var bookings []models.BookingAttempt
+	if err := cursor.All(ctx, &bookings); err != nil {
+		return nil, fmt.Errorf("failed to decode booking attempts: %w", err)
+	}
+
+	return bookings, nil
+}
+
 func (m *MongoStorage) UpdateBookingStatus(ctx context.Context, attemptID string, status string, errorMsg string) error {
 	update := bson.M{
 		"$set": bson.M{
@@ -154,3 +273,24 @@ func (m *MongoStorage) UpdateBookingStatus(ctx context.Context, attemptID string
 
 	return nil
 }
+
+func (m *MongoStorage) UpdateBookingRetry(ctx context.Context, attemptID string, retryCount int, errorMsg string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"retry_count": retryCount,
+			"error_msg":   errorMsg,
+			"updated_at":  time.Now(),
+		},
+	}
+
+	_, err := m.bookingsCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": attemptID},
+		update,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update booking retry: %w", err)
+	}
+
+	return nil
+}
@@ -147,6 +147,94 @@ func TestMongoStorage(t *testing.T) {
 		assert.Contains(t, schedules, class2)
 	})
 
+	t.Run("DeleteClassBookingSchedule", func(t *testing.T) {
+		// Given
+		storage, err := NewMongoStorage(uri, dbName)
+		require.NoError(t, err)
+		defer storage.Close()
+
+		user := models.User{
+			ChatID:          790,
+			IsAuthenticated: true,
+			Email:           "test@example.com",
+			Password:        "password123",
+		}
+		err = storage.SaveUser(ctx, user)
+		require.NoError(t, err)
+
+		class1 := models.ClassBookingSchedule{
+			ID:        "Monday-10:00-WOD",
+			ClassType: "WOD",
+			Day:       "Monday",
+			Hour:      "10:00",
+		}
+		class2 := models.ClassBookingSchedule{
+			ID:        "Tuesday-11:00-Open",
+			ClassType: "Open",
+			Day:       "Tuesday",
+			Hour:      "11:00",
+		}
+		require.NoError(t, storage.SaveClassBookingSchedule(ctx, user.ChatID, class1))
+		require.NoError(t, storage.SaveClassBookingSchedule(ctx, user.ChatID, class2))
+
+		// When
+		err = storage.DeleteClassBookingSchedule(ctx, user.ChatID, class1.ID)
+
+		// Then
+		require.NoError(t, err)
+		schedules, exists := storage.GetClassBookingSchedules(ctx, user.ChatID)
+		assert.True(t, exists)
+		assert.Equal(t, []models.ClassBookingSchedule{class2}, schedules)
+
+		err = storage.DeleteClassBookingSchedule(ctx, 999, class2.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("UpdateClassBookingSchedule", func(t *testing.T) {
+		// Given
+		storage, err := NewMongoStorage(uri, dbName)
+		require.NoError(t, err)
+		defer storage.Close()
+
+		user := models.User{
+			ChatID:          791,
+			IsAuthenticated: true,
+			Email:           "test@example.com",
+			Password:        "password123",
+		}
+		err = storage.SaveUser(ctx, user)
+		require.NoError(t, err)
+
+		class1 := models.ClassBookingSchedule{
+			ID:        "Monday-10:00-WOD",
+			ClassType: "WOD",
+			Day:       "Monday",
+			Hour:      "10:00",
+		}
+		class2 := models.ClassBookingSchedule{
+			ID:        "Tuesday-11:00-Open",
+			ClassType: "Open",
+			Day:       "Tuesday",
+			Hour:      "11:00",
+		}
+		require.NoError(t, storage.SaveClassBookingSchedule(ctx, user.ChatID, class1))
+		require.NoError(t, storage.SaveClassBookingSchedule(ctx, user.ChatID, class2))
+
+		// When
+		class1.Paused = true
+		class1.PausedUntil = "2025-08-31"
+		err = storage.UpdateClassBookingSchedule(ctx, user.ChatID, class1)
+
+		// Then
+		require.NoError(t, err)
+		schedules, exists := storage.GetClassBookingSchedules(ctx, user.ChatID)
+		assert.True(t, exists)
+		assert.Equal(t, []models.ClassBookingSchedule{class1, class2}, schedules)
+
+		err = storage.UpdateClassBookingSchedule(ctx, user.ChatID, models.ClassBookingSchedule{ID: "Friday-07:00-WOD"})
+		assert.Error(t, err)
+	})
+
 	t.Run("SaveClassBookingScheduleForNonExistentUser", func(t *testing.T) {
 		// Given
 		storage, err := NewMongoStorage(uri, dbName)
@@ -181,4 +269,119 @@ func TestMongoStorage(t *testing.T) {
 		assert.False(t, exists)
 		assert.Nil(t, schedules)
 	})
+
+	t.Run("SaveAndGetBookingAttempt", func(t *testing.T) {
+		// Given
+		storage, err := NewMongoStorage(uri, dbName)
+		require.NoError(t, err)
+		defer storage.Close()
+
+		attempt := models.BookingAttempt{
+			ID:         "123-Monday-10:00-WOD-2025-08-18",
+			ChatID:     123,
+			ScheduleID: "Monday-10:00-WOD",
+			ClassDate:  "2025-08-18",
+			Day:        "Monday",
+			Hour:       "10:00",
+			ClassType:  "WOD",
+			Status:     "pending",
+		}
+
+		// When
+		err = storage.SaveBookingAttempt(ctx, attempt)
+		require.NoError(t, err)
+
+		// Then
+		got, exists := storage.GetBookingAttempt(ctx, attempt.ID)
+		assert.True(t, exists)
+		assert.Equal(t, attempt.ScheduleID, got.ScheduleID)
+		assert.Equal(t, attempt.ClassDate, got.ClassDate)
+
+		_, exists = storage.GetBookingAttempt(ctx, "missing")
+		assert.False(t, exists)
+	})
+
+	t.Run("SaveGetAndInvalidateUserSession", func(t *testing.T) {
+		// Given
+		storage, err := NewMongoStorage(uri, dbName)
+		require.NoError(t, err)
+		defer storage.Close()
+
+		expires := time.Now().Add(24 * time.Hour).Truncate(time.Millisecond)
+		session := models.NewUserSession(123, []*http.Cookie{
+			{Name: ".WBAuth", Value: "session", Domain: "wodbuster.com", Path: "/", Expires: expires, HttpOnly: true},
+			{Name: "ASP.NET_SessionId", Value: "aspnet", Domain: "wodbuster.com", Path: "/"},
+		}, time.Now())
+
+		// When
+		require.NoError(t, storage.SaveUserSession(ctx, session))
+
+		// Then
+		got, exists := storage.GetUserSession(ctx, 123)
+		assert.True(t, exists)
+		assert.True(t, got.Valid(time.Now()))
+		require.Len(t, got.HTTPCookies(), 2)
+		assert.Equal(t, "ASP.NET_SessionId", got.HTTPCookies()[1].Name)
+
+		require.NoError(t, storage.InvalidateUserSession(ctx, 123))
+		got, exists = storage.GetUserSession(ctx, 123)
+		assert.True(t, exists)
+		assert.False(t, got.Valid(time.Now()))
+
+		_, exists = storage.GetUserSession(ctx, 999)
+		assert.False(t, exists)
+	})
+
+	t.Run("UpdateBookingRetry", func(t *testing.T) {
+		// Given
+		storage, err := NewMongoStorage(uri, dbName)
+		require.NoError(t, err)
+		defer storage.Close()
+
+		attempt := models.BookingAttempt{ID: "123-Tuesday-10:00-WOD-2025-08-19", ChatID: 123, Status: "active"}
+		require.NoError(t, storage.SaveBookingAttempt(ctx, attempt))
+
+		// When
+		err = storage.UpdateBookingRetry(ctx, attempt.ID, 2, "navigation timed out")
+		require.NoError(t, err)
+
+		// Then
+		got, exists := storage.GetBookingAttempt(ctx, attempt.ID)
+		assert.True(t, exists)
+		assert.Equal(t, 2, got.RetryCount)
+		assert.Equal(t, "navigation timed out", got.ErrorMsg)
+		assert.Equal(t, "active", got.Status)
+	})
+
+	t.Run("GetBookingsByStatus", func(t *testing.T) {
+		// Given
+		storage, err := NewMongoStorage(uri, dbName)
+		require.NoError(t, err)
+		defer storage.Close()
+
+		require.NoError(t, storage.SaveBookingAttempt(ctx, models.BookingAttempt{ID: "123-w1-2025-08-20", ChatID: 123, Status: "monitoring"}))
+		require.NoError(t, storage.SaveBookingAttempt(ctx, models.BookingAttempt{ID: "123-w2-2025-08-21", ChatID: 123, Status: "success"}))
+
+		// When
+		monitoring, err := storage.GetBookingsByStatus(ctx, "monitoring")
+		require.NoError(t, err)
+
+		// Then
+		require.Len(t, monitoring, 1)
+		assert.Equal(t, "123-w1-2025-08-20", monitoring[0].ID)
+	})
+
+	t.Run("GetAllUsers", func(t *testing.T) {
+		// Given
+		storage, err := NewMongoStorage(uri, dbName)
+		require.NoError(t, err)
+		defer storage.Close()
+
+		// When
+		users, err := storage.GetAllUsers(ctx)
+
+		// Then
+		require.NoError(t, err)
+		assert.NotEmpty(t, users)
+	})
 }
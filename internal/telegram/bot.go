@@ -2,8 +2,11 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
@@ -19,21 +22,36 @@ type BotManager interface {
 	GetUser(ctx context.Context, chatID int64) (models.User, bool)
 	LogInAndSave(ctx context.Context, chatID int64, email, password string) error
 	ScheduleBookClass(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error
-	GetActiveBookings() map[int64]*usecase.BookingContext
-	CancelBooking(chatID int64) bool
-	TestUserSession(ctx context.Context, chatID int64) error
+	SkipScheduledClass(ctx context.Context, chatID int64, scheduleID, classDate string) error
+	RemoveClass(ctx context.Context, chatID int64, day models.Day, hour, classType string) (bool, error)
+	DeleteSchedule(ctx context.Context, chatID int64, scheduleID string) error
+	EditScheduleDates(ctx context.Context, chatID int64, scheduleID, startDate, endDate string) error
+	PauseSchedules(ctx context.Context, chatID int64, scheduleID, until string) (int, error)
+	ResumeSchedules(ctx context.Context, chatID int64, scheduleID string) (int, error)
+	ResolveClassType(ctx context.Context, chatID int64, input string) (string, error)
+	ClassTypes() []string
+	GetTimetable(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error)
+	GetActiveBookings(chatID int64) []usecase.BookingContext
+	CancelBooking(chatID int64, attemptID string) bool
+	GetBookingLimits(ctx context.Context, chatID int64) (models.BookingLimits, error)
+	SetBookingLimits(ctx context.Context, chatID int64, limits *models.BookingLimits) error
+	TestUserSession(ctx context.Context, chatID int64) (usecase.SessionStatus, error)
 	GetScheduleInfo() string
 }
 
 type Bot struct {
-	api          *tgbotapi.BotAPI
-	logger       *slog.Logger
-	manager      BotManager
-	loginHandler *handlers.LoginHandler
-	bookHandler  *handlers.BookingHandler
-	rateLimiter  *utils.RateLimiter
-	stopChan     chan struct{}
-	// removeHandler *handlers.RemoveHandler
+	api               *tgbotapi.BotAPI
+	logger            *slog.Logger
+	manager           BotManager
+	loginHandler      *handlers.LoginHandler
+	bookHandler       *handlers.BookingHandler
+	skipHandler       *handlers.SkipHandler
+	removeHandler     *handlers.RemoveHandler
+	myScheduleHandler *handlers.MyScheduleHandler
+	classesHandler    *handlers.ClassesHandler
+	bookingWizard     *handlers.BookingWizard
+	rateLimiter       *utils.RateLimiter
+	stopChan          chan struct{}
 }
 
 func New(token string, manager BotManager, logger *slog.Logger) (*Bot, error) {
@@ -58,12 +76,17 @@ func New(token string, manager BotManager, logger *slog.Logger) (*Bot, error) {
 	rateLimiter := utils.NewRateLimiter(2*time.Second, 5)
 
 	return &Bot{
-		api:          api,
-		logger:       logger,
-		manager:      manager,
-		loginHandler: handlers.NewLoginHandler(api, manager),
-		bookHandler:  handlers.NewBookingHandler(api, manager),
-		rateLimiter:  rateLimiter,
+		api:               api,
+		logger:            logger,
+		manager:           manager,
+		loginHandler:      handlers.NewLoginHandler(api, manager),
+		bookHandler:       handlers.NewBookingHandler(api, manager),
+		skipHandler:       handlers.NewSkipHandler(api, manager),
+		removeHandler:     handlers.NewRemoveHandler(api, manager),
+		myScheduleHandler: handlers.NewMyScheduleHandler(api, manager),
+		classesHandler:    handlers.NewClassesHandler(api, manager),
+		bookingWizard:     handlers.NewBookingWizard(api, manager),
+		rateLimiter:       rateLimiter,
 	}, nil
 }
 
@@ -92,7 +115,7 @@ func (b *Bot) Start() error {
 	for {
 		select {
 		case update := <-updates:
-			if update.Message == nil {
+			if update.Message == nil && update.CallbackQuery == nil {
 				continue
 			}
 			go b.handleUpdate(update)
@@ -111,17 +134,41 @@ func (b *Bot) Stop() error {
 }
 
 func (b *Bot) handleUpdate(update tgbotapi.Update) {
-	if update.Message == nil {
+	chat := update.FromChat()
+	if chat == nil {
+		return
+	}
+
+	// A password is deleted before anything else, even when it looks like a
+	// command or is sent too quickly
+	if update.Message != nil && b.loginHandler.HandlePassword(update) {
 		return
 	}
 
 	// Check rate limit
-	if !b.rateLimiter.Allow(update.Message.Chat.ID) {
-		b.sendMessage(update.Message.Chat.ID,
+	if !b.rateLimiter.Allow(chat.ID) {
+		b.sendMessage(chat.ID,
 			"You're sending commands too quickly. Please wait a moment before trying again.")
 		return
 	}
 
+	if update.CallbackQuery != nil {
+		b.handleCallback(update)
+		return
+	}
+	if update.Message == nil {
+		return
+	}
+
+	// A message that isn't a command may be the email or password asked by /login
+	if !update.Message.IsCommand() && b.loginHandler.InProgress(chat.ID) {
+		b.loginHandler.HandleReply(update)
+		return
+	}
+	if update.Message.Command() != "login" {
+		b.loginHandler.Cancel(chat.ID)
+	}
+
 	switch update.Message.Command() {
 	case "start":
 		b.sendMessage(update.Message.Chat.ID,
@@ -131,25 +178,54 @@ func (b *Bot) handleUpdate(update tgbotapi.Update) {
 	case "login":
 		b.loginHandler.Handle(update)
 	case "book":
+		// Without arguments, guide the user through the booking with buttons
+		if update.Message.CommandArguments() == "" {
+			b.bookingWizard.Start(update)
+			return
+		}
 		b.bookHandler.Handle(update)
+	case "skip":
+		b.skipHandler.Handle(update)
+	case "remove":
+		b.removeHandler.Handle(update)
+	case "myschedule":
+		b.myScheduleHandler.Handle(update)
+	case "classes":
+		b.classesHandler.Handle(update)
 	case "status":
 		b.handleStatus(update)
 	case "test":
 		b.handleTestSession(update)
 	case "active":
 		b.handleActiveBookings(update)
+	case "cancel":
+		b.handleCancelBooking(update)
 	case "schedule":
 		b.handleSchedule(update)
+	case "limits":
+		b.handleLimits(update)
 	case "help":
 		b.sendMessage(update.Message.Chat.ID,
 			"🤖 **WODBuster Bot Commands**\n\n"+
 				"**Authentication:**\n"+
-				"• `/login email password` - Login to WODBuster\n"+
+				"• `/login` - Login to WODBuster, sending your email and then your password\n"+
 				"• `/test` - Test your current session\n\n"+
 				"**Booking:**\n"+
-				"• `/book day hour class-type` - Schedule a class\n"+
+				"• `/classes [day] [this|next]` - Show the gym's classes of this or next week\n"+
+				"  Example: `/classes Monday next`\n"+
+				"• `/book` - Pick a day and a class to book every week\n"+
+				"• `/book day hour class-type [start-date] [end-date]` - Book a class every week\n"+
 				"  Example: `/book Monday 10:00 wod`\n"+
+				"  Add fallbacks with `or`: `/book Monday 07:00 wod or 08:00 wod`\n"+
+				"• `/skip day hour class-type date` - Skip one week of a scheduled class\n"+
+				"  Example: `/skip Monday 10:00 wod 2025-08-18`\n"+
+				"• `/remove day hour class-type` - Cancel a booked class and its weekly schedule\n"+
+				"  Example: `/remove Monday 10:00 wod`\n"+
+				"• `/myschedule` - List your booking rules with their IDs\n"+
+				"• `/myschedule delete|edit|pause|resume id` - Manage a rule, e.g. `/myschedule pause all 2025-08-31` for holidays\n"+
 				"• `/active` - Show active booking attempts\n"+
+				"• `/cancel attempt-id` - Stop an active booking attempt listed by `/active`\n"+
+				"• `/limits [per-week] [per-day]` - Show or set how many classes are booked for you, 0 for no limit\n"+
 				"• `/status` - Show your account status\n"+
 				"• `/schedule` - Show next booking schedule\n\n"+
 				"**Other:**\n"+
@@ -157,13 +233,31 @@ func (b *Bot) handleUpdate(update tgbotapi.Update) {
 				"**How it works:**\n"+
 				"1. Login with your WODBuster credentials\n"+
 				"2. Schedule classes with `/book`\n"+
-				"3. Every Saturday at 11:55, the bot will automatically book your classes when they open at 12:00!")
+				"3. The bot automatically books your classes as soon as their booking opens. Use /schedule to see the next run!")
 	default:
 		b.sendMessage(update.Message.Chat.ID,
 			"I don't know that command. Use /help to see available commands")
 	}
 }
 
+// handleCallback routes the callback query of an inline keyboard button to the
+// handler that sent the keyboard
+func (b *Bot) handleCallback(update tgbotapi.Update) {
+	query := update.CallbackQuery
+	if query.Message == nil {
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(query.Data, handlers.BookingWizardCallbackPrefix):
+		b.bookingWizard.HandleCallback(update)
+	default:
+		if _, err := b.api.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+			b.logger.Error("Failed to answer callback query", "error", err, "chat_id", query.Message.Chat.ID)
+		}
+	}
+}
+
 func (b *Bot) handleStatus(update tgbotapi.Update) {
 	ctx := context.Background()
 	chatID := update.Message.Chat.ID
@@ -189,7 +283,14 @@ func (b *Bot) handleStatus(update tgbotapi.Update) {
 	if scheduleCount > 0 {
 		message += "**Scheduled Classes:**\n"
 		for _, class := range user.ClassBookingSchedules {
-			message += "• " + class.Day + " " + class.Hour + " - " + class.ClassType + "\n"
+			message += "• " + class.Day.String() + " " + class.Hour + " - " + class.ClassType
+			if class.StartDate != "" || class.EndDate != "" {
+				message += " (" + class.StartDate + " → " + class.EndDate + ")"
+			}
+			message += "\n"
+			for _, alternative := range class.Alternatives {
+				message += "  or " + alternative.Hour + " - " + alternative.ClassType + "\n"
+			}
 		}
 	}
 
@@ -207,29 +308,124 @@ func (b *Bot) handleTestSession(update tgbotapi.Update) {
 
 	b.sendMessage(chatID, "🧪 Testing your session...")
 
-	if err := b.manager.TestUserSession(ctx, chatID); err != nil {
+	status, err := b.manager.TestUserSession(ctx, chatID)
+	if err != nil {
 		b.sendMessage(chatID, "❌ Session test failed: "+err.Error()+"\nPlease use /login to authenticate again.")
 		return
 	}
 
-	b.sendMessage(chatID, "✅ Your session is working correctly!")
+	b.sendMessage(chatID, sessionStatusMessage(status, time.Now()))
+}
+
+// sessionStatusMessage describes the probed session and how long it lasts
+func sessionStatusMessage(status usecase.SessionStatus, now time.Time) string {
+	if !status.Alive {
+		return "❌ Your session has expired and there is no stored password to log in again.\nPlease use /login to authenticate again."
+	}
+
+	message := "✅ Your session is working correctly!"
+	if status.Refreshed {
+		message = "✅ Your session had expired, so I logged in again with your stored credentials."
+	}
+	if status.ExpiresAt.IsZero() {
+		return message + "\nIt lasts until WODBuster ends it."
+	}
+	return message + fmt.Sprintf("\nIt expires in %s (%s).",
+		status.ExpiresAt.Sub(now).Round(time.Minute), status.ExpiresAt.Format("January 2, 2006 at 15:04 MST"))
 }
 
 func (b *Bot) handleActiveBookings(update tgbotapi.Update) {
 	chatID := update.Message.Chat.ID
-	activeBookings := b.manager.GetActiveBookings()
-
-	userBooking, hasActiveBooking := activeBookings[chatID]
-	if !hasActiveBooking {
+	activeBookings := b.manager.GetActiveBookings(chatID)
+	if len(activeBookings) == 0 {
 		b.sendMessage(chatID, "📭 You have no active booking attempts right now.")
 		return
 	}
 
-	message := "🚀 **Active Booking**\n\n" +
-		"Status: " + userBooking.Status + "\n" +
-		"Class: " + userBooking.BookingData.Day + " " + userBooking.BookingData.Hour + " - " + userBooking.BookingData.ClassType + "\n"
+	var message strings.Builder
+	message.WriteString("🚀 **Active Bookings**\n")
+	for _, booking := range activeBookings {
+		fmt.Fprintf(&message, "\n• %s %s - %s (%s)\n  `%s`\n",
+			booking.BookingData.Day, booking.BookingData.Hour, booking.BookingData.ClassType, booking.Status, booking.AttemptID)
+	}
+	message.WriteString("\nUse `/cancel attempt-id` to stop one of them.")
+
+	b.sendMessage(chatID, message.String())
+}
 
-	b.sendMessage(chatID, message)
+func (b *Bot) handleCancelBooking(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+
+	attemptID := strings.TrimSpace(update.Message.CommandArguments())
+	if attemptID == "" {
+		b.sendMessage(chatID, "Usage: `/cancel attempt-id`, with an ID listed by /active")
+		return
+	}
+
+	if !b.manager.CancelBooking(chatID, attemptID) {
+		b.sendMessage(chatID, "❌ No active booking attempt with that ID. Use /active to list them.")
+		return
+	}
+	b.sendMessage(chatID, "🛑 Booking attempt cancelled.")
+}
+
+func (b *Bot) handleLimits(update tgbotapi.Update) {
+	ctx := context.Background()
+	chatID := update.Message.Chat.ID
+
+	args := strings.Fields(update.Message.CommandArguments())
+	switch {
+	case len(args) == 0:
+	case len(args) == 1 && strings.EqualFold(args[0], "default"):
+		if err := b.manager.SetBookingLimits(ctx, chatID, nil); err != nil {
+			b.sendLimitsError(chatID, err)
+			return
+		}
+	case len(args) <= 2:
+		var values [2]int
+		for i, arg := range args {
+			value, err := strconv.Atoi(arg)
+			if err != nil || value < 0 {
+				b.sendMessage(chatID, "Usage: `/limits per-week per-day`, 0 for no limit, or `/limits default`\n  Example: `/limits 3 1`")
+				return
+			}
+			values[i] = value
+		}
+		if err := b.manager.SetBookingLimits(ctx, chatID, &models.BookingLimits{MaxPerWeek: values[0], MaxPerDay: values[1]}); err != nil {
+			b.sendLimitsError(chatID, err)
+			return
+		}
+	default:
+		b.sendMessage(chatID, "Usage: `/limits per-week per-day`, 0 for no limit, or `/limits default`\n  Example: `/limits 3 1`")
+		return
+	}
+
+	limits, err := b.manager.GetBookingLimits(ctx, chatID)
+	if err != nil {
+		b.sendLimitsError(chatID, err)
+		return
+	}
+
+	limit := func(classes int) string {
+		if classes == 0 {
+			return "no limit"
+		}
+		return strconv.Itoa(classes)
+	}
+	b.sendMessage(chatID, "📏 **Your Booking Limits**\n\n"+
+		"Classes per week: "+limit(limits.MaxPerWeek)+"\n"+
+		"Classes per day: "+limit(limits.MaxPerDay)+"\n\n"+
+		"Overlapping classes are never both booked.")
+}
+
+// sendLimitsError tells the user their limits could not be read or saved
+func (b *Bot) sendLimitsError(chatID int64, err error) {
+	if errors.Is(err, usecase.ErrUserNotFound) {
+		b.sendMessage(chatID, "❌ You are not registered. Please use /login first.")
+		return
+	}
+	b.logger.Error("Failed to handle booking limits", "error", err, "chat_id", chatID)
+	b.sendMessage(chatID, "❌ Failed to update your limits. Please try again later.")
 }
 
 func (b *Bot) handleSchedule(update tgbotapi.Update) {
@@ -240,11 +436,27 @@ func (b *Bot) handleSchedule(update tgbotapi.Update) {
 	b.sendMessage(chatID, message)
 }
 
-func (b *Bot) sendMessage(chatID int64, text string) {
-	msg := tgbotapi.NewMessage(chatID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
+// Notify sends a message the user did not ask for, e.g. about a booking made
+// in the background. The message is sent as plain text, since it may quote
+// error messages that would break Markdown.
+func (b *Bot) Notify(_ context.Context, chatID int64, message string) error {
+	if err := b.send(chatID, message, ""); err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	return nil
+}
 
-	if _, err := b.api.Send(msg); err != nil {
+func (b *Bot) sendMessage(chatID int64, text string) {
+	if err := b.send(chatID, text, tgbotapi.ModeMarkdown); err != nil {
 		b.logger.Error("Failed to send message", "error", err, "chat_id", chatID)
 	}
 }
+
+// send sends the text to the chat, parsed with the parse mode unless it is empty
+func (b *Bot) send(chatID int64, text, parseMode string) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = parseMode
+
+	_, err := b.api.Send(msg)
+	return err
+}
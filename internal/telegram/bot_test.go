@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/handlers"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTelegram answers the Bot API methods the bot calls and records them
+type fakeTelegram struct {
+	mu      sync.Mutex
+	methods []string
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := path.Base(r.URL.Path)
+	f.mu.Lock()
+	f.methods = append(f.methods, method)
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch method {
+	case "getMe":
+		io.WriteString(w, `{"ok":true,"result":{"id":1,"is_bot":true,"username":"wodbuster_bot"}}`)
+	case "deleteMessage":
+		io.WriteString(w, `{"ok":true,"result":true}`)
+	default:
+		io.WriteString(w, `{"ok":true,"result":{"message_id":1,"chat":{"id":123}}}`)
+	}
+}
+
+func (f *fakeTelegram) called(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, called := range f.methods {
+		if called == method {
+			count++
+		}
+	}
+	return count
+}
+
+func TestBot_HandleUpdatePasswordBeforeRateLimit(t *testing.T) {
+	const testChatID int64 = 123
+
+	telegram := &fakeTelegram{}
+	server := httptest.NewServer(telegram)
+	defer server.Close()
+
+	api, err := tgbotapi.NewBotAPIWithAPIEndpoint("token", server.URL+"/bot%s/%s")
+	require.NoError(t, err)
+
+	manager := NewMockBotManager(t)
+	manager.EXPECT().LogInAndSave(mock.Anything, testChatID, "testuser@email.com", "/start123").Return(nil).Once()
+
+	// Only /login and the email get through before the chat is throttled
+	bot := &Bot{
+		api:          api,
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		manager:      manager,
+		loginHandler: handlers.NewLoginHandler(api, manager),
+		rateLimiter:  utils.NewRateLimiter(time.Hour, 2),
+	}
+
+	message := func(id int, text string) tgbotapi.Update {
+		update := tgbotapi.Update{Message: &tgbotapi.Message{
+			MessageID: id,
+			Chat:      &tgbotapi.Chat{ID: testChatID, Type: "private"},
+			Text:      text,
+		}}
+		if strings.HasPrefix(text, "/") {
+			length := len(strings.Fields(text)[0])
+			update.Message.Entities = []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: length}}
+		}
+		return update
+	}
+
+	bot.handleUpdate(message(1, "/login"))
+	bot.handleUpdate(message(2, "testuser@email.com"))
+	require.True(t, bot.loginHandler.InProgress(testChatID))
+
+	// The password is throttled and looks like a command, it is read and
+	// deleted all the same
+	bot.handleUpdate(message(3, "/start123"))
+	assert.Equal(t, 1, telegram.called("deleteMessage"))
+	assert.False(t, bot.loginHandler.InProgress(testChatID))
+}
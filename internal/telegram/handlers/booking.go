@@ -2,20 +2,23 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 type BookingManager interface {
 	IsAuthenticated(ctx context.Context, chatID int64) bool
 	ScheduleBookClass(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error
+	ResolveClassType(ctx context.Context, chatID int64, input string) (string, error)
+	ClassTypes() []string
 }
 
 type BookingBotAPI interface {
@@ -42,8 +45,10 @@ func (h *BookingHandler) Handle(update tgbotapi.Update) {
 		return
 	}
 
-	args := strings.Split(update.Message.Text, " ")
-	if len(args) != 4 {
+	// Alternatives follow the rule, each after an "or": 08:00 wod or 07:00 open
+	segments := splitAlternatives(strings.Split(update.Message.Text, " "))
+	args := segments[0]
+	if len(args) < 4 || len(args) > 6 {
 		h.sendMessage(update.Message.Chat.ID,
 			"Please provide day and hour: /book <day> <hour> <class-type> (e.g., /book Monday 10:00 wod)")
 		return
@@ -55,9 +60,10 @@ func (h *BookingHandler) Handle(update tgbotapi.Update) {
 	rawClassType := utils.SanitizeInput(args[3])
 
 	// Validate inputs
-	if err := utils.ValidateDay(rawDay); err != nil {
+	day, err := models.ParseDay(rawDay, time.Now())
+	if err != nil {
 		h.sendMessage(update.Message.Chat.ID,
-			"Invalid day. Please use: Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday")
+			"Invalid day. Please use a day name in English or Spanish (e.g., Monday, mon, lunes), today or tomorrow")
 		return
 	}
 
@@ -67,24 +73,51 @@ func (h *BookingHandler) Handle(update tgbotapi.Update) {
 		return
 	}
 
-	if err := utils.ValidateClassType(rawClassType); err != nil {
-		h.sendMessage(update.Message.Chat.ID,
-			"Invalid class type. Available types: wod, open, strength, cardio, yoga")
-		return
+	// Optional date range of the weekly rule
+	var startDate, endDate string
+	if len(args) > 4 {
+		startDate = utils.SanitizeInput(args[4])
+		if err := utils.ValidateDate(startDate); err != nil {
+			h.sendMessage(update.Message.Chat.ID,
+				"Invalid start date. Please use YYYY-MM-DD format (e.g., 2025-09-01)")
+			return
+		}
+	}
+	if len(args) > 5 {
+		endDate = utils.SanitizeInput(args[5])
+		if err := utils.ValidateDate(endDate); err != nil || endDate < startDate {
+			h.sendMessage(update.Message.Chat.ID,
+				"Invalid end date. Please use YYYY-MM-DD format, on or after the start date")
+			return
+		}
 	}
 
-	// Format inputs
-	caser := cases.Title(language.English)
-	day := caser.String(strings.ToLower(rawDay))
+	// Class types are booked by the exact name on the gym's timetable
+	classType, err := h.manager.ResolveClassType(ctx, update.Message.Chat.ID, rawClassType)
+	if err != nil {
+		h.sendMessage(update.Message.Chat.ID, invalidClassTypeMessage(h.manager.ClassTypes()))
+		return
+	}
 	hour := rawHour
-	classType := caser.String(strings.ToLower(rawClassType))
+
+	alternatives, ok := h.parseAlternatives(ctx, update.Message.Chat.ID, segments[1:])
+	if !ok {
+		return
+	}
 
 	if err := h.manager.ScheduleBookClass(ctx, update.Message.Chat.ID, models.ClassBookingSchedule{
-		ID:        fmt.Sprintf("%s-%s-%s", day, hour, classType),
-		Day:       day,
-		Hour:      hour,
-		ClassType: classType,
+		ID:           fmt.Sprintf("%s-%s-%s", day, hour, classType),
+		Day:          day,
+		Hour:         hour,
+		ClassType:    classType,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		Alternatives: alternatives,
 	}); err != nil {
+		if message, ok := limitsMessage(err); ok {
+			h.sendMessage(update.Message.Chat.ID, message)
+			return
+		}
 		h.sendMessage(update.Message.Chat.ID,
 			"Failed to book class. Please try again later.")
 
@@ -103,8 +136,71 @@ func (h *BookingHandler) Handle(update tgbotapi.Update) {
 	// 	return
 	// }
 
-	h.sendMessage(update.Message.Chat.ID,
-		fmt.Sprintf("Class scheduled successfully! %s at %s for %s", classType, hour, day))
+	message := fmt.Sprintf("Class scheduled successfully! %s at %s for %s, booked every week", classType, hour, day)
+	for _, alternative := range alternatives {
+		message += "\nOr else " + alternative.String()
+	}
+	h.sendMessage(update.Message.Chat.ID, message)
+}
+
+// splitAlternatives splits the command's arguments at every "or", the rule
+// first and then one segment per alternative
+func splitAlternatives(args []string) [][]string {
+	segments := [][]string{nil}
+	for _, arg := range args {
+		if strings.EqualFold(arg, "or") {
+			segments = append(segments, nil)
+			continue
+		}
+		segments[len(segments)-1] = append(segments[len(segments)-1], arg)
+	}
+	return segments
+}
+
+// parseAlternatives validates the "hour class-type" segments of the
+// alternatives, in order, and tells the user what is wrong with the first
+// invalid one
+func (h *BookingHandler) parseAlternatives(ctx context.Context, chatID int64, segments [][]string) ([]models.ClassAlternative, bool) {
+	var alternatives []models.ClassAlternative
+	for _, segment := range segments {
+		if len(segment) != 2 {
+			h.sendMessage(chatID,
+				"Please provide each alternative as <hour> <class-type> (e.g., /book Monday 07:00 wod or 08:00 wod)")
+			return nil, false
+		}
+
+		hour := utils.SanitizeInput(segment[0])
+		if err := utils.ValidateTime(hour); err != nil {
+			h.sendMessage(chatID,
+				"Invalid time format. Please use HH:MM format (e.g., 10:00)")
+			return nil, false
+		}
+		classType, err := h.manager.ResolveClassType(ctx, chatID, utils.SanitizeInput(segment[1]))
+		if err != nil {
+			h.sendMessage(chatID, invalidClassTypeMessage(h.manager.ClassTypes()))
+			return nil, false
+		}
+
+		alternatives = append(alternatives, models.ClassAlternative{Hour: hour, ClassType: classType})
+	}
+	return alternatives, true
+}
+
+// limitsMessage explains why a rule breaking the user's limits wasn't added,
+// and reports false for any other error
+func limitsMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, usecase.ErrOverlappingClass):
+		return fmt.Sprintf("Class not scheduled: %s.\nYou can't attend both, remove the other one first with /remove.", err), true
+	case errors.Is(err, usecase.ErrWeeklyLimitReached), errors.Is(err, usecase.ErrDailyLimitReached):
+		return fmt.Sprintf("Class not scheduled: %s.\nRemove another class with /remove, or change your limits with /limits.", err), true
+	}
+	return "", false
+}
+
+// invalidClassTypeMessage lists the class types of the gym's catalogue
+func invalidClassTypeMessage(classTypes []string) string {
+	return "Invalid class type. Available types: " + strings.Join(classTypes, ", ")
 }
 
 func (h *BookingHandler) sendMessage(chatID int64, text string) {
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/stretchr/testify/mock"
 )
@@ -23,6 +25,7 @@ func TestBookingHandler_Handle(t *testing.T) {
 			isAuth: true,
 			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
 				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
 				manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, models.ClassBookingSchedule{
 					ID:        "Monday-10:00-Wod",
 					Day:       "Monday",
@@ -32,6 +35,119 @@ func TestBookingHandler_Handle(t *testing.T) {
 				api.EXPECT().Send(mock.Anything).Return(tgbotapi.Message{}, nil)
 			},
 		},
+		{
+			name:   "successful booking with a spanish day",
+			input:  "/book Miércoles 19:30 wod",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, models.ClassBookingSchedule{
+					ID:        "Wednesday-19:30-Wod",
+					Day:       models.DayWednesday,
+					Hour:      "19:30",
+					ClassType: "Wod",
+				}).Return(nil)
+				api.EXPECT().Send(mock.Anything).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:   "successful booking with date range",
+			input:  "/book Monday 10:00 wod 2025-09-01 2025-12-22",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, models.ClassBookingSchedule{
+					ID:        "Monday-10:00-Wod",
+					Day:       "Monday",
+					Hour:      "10:00",
+					ClassType: "Wod",
+					StartDate: "2025-09-01",
+					EndDate:   "2025-12-22",
+				}).Return(nil)
+				api.EXPECT().Send(mock.Anything).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:   "successful booking with alternatives",
+			input:  "/book Monday 07:00 wod or 08:00 wod or 07:00 open",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "open").Return("Open box", nil)
+				manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, models.ClassBookingSchedule{
+					ID:        "Monday-07:00-Wod",
+					Day:       "Monday",
+					Hour:      "07:00",
+					ClassType: "Wod",
+					Alternatives: []models.ClassAlternative{
+						{Hour: "08:00", ClassType: "Wod"},
+						{Hour: "07:00", ClassType: "Open box"},
+					},
+				}).Return(nil)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Class scheduled successfully! Wod at 07:00 for Monday, booked every week\nOr else Wod at 08:00\nOr else Open box at 07:00"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:   "incomplete alternative",
+			input:  "/book Monday 07:00 wod or 08:00",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Please provide each alternative as <hour> <class-type> (e.g., /book Monday 07:00 wod or 08:00 wod)"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:   "weekly limit reached",
+			input:  "/book Friday 07:00 wod",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, mock.Anything).
+					Return(fmt.Errorf("%w: 2 per week", usecase.ErrWeeklyLimitReached))
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Class not scheduled: weekly class limit reached: 2 per week.\nRemove another class with /remove, or change your limits with /limits."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:   "overlapping class",
+			input:  "/book Monday 07:30 open",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "open").Return("Open box", nil)
+				manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, mock.Anything).
+					Return(fmt.Errorf("%w: Wod at 07:00", usecase.ErrOverlappingClass))
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Class not scheduled: class overlaps another scheduled class: Wod at 07:00.\nYou can't attend both, remove the other one first with /remove."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:   "end date before start date",
+			input:  "/book Monday 10:00 wod 2025-09-01 2025-08-01",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Invalid end date. Please use YYYY-MM-DD format, on or after the start date"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
 		{
 			name:   "not authenticated",
 			input:  "/book Monday 10:00 wod",
@@ -44,6 +160,48 @@ func TestBookingHandler_Handle(t *testing.T) {
 				})).Return(tgbotapi.Message{}, nil)
 			},
 		},
+		{
+			name:   "class type alias",
+			input:  "/book Friday 08:00 open",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "open").Return("Open box", nil)
+				manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, models.ClassBookingSchedule{
+					ID:        "Friday-08:00-Open box",
+					Day:       models.DayFriday,
+					Hour:      "08:00",
+					ClassType: "Open box",
+				}).Return(nil)
+				api.EXPECT().Send(mock.Anything).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:   "unknown class type",
+			input:  "/book Monday 10:00 yoga",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "yoga").Return("", usecase.ErrInvalidClassType)
+				manager.EXPECT().ClassTypes().Return([]string{"HYROX", "Open box", "Wod"})
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Invalid class type. Available types: HYROX, Open box, Wod"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:   "invalid day",
+			input:  "/book Funday 10:00 wod",
+			isAuth: true,
+			setupMocks: func(api *MockBookingBotAPI, manager *MockBookingManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Invalid day. Please use a day name in English or Spanish (e.g., Monday, mon, lunes), today or tomorrow"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
 		{
 			name:   "invalid format",
 			input:  "/book Monday",
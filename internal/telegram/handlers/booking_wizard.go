@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// BookingWizardCallbackPrefix prefixes the callback data of the wizard's
+// buttons, so the bot can route their callback queries to it
+const BookingWizardCallbackPrefix = "book:"
+
+// Callback data of the wizard's buttons, after the prefix
+const (
+	wizardDayAction     = "day:"   // followed by the day, e.g. "day:Monday"
+	wizardClassAction   = "class:" // followed by the index of the class in the day's timetable
+	wizardBackAction    = "back"
+	wizardConfirmAction = "confirm"
+	wizardCancelAction  = "cancel"
+)
+
+// wizardTimeout is how long an unfinished booking conversation is kept
+const wizardTimeout = 15 * time.Minute
+
+type BookingWizardManager interface {
+	IsAuthenticated(ctx context.Context, chatID int64) bool
+	GetTimetable(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error)
+	ScheduleBookClass(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error
+}
+
+type BookingWizardBotAPI interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+}
+
+// BookingWizard schedules a weekly booking through inline keyboards: the user
+// picks a day, then a class from the gym's timetable, then confirms. Every
+// step edits the same message.
+type BookingWizard struct {
+	api     BookingWizardBotAPI
+	manager BookingWizardManager
+
+	mu            sync.Mutex
+	conversations map[int64]*bookingConversation
+}
+
+// bookingConversation is the state of a chat's booking in progress. Its
+// callbacks are handled one at a time, holding mu, as quick taps arrive in
+// updates handled concurrently.
+type bookingConversation struct {
+	mu sync.Mutex
+
+	messageID int
+	day       models.Day
+	classes   []models.ClassSchedule
+	class     *models.ClassSchedule
+	updatedAt time.Time
+}
+
+func NewBookingWizard(api BookingWizardBotAPI, manager BookingWizardManager) *BookingWizard {
+	return &BookingWizard{
+		api:           api,
+		manager:       manager,
+		conversations: make(map[int64]*bookingConversation),
+	}
+}
+
+// Start asks for the day of the class, replacing any booking in progress
+func (w *BookingWizard) Start(update tgbotapi.Update) {
+	ctx := context.Background()
+	chatID := update.Message.Chat.ID
+
+	if !w.manager.IsAuthenticated(ctx, chatID) {
+		w.send(tgbotapi.NewMessage(chatID, "Please login first using /login command"))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, "📅 Which day do you want to book every week?")
+	msg.ReplyMarkup = dayKeyboard()
+	sent, err := w.api.Send(msg)
+	if err != nil {
+		slog.Error("Failed to send message", "error", err, "chat_id", chatID)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.conversations[chatID] = &bookingConversation{messageID: sent.MessageID, updatedAt: time.Now()}
+}
+
+// HandleCallback moves the chat's booking to the step of the pressed button
+func (w *BookingWizard) HandleCallback(update tgbotapi.Update) {
+	ctx := context.Background()
+	query := update.CallbackQuery
+	chatID := query.Message.Chat.ID
+	action := strings.TrimPrefix(query.Data, BookingWizardCallbackPrefix)
+
+	conversation, ok := w.conversation(chatID, query.Message.MessageID)
+	if ok {
+		conversation.mu.Lock()
+		defer conversation.mu.Unlock()
+		// The previous tap may have finished the booking meanwhile
+		ok = w.current(chatID, conversation)
+	}
+	if !ok {
+		w.answer(query.ID, "This booking has expired, start again with /book")
+		return
+	}
+	w.answer(query.ID, "")
+
+	switch {
+	case strings.HasPrefix(action, wizardDayAction):
+		w.chooseDay(ctx, chatID, conversation, models.Day(strings.TrimPrefix(action, wizardDayAction)))
+	case strings.HasPrefix(action, wizardClassAction):
+		index, err := strconv.Atoi(strings.TrimPrefix(action, wizardClassAction))
+		if err != nil || index < 0 || index >= len(conversation.classes) {
+			return
+		}
+		w.chooseClass(chatID, conversation, conversation.classes[index])
+	case action == wizardBackAction:
+		conversation.class = nil
+		w.edit(chatID, conversation, "📅 Which day do you want to book every week?", dayKeyboard())
+	case action == wizardConfirmAction && conversation.class != nil:
+		w.confirm(ctx, chatID, conversation)
+	case action == wizardCancelAction:
+		w.finish(chatID)
+		w.edit(chatID, conversation, "Booking cancelled.", nil)
+	}
+}
+
+// chooseDay shows the classes of the day on the gym's timetable
+func (w *BookingWizard) chooseDay(ctx context.Context, chatID int64, conversation *bookingConversation, day models.Day) {
+	if !day.Valid() {
+		return
+	}
+
+	classes, err := w.manager.GetTimetable(ctx, chatID, day, false)
+	if err != nil {
+		slog.Error("Failed to get timetable", "error", err, "chat_id", chatID, "day", day)
+		w.edit(chatID, conversation, "Failed to get the classes from WODBuster. Please pick a day to try again.", dayKeyboard())
+		return
+	}
+	if len(classes) == 0 {
+		w.edit(chatID, conversation, fmt.Sprintf("There are no classes on %s. Please pick another day.", day), dayKeyboard())
+		return
+	}
+
+	conversation.day = day
+	conversation.classes = classes
+	w.edit(chatID, conversation, fmt.Sprintf("🏋️ Which class on %s?", day), classKeyboard(classes))
+}
+
+// chooseClass asks to confirm the weekly booking of the class
+func (w *BookingWizard) chooseClass(chatID int64, conversation *bookingConversation, class models.ClassSchedule) {
+	conversation.class = &class
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✅ Confirm", BookingWizardCallbackPrefix+wizardConfirmAction),
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Back", BookingWizardCallbackPrefix+wizardBackAction),
+		tgbotapi.NewInlineKeyboardButtonData("✖️ Cancel", BookingWizardCallbackPrefix+wizardCancelAction),
+	))
+	w.edit(chatID, conversation,
+		fmt.Sprintf("Book %s at %s on %s every week?", class.ClassType, class.Hour, conversation.day), &keyboard)
+}
+
+// confirm schedules the weekly booking of the chosen class
+func (w *BookingWizard) confirm(ctx context.Context, chatID int64, conversation *bookingConversation) {
+	class := conversation.class
+	w.finish(chatID)
+
+	if err := w.manager.ScheduleBookClass(ctx, chatID, models.ClassBookingSchedule{
+		ID:        fmt.Sprintf("%s-%s-%s", conversation.day, class.Hour, class.ClassType),
+		Day:       conversation.day,
+		Hour:      class.Hour,
+		ClassType: class.ClassType,
+	}); err != nil {
+		if message, ok := limitsMessage(err); ok {
+			w.edit(chatID, conversation, message, nil)
+			return
+		}
+		w.edit(chatID, conversation, "Failed to book class. Please try again later.", nil)
+		slog.Error("Failed to book class",
+			"error", err,
+			"chat_id", chatID,
+			"day", conversation.day,
+			"hour", class.Hour,
+			"class_type", class.ClassType)
+		return
+	}
+
+	w.edit(chatID, conversation,
+		fmt.Sprintf("Class scheduled successfully! %s at %s for %s, booked every week", class.ClassType, class.Hour, conversation.day), nil)
+}
+
+// conversation returns the chat's booking in progress on the message, unless
+// it expired
+func (w *BookingWizard) conversation(chatID int64, messageID int) (*bookingConversation, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	conversation, ok := w.conversations[chatID]
+	if !ok || conversation.messageID != messageID {
+		return nil, false
+	}
+	if time.Since(conversation.updatedAt) > wizardTimeout {
+		delete(w.conversations, chatID)
+		return nil, false
+	}
+	conversation.updatedAt = time.Now()
+	return conversation, true
+}
+
+// current reports whether the conversation is still the chat's booking in
+// progress
+func (w *BookingWizard) current(chatID int64, conversation *bookingConversation) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conversations[chatID] == conversation
+}
+
+func (w *BookingWizard) finish(chatID int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.conversations, chatID)
+}
+
+// dayKeyboard offers the days of the week
+func dayKeyboard() *tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for i, day := range models.Days {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(day.String(), BookingWizardCallbackPrefix+wizardDayAction+day.String()))
+		if len(row) == 4 || i == len(models.Days)-1 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✖️ Cancel", BookingWizardCallbackPrefix+wizardCancelAction)))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return &keyboard
+}
+
+// classKeyboard offers the classes of a day, one per row
+func classKeyboard(classes []models.ClassSchedule) *tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, class := range classes {
+		label := fmt.Sprintf("%s %s", class.Hour, class.ClassType)
+		if !class.Available {
+			label += " ⛔"
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, BookingWizardCallbackPrefix+wizardClassAction+strconv.Itoa(i))))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Back", BookingWizardCallbackPrefix+wizardBackAction),
+		tgbotapi.NewInlineKeyboardButtonData("✖️ Cancel", BookingWizardCallbackPrefix+wizardCancelAction)))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return &keyboard
+}
+
+// edit replaces the text and keyboard of the conversation's message; a nil
+// keyboard removes it
+func (w *BookingWizard) edit(chatID int64, conversation *bookingConversation, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	edit := tgbotapi.NewEditMessageText(chatID, conversation.messageID, text)
+	edit.ReplyMarkup = keyboard
+	w.send(edit)
+}
+
+// answer acknowledges the callback query, so the button stops loading
+func (w *BookingWizard) answer(queryID, text string) {
+	if _, err := w.api.Request(tgbotapi.NewCallback(queryID, text)); err != nil {
+		slog.Error("Failed to answer callback query", "error", err)
+	}
+}
+
+func (w *BookingWizard) send(c tgbotapi.Chattable) {
+	if _, err := w.api.Send(c); err != nil {
+		slog.Error("Failed to send message", "error", err)
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookingWizard(t *testing.T) {
+	const (
+		testChatID    int64 = 123
+		testMessageID       = 42
+	)
+
+	timetable := []models.ClassSchedule{
+		{Day: models.DayFriday, Hour: "07:00", ClassType: "Wod", Available: true},
+		{Day: models.DayFriday, Hour: "08:00", ClassType: "Open box", Available: false},
+	}
+
+	start := tgbotapi.Update{Message: &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: testChatID},
+		Text: "/book",
+	}}
+	press := func(data string) tgbotapi.Update {
+		return tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			ID:   "query",
+			Data: data,
+			Message: &tgbotapi.Message{
+				MessageID: testMessageID,
+				Chat:      &tgbotapi.Chat{ID: testChatID},
+			},
+		}}
+	}
+	edited := func(text string) interface{} {
+		return mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+			edit, ok := c.(tgbotapi.EditMessageTextConfig)
+			return ok && edit.MessageID == testMessageID && edit.Text == text
+		})
+	}
+	answered := mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+		_, ok := c.(tgbotapi.CallbackConfig)
+		return ok
+	})
+
+	tests := []struct {
+		name       string
+		presses    []string
+		setupMocks func(*MockBookingWizardBotAPI, *MockBookingWizardManager)
+	}{
+		{
+			name:    "books the chosen class",
+			presses: []string{"book:day:Friday", "book:class:1", "book:confirm"},
+			setupMocks: func(api *MockBookingWizardBotAPI, manager *MockBookingWizardManager) {
+				manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.DayFriday, false).Return(timetable, nil)
+				manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, models.ClassBookingSchedule{
+					ID:        "Friday-08:00-Open box",
+					Day:       models.DayFriday,
+					Hour:      "08:00",
+					ClassType: "Open box",
+				}).Return(nil)
+				api.EXPECT().Request(answered).Return(&tgbotapi.APIResponse{Ok: true}, nil).Times(3)
+				api.EXPECT().Send(edited("🏋️ Which class on Friday?")).Return(tgbotapi.Message{}, nil)
+				api.EXPECT().Send(edited("Book Open box at 08:00 on Friday every week?")).Return(tgbotapi.Message{}, nil)
+				api.EXPECT().Send(edited("Class scheduled successfully! Open box at 08:00 for Friday, booked every week")).
+					Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:    "goes back to the days",
+			presses: []string{"book:day:Friday", "book:back"},
+			setupMocks: func(api *MockBookingWizardBotAPI, manager *MockBookingWizardManager) {
+				manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.DayFriday, false).Return(timetable, nil)
+				api.EXPECT().Request(answered).Return(&tgbotapi.APIResponse{Ok: true}, nil).Times(2)
+				api.EXPECT().Send(edited("🏋️ Which class on Friday?")).Return(tgbotapi.Message{}, nil)
+				api.EXPECT().Send(edited("📅 Which day do you want to book every week?")).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:    "day without classes",
+			presses: []string{"book:day:Sunday"},
+			setupMocks: func(api *MockBookingWizardBotAPI, manager *MockBookingWizardManager) {
+				manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.DaySunday, false).Return(nil, nil)
+				api.EXPECT().Request(answered).Return(&tgbotapi.APIResponse{Ok: true}, nil)
+				api.EXPECT().Send(edited("There are no classes on Sunday. Please pick another day.")).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:    "timetable fails",
+			presses: []string{"book:day:Friday"},
+			setupMocks: func(api *MockBookingWizardBotAPI, manager *MockBookingWizardManager) {
+				manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.DayFriday, false).Return(nil, errors.New("session expired"))
+				api.EXPECT().Request(answered).Return(&tgbotapi.APIResponse{Ok: true}, nil)
+				api.EXPECT().Send(edited("Failed to get the classes from WODBuster. Please pick a day to try again.")).
+					Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:    "cancelled booking expires",
+			presses: []string{"book:cancel", "book:day:Friday"},
+			setupMocks: func(api *MockBookingWizardBotAPI, manager *MockBookingWizardManager) {
+				api.EXPECT().Request(answered).Return(&tgbotapi.APIResponse{Ok: true}, nil).Times(2)
+				api.EXPECT().Send(edited("Booking cancelled.")).Return(tgbotapi.Message{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewMockBookingWizardBotAPI(t)
+			manager := NewMockBookingWizardManager(t)
+
+			manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+			api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+				msg, ok := c.(tgbotapi.MessageConfig)
+				return ok && msg.Text == "📅 Which day do you want to book every week?" && msg.ReplyMarkup != nil
+			})).Return(tgbotapi.Message{MessageID: testMessageID}, nil)
+			tt.setupMocks(api, manager)
+
+			wizard := NewBookingWizard(api, manager)
+			wizard.Start(start)
+			for _, data := range tt.presses {
+				wizard.HandleCallback(press(data))
+			}
+		})
+	}
+}
+
+func TestBookingWizard_DoubleTap(t *testing.T) {
+	const (
+		testChatID    int64 = 123
+		testMessageID       = 42
+	)
+
+	press := func(data string) tgbotapi.Update {
+		return tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			ID:   "query",
+			Data: data,
+			Message: &tgbotapi.Message{
+				MessageID: testMessageID,
+				Chat:      &tgbotapi.Chat{ID: testChatID},
+			},
+		}}
+	}
+
+	api := NewMockBookingWizardBotAPI(t)
+	manager := NewMockBookingWizardManager(t)
+
+	manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+	// Reading the timetable is slow, so taps on a day overlap
+	manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.DayFriday, false).
+		RunAndReturn(func(context.Context, int64, models.Day, bool) ([]models.ClassSchedule, error) {
+			time.Sleep(10 * time.Millisecond)
+			return []models.ClassSchedule{{Day: models.DayFriday, Hour: "07:00", ClassType: "Wod", Available: true}}, nil
+		})
+	// Only one of the taps on Confirm books the class, the other finds the booking finished
+	manager.EXPECT().ScheduleBookClass(mock.Anything, testChatID, models.ClassBookingSchedule{
+		ID:        "Friday-07:00-Wod",
+		Day:       models.DayFriday,
+		Hour:      "07:00",
+		ClassType: "Wod",
+	}).Return(nil).Once()
+	api.EXPECT().Send(mock.Anything).Return(tgbotapi.Message{MessageID: testMessageID}, nil)
+	api.EXPECT().Request(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+		callback, ok := c.(tgbotapi.CallbackConfig)
+		return ok && callback.Text == "This booking has expired, start again with /book"
+	})).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once()
+	api.EXPECT().Request(mock.Anything).Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+	wizard := NewBookingWizard(api, manager)
+	wizard.Start(tgbotapi.Update{Message: &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: testChatID},
+		Text: "/book",
+	}})
+
+	var wg sync.WaitGroup
+	for _, data := range []string{"book:day:Friday", "book:day:Friday", "book:class:0", "book:back", "book:class:0"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wizard.HandleCallback(press(data))
+		}()
+	}
+	wg.Wait()
+	wizard.HandleCallback(press("book:class:0"))
+
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wizard.HandleCallback(press("book:confirm"))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBookingWizard_NotAuthenticated(t *testing.T) {
+	const testChatID int64 = 123
+
+	api := NewMockBookingWizardBotAPI(t)
+	manager := NewMockBookingWizardManager(t)
+
+	manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(false)
+	api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+		msg, ok := c.(tgbotapi.MessageConfig)
+		return ok && msg.Text == "Please login first using /login command"
+	})).Return(tgbotapi.Message{}, nil)
+
+	NewBookingWizard(api, manager).Start(tgbotapi.Update{Message: &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: testChatID},
+		Text: "/book",
+	}})
+}
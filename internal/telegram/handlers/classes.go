@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type ClassesManager interface {
+	IsAuthenticated(ctx context.Context, chatID int64) bool
+	GetTimetable(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error)
+}
+
+type ClassesBotAPI interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+}
+
+// ClassesHandler shows the gym's timetable of the current or the next week
+type ClassesHandler struct {
+	api     ClassesBotAPI
+	manager ClassesManager
+}
+
+func NewClassesHandler(api ClassesBotAPI, manager ClassesManager) *ClassesHandler {
+	return &ClassesHandler{
+		api:     api,
+		manager: manager,
+	}
+}
+
+// weekNames are the accepted words for the [week] argument, true for next week
+var weekNames = map[string]bool{
+	"this":      false,
+	"current":   false,
+	"esta":      false,
+	"next":      true,
+	"siguiente": true,
+	"proxima":   true,
+	"próxima":   true,
+}
+
+func (h *ClassesHandler) Handle(update tgbotapi.Update) {
+	ctx := context.Background()
+
+	if !h.manager.IsAuthenticated(ctx, update.Message.Chat.ID) {
+		h.sendMessage(update.Message.Chat.ID, "Please login first using /login command")
+		return
+	}
+
+	// Both arguments are optional: "/classes next" shows the whole next week
+	args := strings.Fields(update.Message.Text)[1:]
+	nextWeek := false
+	if len(args) > 0 {
+		if next, ok := weekNames[strings.ToLower(args[len(args)-1])]; ok {
+			nextWeek = next
+			args = args[:len(args)-1]
+		}
+	}
+	if len(args) > 1 {
+		h.sendMessage(update.Message.Chat.ID,
+			"Please use: /classes [day] [this|next] (e.g., /classes, /classes Monday or /classes Monday next)")
+		return
+	}
+
+	var day models.Day
+	if len(args) == 1 {
+		parsed, err := models.ParseDay(utils.SanitizeInput(args[0]), time.Now())
+		if err != nil {
+			h.sendMessage(update.Message.Chat.ID,
+				"Invalid day. Please use a day name in English or Spanish (e.g., Monday, mon, lunes), today or tomorrow")
+			return
+		}
+		day = parsed
+	}
+
+	classes, err := h.manager.GetTimetable(ctx, update.Message.Chat.ID, day, nextWeek)
+	if err != nil {
+		h.sendMessage(update.Message.Chat.ID,
+			"Failed to get the classes from WODBuster. Please try again later.")
+		slog.Error("Failed to get timetable",
+			"error", err,
+			"chat_id", update.Message.Chat.ID,
+			"day", day,
+			"next_week", nextWeek)
+		return
+	}
+
+	h.sendMessage(update.Message.Chat.ID, formatTimetable(classes, day, nextWeek))
+}
+
+// formatTimetable renders the classes grouped by day, marking whether each
+// one can still be booked
+func formatTimetable(classes []models.ClassSchedule, day models.Day, nextWeek bool) string {
+	week := "this week"
+	if nextWeek {
+		week = "next week"
+	}
+	title := "📅 Classes " + week
+	if day != "" {
+		title = fmt.Sprintf("📅 Classes on %s %s", day, week)
+	}
+
+	if len(classes) == 0 {
+		return title + "\n\nNo classes found."
+	}
+
+	var b strings.Builder
+	b.WriteString(title + "\n")
+	var current models.Day
+	for _, class := range classes {
+		if class.Day != current {
+			current = class.Day
+			fmt.Fprintf(&b, "\n%s\n", current)
+		}
+
+		marker := "✅"
+		if !class.Available {
+			marker = "⛔"
+		}
+		fmt.Fprintf(&b, "%s %s %s\n", marker, class.Hour, class.ClassType)
+	}
+	b.WriteString("\n✅ bookable  ⛔ full or not bookable")
+
+	return b.String()
+}
+
+func (h *ClassesHandler) sendMessage(chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	if _, err := h.api.Send(msg); err != nil {
+		slog.Error("Failed to send message",
+			"error", err,
+			"chat_id", chatID)
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClassesHandler_Handle(t *testing.T) {
+	const testChatID int64 = 123
+
+	timetable := []models.ClassSchedule{
+		{Day: models.DayMonday, Hour: "07:00", ClassType: "Wod", Available: true},
+		{Day: models.DayMonday, Hour: "08:00", ClassType: "HYROX", Available: false},
+		{Day: models.DayTuesday, Hour: "19:00", ClassType: "Open box", Available: true},
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		setupMocks func(*MockClassesBotAPI, *MockClassesManager)
+	}{
+		{
+			name:  "whole current week",
+			input: "/classes",
+			setupMocks: func(api *MockClassesBotAPI, manager *MockClassesManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.Day(""), false).Return(timetable, nil)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "📅 Classes this week\n\n"+
+						"Monday\n✅ 07:00 Wod\n⛔ 08:00 HYROX\n\n"+
+						"Tuesday\n✅ 19:00 Open box\n\n"+
+						"✅ bookable  ⛔ full or not bookable"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "day of next week",
+			input: "/classes lunes next",
+			setupMocks: func(api *MockClassesBotAPI, manager *MockClassesManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.DayMonday, true).Return(timetable[:2], nil)
+				api.EXPECT().Send(mock.Anything).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "whole next week",
+			input: "/classes next",
+			setupMocks: func(api *MockClassesBotAPI, manager *MockClassesManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.Day(""), true).Return(nil, nil)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "📅 Classes next week\n\nNo classes found."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "invalid day",
+			input: "/classes funday",
+			setupMocks: func(api *MockClassesBotAPI, manager *MockClassesManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Invalid day. Please use a day name in English or Spanish (e.g., Monday, mon, lunes), today or tomorrow"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "fetch fails",
+			input: "/classes Monday",
+			setupMocks: func(api *MockClassesBotAPI, manager *MockClassesManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().GetTimetable(mock.Anything, testChatID, models.DayMonday, false).Return(nil, errors.New("session expired"))
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Failed to get the classes from WODBuster. Please try again later."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "not authenticated",
+			input: "/classes",
+			setupMocks: func(api *MockClassesBotAPI, manager *MockClassesManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(false)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Please login first using /login command"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewMockClassesBotAPI(t)
+			manager := NewMockClassesManager(t)
+
+			handler := NewClassesHandler(api, manager)
+
+			tt.setupMocks(api, manager)
+
+			update := tgbotapi.Update{
+				Message: &tgbotapi.Message{
+					Chat: &tgbotapi.Chat{ID: testChatID},
+					Text: tt.input,
+				},
+			}
+
+			handler.Handle(update)
+		})
+	}
+}
+
+func TestFormatTimetable_Day(t *testing.T) {
+	message := formatTimetable([]models.ClassSchedule{
+		{Day: models.DayFriday, Hour: "07:00", ClassType: "Wod", Available: true},
+	}, models.DayFriday, true)
+
+	assert.Equal(t, "📅 Classes on Friday next week\n\nFriday\n✅ 07:00 Wod\n\n✅ bookable  ⛔ full or not bookable", message)
+}
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// loginTimeout is how long the bot waits for the email or the password of a
+// login in progress
+const loginTimeout = 5 * time.Minute
+
 type LogInManager interface {
 	IsAuthenticated(ctx context.Context, chatID int64) bool
 	LogInAndSave(ctx context.Context, chatID int64, email, password string) error
@@ -17,64 +23,198 @@ type LogInManager interface {
 
 type LogInBotAPI interface {
 	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
 }
 
+// LoginHandler saves the user's WODBuster credentials, either from
+// "/login email password" or by asking for the email and then the password in
+// separate messages. Messages with a password are deleted as soon as they are
+// read, and logins are only accepted in private chats.
 type LoginHandler struct {
 	api     LogInBotAPI
 	manager LogInManager
+
+	mu      sync.Mutex
+	pending map[int64]*pendingLogin
+}
+
+// pendingLogin is a login waiting for the user's next message: the email
+// while it is empty, the password otherwise
+type pendingLogin struct {
+	email     string
+	updatedAt time.Time
 }
 
 func NewLoginHandler(api LogInBotAPI, logInManager LogInManager) *LoginHandler {
 	return &LoginHandler{
 		api:     api,
 		manager: logInManager,
+		pending: make(map[int64]*pendingLogin),
 	}
 }
 
+// Handle starts a login: from "/login email password" right away, otherwise by
+// asking for whatever is missing
 func (h *LoginHandler) Handle(update tgbotapi.Update) {
-	ctx := context.Background()
+	chatID := update.Message.Chat.ID
 
-	args := strings.Split(update.Message.Text, " ")
-	if len(args) != 3 {
-		h.sendMessage(update.Message.Chat.ID,
-			"Please provide email and password: /login email password")
+	args := strings.Fields(update.Message.Text)[1:]
+	if !update.Message.Chat.IsPrivate() {
+		// The password is already public, at least take it down
+		if len(args) > 1 {
+			h.deleteMessage(update.Message)
+		}
+		h.sendMessage(chatID,
+			"For your security, /login only works in a private chat with the bot.")
 		return
 	}
 
-	email := utils.SanitizeInput(args[1])
-	password := utils.SanitizeInput(args[2])
+	switch len(args) {
+	case 0:
+		h.setPending(chatID, "")
+		h.sendMessage(chatID, "Please send your WODBuster email.")
+	case 1:
+		h.askPassword(chatID, args[0])
+	case 2:
+		h.deleteMessage(update.Message)
+		h.logIn(chatID, utils.SanitizeInput(args[0]), utils.SanitizeInput(args[1]))
+	default:
+		h.deleteMessage(update.Message)
+		h.sendMessage(chatID,
+			"Please use /login and send your email and then your password when asked")
+	}
+}
+
+// InProgress reports whether the chat's next message is the reply to a login
+// prompt
+func (h *LoginHandler) InProgress(chatID int64) bool {
+	_, ok := h.pendingLogin(chatID)
+	return ok
+}
+
+// HandlePassword reads the message as the password the chat's login is
+// waiting for, unless it is /login or /cancel, and reports whether it did.
+// It must run before rate limiting and command routing, so a password that
+// starts with "/" or is sent too quickly is still deleted.
+func (h *LoginHandler) HandlePassword(update tgbotapi.Update) bool {
+	login, ok := h.pendingLogin(update.Message.Chat.ID)
+	if !ok || login.email == "" {
+		return false
+	}
+	switch update.Message.Command() {
+	case "login", "cancel":
+		return false
+	}
+
+	h.HandleReply(update)
+	return true
+}
+
+// pendingLogin returns the chat's login in progress, dropping it once it has
+// waited too long for the user's reply
+func (h *LoginHandler) pendingLogin(chatID int64) (pendingLogin, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	login, ok := h.pending[chatID]
+	if !ok {
+		return pendingLogin{}, false
+	}
+	if time.Since(login.updatedAt) > loginTimeout {
+		delete(h.pending, chatID)
+		return pendingLogin{}, false
+	}
+	return *login, true
+}
+
+// Cancel drops the chat's login in progress, if any
+func (h *LoginHandler) Cancel(chatID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.pending, chatID)
+}
+
+// HandleReply reads the email or the password of the chat's login in progress
+func (h *LoginHandler) HandleReply(update tgbotapi.Update) {
+	chatID := update.Message.Chat.ID
+
+	h.mu.Lock()
+	login, ok := h.pending[chatID]
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if login.email == "" {
+		h.askPassword(chatID, update.Message.Text)
+		return
+	}
+
+	h.deleteMessage(update.Message)
+	h.Cancel(chatID)
+	h.logIn(chatID, login.email, utils.SanitizeInput(update.Message.Text))
+}
+
+// askPassword validates the email and asks for the password
+func (h *LoginHandler) askPassword(chatID int64, email string) {
+	email = utils.SanitizeInput(email)
+	if err := utils.ValidateEmail(email); err != nil {
+		h.setPending(chatID, "")
+		h.sendMessage(chatID, "Please send a valid email address")
+		return
+	}
+
+	h.setPending(chatID, email)
+	h.sendMessage(chatID,
+		"Now send your WODBuster password. The message will be deleted as soon as it is read.")
+}
+
+func (h *LoginHandler) logIn(chatID int64, email, password string) {
+	ctx := context.Background()
 
 	// Validate email format
 	if err := utils.ValidateEmail(email); err != nil {
-		h.sendMessage(update.Message.Chat.ID,
+		h.sendMessage(chatID,
 			"Please provide a valid email address")
 		return
 	}
 
 	// Validate password requirements
 	if err := utils.ValidatePassword(password); err != nil {
-		h.sendMessage(update.Message.Chat.ID,
+		h.sendMessage(chatID,
 			fmt.Sprintf("Invalid password: %s", err.Error()))
 		return
 	}
 
-	// if err := h.wodbuster.Login(email, password); err != nil {
-	// 	h.sendMessage(update.Message.Chat.ID,
-	// 		"Login failed. Please check your credentials and try again.")
-	// 	return
-	// }
-
-	if err := h.manager.LogInAndSave(ctx, update.Message.Chat.ID, email, password); err != nil {
-		h.sendMessage(update.Message.Chat.ID,
+	if err := h.manager.LogInAndSave(ctx, chatID, email, password); err != nil {
+		h.sendMessage(chatID,
 			"Failed to save login information. Please try again later.")
-		slog.Error("Failed to save user login", "error", err, "chat_id", update.Message.Chat.ID)
+		slog.Error("Failed to save user login", "error", err, "chat_id", chatID)
 		return
 	}
 
-	h.sendMessage(update.Message.Chat.ID,
+	h.sendMessage(chatID,
 		"Login successful! You can now use /book and /remove commands.")
 }
 
+func (h *LoginHandler) setPending(chatID int64, email string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pending[chatID] = &pendingLogin{email: email, updatedAt: time.Now()}
+}
+
+// deleteMessage deletes the user's message with a password, or asks the user
+// to delete it when the bot can't
+func (h *LoginHandler) deleteMessage(message *tgbotapi.Message) {
+	if _, err := h.api.Request(tgbotapi.NewDeleteMessage(message.Chat.ID, message.MessageID)); err != nil {
+		slog.Error("Failed to delete login message",
+			"error", err,
+			"chat_id", message.Chat.ID)
+		h.sendMessage(message.Chat.ID,
+			"I couldn't delete your message with the password, please delete it yourself.")
+	}
+}
+
 func (h *LoginHandler) sendMessage(chatID int64, text string) {
 	msg := tgbotapi.NewMessage(chatID, text)
 	if _, err := h.api.Send(msg); err != nil {
@@ -1,24 +1,38 @@
 package handlers
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestLoginHandler_Handle(t *testing.T) {
-	const testChatID int64 = 123
+	const (
+		testChatID    int64 = 123
+		testMessageID       = 7
+	)
+
+	deleted := mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+		del, ok := c.(tgbotapi.DeleteMessageConfig)
+		return ok && del.ChatID == testChatID && del.MessageID == testMessageID
+	})
 
 	tests := []struct {
 		name       string
 		input      string
+		chatType   string
 		setupMocks func(*MockLogInBotAPI, *MockLogInManager)
 	}{
 		{
-			name:  "successful login",
-			input: "/login testuser@email.com password123",
+			name:     "successful login",
+			input:    "/login testuser@email.com password123",
+			chatType: "private",
 			setupMocks: func(api *MockLogInBotAPI, manager *MockLogInManager) {
+				api.EXPECT().Request(deleted).Return(&tgbotapi.APIResponse{Ok: true}, nil)
 				manager.EXPECT().LogInAndSave(mock.Anything, testChatID, "testuser@email.com", "password123").Return(nil)
 				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
 					msg, ok := c.(tgbotapi.MessageConfig)
@@ -27,12 +41,64 @@ func TestLoginHandler_Handle(t *testing.T) {
 			},
 		},
 		{
-			name:  "invalid format",
-			input: "/login testuser",
+			name:     "password message can't be deleted",
+			input:    "/login testuser@email.com password123",
+			chatType: "private",
 			setupMocks: func(api *MockLogInBotAPI, manager *MockLogInManager) {
+				api.EXPECT().Request(deleted).Return(nil, errors.New("message can't be deleted"))
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "I couldn't delete your message with the password, please delete it yourself."
+				})).Return(tgbotapi.Message{}, nil)
+				manager.EXPECT().LogInAndSave(mock.Anything, testChatID, "testuser@email.com", "password123").Return(nil)
 				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
 					msg, ok := c.(tgbotapi.MessageConfig)
-					return ok && msg.Text == "Please provide email and password: /login email password"
+					return ok && msg.Text == "Login successful! You can now use /book and /remove commands."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:     "asks for the email",
+			input:    "/login",
+			chatType: "private",
+			setupMocks: func(api *MockLogInBotAPI, manager *MockLogInManager) {
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Please send your WODBuster email."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:     "asks for the password",
+			input:    "/login testuser@email.com",
+			chatType: "private",
+			setupMocks: func(api *MockLogInBotAPI, manager *MockLogInManager) {
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Now send your WODBuster password. The message will be deleted as soon as it is read."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:     "invalid email",
+			input:    "/login testuser",
+			chatType: "private",
+			setupMocks: func(api *MockLogInBotAPI, manager *MockLogInManager) {
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Please send a valid email address"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:     "group chat",
+			input:    "/login testuser@email.com password123",
+			chatType: "group",
+			setupMocks: func(api *MockLogInBotAPI, manager *MockLogInManager) {
+				api.EXPECT().Request(deleted).Return(&tgbotapi.APIResponse{Ok: true}, nil)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "For your security, /login only works in a private chat with the bot."
 				})).Return(tgbotapi.Message{}, nil)
 			},
 		},
@@ -49,8 +115,9 @@ func TestLoginHandler_Handle(t *testing.T) {
 
 			update := tgbotapi.Update{
 				Message: &tgbotapi.Message{
-					Chat: &tgbotapi.Chat{ID: testChatID},
-					Text: tt.input,
+					MessageID: testMessageID,
+					Chat:      &tgbotapi.Chat{ID: testChatID, Type: tt.chatType},
+					Text:      tt.input,
 				},
 			}
 
@@ -58,3 +125,84 @@ func TestLoginHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestLoginHandler_Conversation(t *testing.T) {
+	const testChatID int64 = 123
+
+	api := NewMockLogInBotAPI(t)
+	manager := NewMockLogInManager(t)
+	handler := NewLoginHandler(api, manager)
+
+	message := func(id int, text string) tgbotapi.Update {
+		return tgbotapi.Update{Message: &tgbotapi.Message{
+			MessageID: id,
+			Chat:      &tgbotapi.Chat{ID: testChatID, Type: "private"},
+			Text:      text,
+		}}
+	}
+
+	api.EXPECT().Send(mock.Anything).Return(tgbotapi.Message{}, nil)
+	api.EXPECT().Request(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+		del, ok := c.(tgbotapi.DeleteMessageConfig)
+		return ok && del.MessageID == 3
+	})).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once()
+	manager.EXPECT().LogInAndSave(mock.Anything, testChatID, "testuser@email.com", "pass word123").Return(nil).Once()
+
+	assert.False(t, handler.InProgress(testChatID))
+
+	handler.Handle(message(1, "/login"))
+	assert.True(t, handler.InProgress(testChatID))
+
+	handler.HandleReply(message(2, " testuser@email.com "))
+	assert.True(t, handler.InProgress(testChatID))
+
+	// Passwords sent on their own may have spaces
+	handler.HandleReply(message(3, "pass word123"))
+	assert.False(t, handler.InProgress(testChatID))
+
+	handler.Handle(message(4, "/login"))
+	handler.Cancel(testChatID)
+	assert.False(t, handler.InProgress(testChatID))
+}
+
+func TestLoginHandler_HandlePassword(t *testing.T) {
+	const testChatID int64 = 123
+
+	command := func(id int, text string) tgbotapi.Update {
+		update := tgbotapi.Update{Message: &tgbotapi.Message{
+			MessageID: id,
+			Chat:      &tgbotapi.Chat{ID: testChatID, Type: "private"},
+			Text:      text,
+		}}
+		if strings.HasPrefix(text, "/") {
+			length := len(strings.Fields(text)[0])
+			update.Message.Entities = []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: length}}
+		}
+		return update
+	}
+
+	api := NewMockLogInBotAPI(t)
+	manager := NewMockLogInManager(t)
+	handler := NewLoginHandler(api, manager)
+
+	api.EXPECT().Send(mock.Anything).Return(tgbotapi.Message{}, nil)
+	api.EXPECT().Request(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+		del, ok := c.(tgbotapi.DeleteMessageConfig)
+		return ok && del.MessageID == 6
+	})).Return(&tgbotapi.APIResponse{Ok: true}, nil).Once()
+	manager.EXPECT().LogInAndSave(mock.Anything, testChatID, "testuser@email.com", "/start123").Return(nil).Once()
+
+	// Nothing is taken while no password is awaited
+	assert.False(t, handler.HandlePassword(command(1, "/start123")))
+	handler.Handle(command(2, "/login"))
+	assert.False(t, handler.HandlePassword(command(3, "testuser@email.com")))
+	handler.HandleReply(command(3, "testuser@email.com"))
+
+	// /login and /cancel still reach the bot
+	assert.False(t, handler.HandlePassword(command(4, "/cancel")))
+	assert.False(t, handler.HandlePassword(command(5, "/login")))
+
+	// A password that looks like a command is read and deleted
+	assert.True(t, handler.HandlePassword(command(6, "/start123")))
+	assert.False(t, handler.InProgress(testChatID))
+}
@@ -39,6 +39,52 @@ func (_m *MockBookingManager) EXPECT() *MockBookingManager_Expecter {
 	return &MockBookingManager_Expecter{mock: &_m.Mock}
 }
 
+// ClassTypes provides a mock function for the type MockBookingManager
+func (_mock *MockBookingManager) ClassTypes() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClassTypes")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockBookingManager_ClassTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClassTypes'
+type MockBookingManager_ClassTypes_Call struct {
+	*mock.Call
+}
+
+// ClassTypes is a helper method to define mock.On call
+func (_e *MockBookingManager_Expecter) ClassTypes() *MockBookingManager_ClassTypes_Call {
+	return &MockBookingManager_ClassTypes_Call{Call: _e.mock.On("ClassTypes")}
+}
+
+func (_c *MockBookingManager_ClassTypes_Call) Run(run func()) *MockBookingManager_ClassTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBookingManager_ClassTypes_Call) Return(strings []string) *MockBookingManager_ClassTypes_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *MockBookingManager_ClassTypes_Call) RunAndReturn(run func() []string) *MockBookingManager_ClassTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsAuthenticated provides a mock function for the type MockBookingManager
 func (_mock *MockBookingManager) IsAuthenticated(ctx context.Context, chatID int64) bool {
 	ret := _mock.Called(ctx, chatID)
@@ -96,6 +142,78 @@ func (_c *MockBookingManager_IsAuthenticated_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// ResolveClassType provides a mock function for the type MockBookingManager
+func (_mock *MockBookingManager) ResolveClassType(ctx context.Context, chatID int64, input string) (string, error) {
+	ret := _mock.Called(ctx, chatID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveClassType")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (string, error)); ok {
+		return returnFunc(ctx, chatID, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) string); ok {
+		r0 = returnFunc(ctx, chatID, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, chatID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBookingManager_ResolveClassType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveClassType'
+type MockBookingManager_ResolveClassType_Call struct {
+	*mock.Call
+}
+
+// ResolveClassType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - input string
+func (_e *MockBookingManager_Expecter) ResolveClassType(ctx interface{}, chatID interface{}, input interface{}) *MockBookingManager_ResolveClassType_Call {
+	return &MockBookingManager_ResolveClassType_Call{Call: _e.mock.On("ResolveClassType", ctx, chatID, input)}
+}
+
+func (_c *MockBookingManager_ResolveClassType_Call) Run(run func(ctx context.Context, chatID int64, input string)) *MockBookingManager_ResolveClassType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBookingManager_ResolveClassType_Call) Return(s string, err error) *MockBookingManager_ResolveClassType_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockBookingManager_ResolveClassType_Call) RunAndReturn(run func(ctx context.Context, chatID int64, input string) (string, error)) *MockBookingManager_ResolveClassType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ScheduleBookClass provides a mock function for the type MockBookingManager
 func (_mock *MockBookingManager) ScheduleBookClass(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
 	ret := _mock.Called(ctx, chatID, class)
@@ -246,13 +364,13 @@ func (_c *MockBookingBotAPI_Send_Call) RunAndReturn(run func(c tgbotapi.Chattabl
 	return _c
 }
 
-// NewMockLogInManager creates a new instance of MockLogInManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockBookingWizardManager creates a new instance of MockBookingWizardManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockLogInManager(t interface {
+func NewMockBookingWizardManager(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockLogInManager {
-	mock := &MockLogInManager{}
+}) *MockBookingWizardManager {
+	mock := &MockBookingWizardManager{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -260,21 +378,101 @@ func NewMockLogInManager(t interface {
 	return mock
 }
 
-// MockLogInManager is an autogenerated mock type for the LogInManager type
-type MockLogInManager struct {
+// MockBookingWizardManager is an autogenerated mock type for the BookingWizardManager type
+type MockBookingWizardManager struct {
 	mock.Mock
 }
 
-type MockLogInManager_Expecter struct {
+type MockBookingWizardManager_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockLogInManager) EXPECT() *MockLogInManager_Expecter {
-	return &MockLogInManager_Expecter{mock: &_m.Mock}
+func (_m *MockBookingWizardManager) EXPECT() *MockBookingWizardManager_Expecter {
+	return &MockBookingWizardManager_Expecter{mock: &_m.Mock}
 }
 
-// IsAuthenticated provides a mock function for the type MockLogInManager
-func (_mock *MockLogInManager) IsAuthenticated(ctx context.Context, chatID int64) bool {
+// GetTimetable provides a mock function for the type MockBookingWizardManager
+func (_mock *MockBookingWizardManager) GetTimetable(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error) {
+	ret := _mock.Called(ctx, chatID, day, nextWeek)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimetable")
+	}
+
+	var r0 []models.ClassSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, bool) ([]models.ClassSchedule, error)); ok {
+		return returnFunc(ctx, chatID, day, nextWeek)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, bool) []models.ClassSchedule); ok {
+		r0 = returnFunc(ctx, chatID, day, nextWeek)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ClassSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, models.Day, bool) error); ok {
+		r1 = returnFunc(ctx, chatID, day, nextWeek)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBookingWizardManager_GetTimetable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTimetable'
+type MockBookingWizardManager_GetTimetable_Call struct {
+	*mock.Call
+}
+
+// GetTimetable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - day models.Day
+//   - nextWeek bool
+func (_e *MockBookingWizardManager_Expecter) GetTimetable(ctx interface{}, chatID interface{}, day interface{}, nextWeek interface{}) *MockBookingWizardManager_GetTimetable_Call {
+	return &MockBookingWizardManager_GetTimetable_Call{Call: _e.mock.On("GetTimetable", ctx, chatID, day, nextWeek)}
+}
+
+func (_c *MockBookingWizardManager_GetTimetable_Call) Run(run func(ctx context.Context, chatID int64, day models.Day, nextWeek bool)) *MockBookingWizardManager_GetTimetable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 models.Day
+		if args[2] != nil {
+			arg2 = args[2].(models.Day)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBookingWizardManager_GetTimetable_Call) Return(classSchedules []models.ClassSchedule, err error) *MockBookingWizardManager_GetTimetable_Call {
+	_c.Call.Return(classSchedules, err)
+	return _c
+}
+
+func (_c *MockBookingWizardManager_GetTimetable_Call) RunAndReturn(run func(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error)) *MockBookingWizardManager_GetTimetable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsAuthenticated provides a mock function for the type MockBookingWizardManager
+func (_mock *MockBookingWizardManager) IsAuthenticated(ctx context.Context, chatID int64) bool {
 	ret := _mock.Called(ctx, chatID)
 
 	if len(ret) == 0 {
@@ -290,19 +488,19 @@ func (_mock *MockLogInManager) IsAuthenticated(ctx context.Context, chatID int64
 	return r0
 }
 
-// MockLogInManager_IsAuthenticated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthenticated'
-type MockLogInManager_IsAuthenticated_Call struct {
+// MockBookingWizardManager_IsAuthenticated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthenticated'
+type MockBookingWizardManager_IsAuthenticated_Call struct {
 	*mock.Call
 }
 
 // IsAuthenticated is a helper method to define mock.On call
 //   - ctx context.Context
 //   - chatID int64
-func (_e *MockLogInManager_Expecter) IsAuthenticated(ctx interface{}, chatID interface{}) *MockLogInManager_IsAuthenticated_Call {
-	return &MockLogInManager_IsAuthenticated_Call{Call: _e.mock.On("IsAuthenticated", ctx, chatID)}
+func (_e *MockBookingWizardManager_Expecter) IsAuthenticated(ctx interface{}, chatID interface{}) *MockBookingWizardManager_IsAuthenticated_Call {
+	return &MockBookingWizardManager_IsAuthenticated_Call{Call: _e.mock.On("IsAuthenticated", ctx, chatID)}
 }
 
-func (_c *MockLogInManager_IsAuthenticated_Call) Run(run func(ctx context.Context, chatID int64)) *MockLogInManager_IsAuthenticated_Call {
+func (_c *MockBookingWizardManager_IsAuthenticated_Call) Run(run func(ctx context.Context, chatID int64)) *MockBookingWizardManager_IsAuthenticated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -320,48 +518,47 @@ func (_c *MockLogInManager_IsAuthenticated_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockLogInManager_IsAuthenticated_Call) Return(b bool) *MockLogInManager_IsAuthenticated_Call {
+func (_c *MockBookingWizardManager_IsAuthenticated_Call) Return(b bool) *MockBookingWizardManager_IsAuthenticated_Call {
 	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockLogInManager_IsAuthenticated_Call) RunAndReturn(run func(ctx context.Context, chatID int64) bool) *MockLogInManager_IsAuthenticated_Call {
+func (_c *MockBookingWizardManager_IsAuthenticated_Call) RunAndReturn(run func(ctx context.Context, chatID int64) bool) *MockBookingWizardManager_IsAuthenticated_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LogInAndSave provides a mock function for the type MockLogInManager
-func (_mock *MockLogInManager) LogInAndSave(ctx context.Context, chatID int64, email string, password string) error {
-	ret := _mock.Called(ctx, chatID, email, password)
+// ScheduleBookClass provides a mock function for the type MockBookingWizardManager
+func (_mock *MockBookingWizardManager) ScheduleBookClass(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
+	ret := _mock.Called(ctx, chatID, class)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LogInAndSave")
+		panic("no return value specified for ScheduleBookClass")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
-		r0 = returnFunc(ctx, chatID, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.ClassBookingSchedule) error); ok {
+		r0 = returnFunc(ctx, chatID, class)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockLogInManager_LogInAndSave_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogInAndSave'
-type MockLogInManager_LogInAndSave_Call struct {
+// MockBookingWizardManager_ScheduleBookClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleBookClass'
+type MockBookingWizardManager_ScheduleBookClass_Call struct {
 	*mock.Call
 }
 
-// LogInAndSave is a helper method to define mock.On call
+// ScheduleBookClass is a helper method to define mock.On call
 //   - ctx context.Context
 //   - chatID int64
-//   - email string
-//   - password string
-func (_e *MockLogInManager_Expecter) LogInAndSave(ctx interface{}, chatID interface{}, email interface{}, password interface{}) *MockLogInManager_LogInAndSave_Call {
-	return &MockLogInManager_LogInAndSave_Call{Call: _e.mock.On("LogInAndSave", ctx, chatID, email, password)}
+//   - class models.ClassBookingSchedule
+func (_e *MockBookingWizardManager_Expecter) ScheduleBookClass(ctx interface{}, chatID interface{}, class interface{}) *MockBookingWizardManager_ScheduleBookClass_Call {
+	return &MockBookingWizardManager_ScheduleBookClass_Call{Call: _e.mock.On("ScheduleBookClass", ctx, chatID, class)}
 }
 
-func (_c *MockLogInManager_LogInAndSave_Call) Run(run func(ctx context.Context, chatID int64, email string, password string)) *MockLogInManager_LogInAndSave_Call {
+func (_c *MockBookingWizardManager_ScheduleBookClass_Call) Run(run func(ctx context.Context, chatID int64, class models.ClassBookingSchedule)) *MockBookingWizardManager_ScheduleBookClass_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -371,41 +568,36 @@ func (_c *MockLogInManager_LogInAndSave_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
-		var arg2 string
+		var arg2 models.ClassBookingSchedule
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(models.ClassBookingSchedule)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockLogInManager_LogInAndSave_Call) Return(err error) *MockLogInManager_LogInAndSave_Call {
+func (_c *MockBookingWizardManager_ScheduleBookClass_Call) Return(err error) *MockBookingWizardManager_ScheduleBookClass_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockLogInManager_LogInAndSave_Call) RunAndReturn(run func(ctx context.Context, chatID int64, email string, password string) error) *MockLogInManager_LogInAndSave_Call {
+func (_c *MockBookingWizardManager_ScheduleBookClass_Call) RunAndReturn(run func(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error) *MockBookingWizardManager_ScheduleBookClass_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockLogInBotAPI creates a new instance of MockLogInBotAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockBookingWizardBotAPI creates a new instance of MockBookingWizardBotAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockLogInBotAPI(t interface {
+func NewMockBookingWizardBotAPI(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockLogInBotAPI {
-	mock := &MockLogInBotAPI{}
+}) *MockBookingWizardBotAPI {
+	mock := &MockBookingWizardBotAPI{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -413,21 +605,83 @@ func NewMockLogInBotAPI(t interface {
 	return mock
 }
 
-// MockLogInBotAPI is an autogenerated mock type for the LogInBotAPI type
-type MockLogInBotAPI struct {
+// MockBookingWizardBotAPI is an autogenerated mock type for the BookingWizardBotAPI type
+type MockBookingWizardBotAPI struct {
 	mock.Mock
 }
 
-type MockLogInBotAPI_Expecter struct {
+type MockBookingWizardBotAPI_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockLogInBotAPI) EXPECT() *MockLogInBotAPI_Expecter {
-	return &MockLogInBotAPI_Expecter{mock: &_m.Mock}
+func (_m *MockBookingWizardBotAPI) EXPECT() *MockBookingWizardBotAPI_Expecter {
+	return &MockBookingWizardBotAPI_Expecter{mock: &_m.Mock}
 }
 
-// Send provides a mock function for the type MockLogInBotAPI
-func (_mock *MockLogInBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+// Request provides a mock function for the type MockBookingWizardBotAPI
+func (_mock *MockBookingWizardBotAPI) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Request")
+	}
+
+	var r0 *tgbotapi.APIResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) (*tgbotapi.APIResponse, error)); ok {
+		return returnFunc(c)
+	}
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) *tgbotapi.APIResponse); ok {
+		r0 = returnFunc(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tgbotapi.APIResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(tgbotapi.Chattable) error); ok {
+		r1 = returnFunc(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBookingWizardBotAPI_Request_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Request'
+type MockBookingWizardBotAPI_Request_Call struct {
+	*mock.Call
+}
+
+// Request is a helper method to define mock.On call
+//   - c tgbotapi.Chattable
+func (_e *MockBookingWizardBotAPI_Expecter) Request(c interface{}) *MockBookingWizardBotAPI_Request_Call {
+	return &MockBookingWizardBotAPI_Request_Call{Call: _e.mock.On("Request", c)}
+}
+
+func (_c *MockBookingWizardBotAPI_Request_Call) Run(run func(c tgbotapi.Chattable)) *MockBookingWizardBotAPI_Request_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 tgbotapi.Chattable
+		if args[0] != nil {
+			arg0 = args[0].(tgbotapi.Chattable)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBookingWizardBotAPI_Request_Call) Return(aPIResponse *tgbotapi.APIResponse, err error) *MockBookingWizardBotAPI_Request_Call {
+	_c.Call.Return(aPIResponse, err)
+	return _c
+}
+
+func (_c *MockBookingWizardBotAPI_Request_Call) RunAndReturn(run func(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)) *MockBookingWizardBotAPI_Request_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function for the type MockBookingWizardBotAPI
+func (_mock *MockBookingWizardBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
 	ret := _mock.Called(c)
 
 	if len(ret) == 0 {
@@ -452,18 +706,18 @@ func (_mock *MockLogInBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, erro
 	return r0, r1
 }
 
-// MockLogInBotAPI_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
-type MockLogInBotAPI_Send_Call struct {
+// MockBookingWizardBotAPI_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockBookingWizardBotAPI_Send_Call struct {
 	*mock.Call
 }
 
 // Send is a helper method to define mock.On call
 //   - c tgbotapi.Chattable
-func (_e *MockLogInBotAPI_Expecter) Send(c interface{}) *MockLogInBotAPI_Send_Call {
-	return &MockLogInBotAPI_Send_Call{Call: _e.mock.On("Send", c)}
+func (_e *MockBookingWizardBotAPI_Expecter) Send(c interface{}) *MockBookingWizardBotAPI_Send_Call {
+	return &MockBookingWizardBotAPI_Send_Call{Call: _e.mock.On("Send", c)}
 }
 
-func (_c *MockLogInBotAPI_Send_Call) Run(run func(c tgbotapi.Chattable)) *MockLogInBotAPI_Send_Call {
+func (_c *MockBookingWizardBotAPI_Send_Call) Run(run func(c tgbotapi.Chattable)) *MockBookingWizardBotAPI_Send_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 tgbotapi.Chattable
 		if args[0] != nil {
@@ -476,12 +730,1821 @@ func (_c *MockLogInBotAPI_Send_Call) Run(run func(c tgbotapi.Chattable)) *MockLo
 	return _c
 }
 
-func (_c *MockLogInBotAPI_Send_Call) Return(message tgbotapi.Message, err error) *MockLogInBotAPI_Send_Call {
+func (_c *MockBookingWizardBotAPI_Send_Call) Return(message tgbotapi.Message, err error) *MockBookingWizardBotAPI_Send_Call {
 	_c.Call.Return(message, err)
 	return _c
 }
 
-func (_c *MockLogInBotAPI_Send_Call) RunAndReturn(run func(c tgbotapi.Chattable) (tgbotapi.Message, error)) *MockLogInBotAPI_Send_Call {
+func (_c *MockBookingWizardBotAPI_Send_Call) RunAndReturn(run func(c tgbotapi.Chattable) (tgbotapi.Message, error)) *MockBookingWizardBotAPI_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClassesManager creates a new instance of MockClassesManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClassesManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClassesManager {
+	mock := &MockClassesManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockClassesManager is an autogenerated mock type for the ClassesManager type
+type MockClassesManager struct {
+	mock.Mock
+}
+
+type MockClassesManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClassesManager) EXPECT() *MockClassesManager_Expecter {
+	return &MockClassesManager_Expecter{mock: &_m.Mock}
+}
+
+// GetTimetable provides a mock function for the type MockClassesManager
+func (_mock *MockClassesManager) GetTimetable(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error) {
+	ret := _mock.Called(ctx, chatID, day, nextWeek)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimetable")
+	}
+
+	var r0 []models.ClassSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, bool) ([]models.ClassSchedule, error)); ok {
+		return returnFunc(ctx, chatID, day, nextWeek)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, bool) []models.ClassSchedule); ok {
+		r0 = returnFunc(ctx, chatID, day, nextWeek)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ClassSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, models.Day, bool) error); ok {
+		r1 = returnFunc(ctx, chatID, day, nextWeek)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClassesManager_GetTimetable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTimetable'
+type MockClassesManager_GetTimetable_Call struct {
+	*mock.Call
+}
+
+// GetTimetable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - day models.Day
+//   - nextWeek bool
+func (_e *MockClassesManager_Expecter) GetTimetable(ctx interface{}, chatID interface{}, day interface{}, nextWeek interface{}) *MockClassesManager_GetTimetable_Call {
+	return &MockClassesManager_GetTimetable_Call{Call: _e.mock.On("GetTimetable", ctx, chatID, day, nextWeek)}
+}
+
+func (_c *MockClassesManager_GetTimetable_Call) Run(run func(ctx context.Context, chatID int64, day models.Day, nextWeek bool)) *MockClassesManager_GetTimetable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 models.Day
+		if args[2] != nil {
+			arg2 = args[2].(models.Day)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClassesManager_GetTimetable_Call) Return(classSchedules []models.ClassSchedule, err error) *MockClassesManager_GetTimetable_Call {
+	_c.Call.Return(classSchedules, err)
+	return _c
+}
+
+func (_c *MockClassesManager_GetTimetable_Call) RunAndReturn(run func(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error)) *MockClassesManager_GetTimetable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsAuthenticated provides a mock function for the type MockClassesManager
+func (_mock *MockClassesManager) IsAuthenticated(ctx context.Context, chatID int64) bool {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthenticated")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockClassesManager_IsAuthenticated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthenticated'
+type MockClassesManager_IsAuthenticated_Call struct {
+	*mock.Call
+}
+
+// IsAuthenticated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockClassesManager_Expecter) IsAuthenticated(ctx interface{}, chatID interface{}) *MockClassesManager_IsAuthenticated_Call {
+	return &MockClassesManager_IsAuthenticated_Call{Call: _e.mock.On("IsAuthenticated", ctx, chatID)}
+}
+
+func (_c *MockClassesManager_IsAuthenticated_Call) Run(run func(ctx context.Context, chatID int64)) *MockClassesManager_IsAuthenticated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClassesManager_IsAuthenticated_Call) Return(b bool) *MockClassesManager_IsAuthenticated_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockClassesManager_IsAuthenticated_Call) RunAndReturn(run func(ctx context.Context, chatID int64) bool) *MockClassesManager_IsAuthenticated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockClassesBotAPI creates a new instance of MockClassesBotAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockClassesBotAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClassesBotAPI {
+	mock := &MockClassesBotAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockClassesBotAPI is an autogenerated mock type for the ClassesBotAPI type
+type MockClassesBotAPI struct {
+	mock.Mock
+}
+
+type MockClassesBotAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockClassesBotAPI) EXPECT() *MockClassesBotAPI_Expecter {
+	return &MockClassesBotAPI_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function for the type MockClassesBotAPI
+func (_mock *MockClassesBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 tgbotapi.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) (tgbotapi.Message, error)); ok {
+		return returnFunc(c)
+	}
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) tgbotapi.Message); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Get(0).(tgbotapi.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(tgbotapi.Chattable) error); ok {
+		r1 = returnFunc(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClassesBotAPI_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockClassesBotAPI_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - c tgbotapi.Chattable
+func (_e *MockClassesBotAPI_Expecter) Send(c interface{}) *MockClassesBotAPI_Send_Call {
+	return &MockClassesBotAPI_Send_Call{Call: _e.mock.On("Send", c)}
+}
+
+func (_c *MockClassesBotAPI_Send_Call) Run(run func(c tgbotapi.Chattable)) *MockClassesBotAPI_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 tgbotapi.Chattable
+		if args[0] != nil {
+			arg0 = args[0].(tgbotapi.Chattable)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClassesBotAPI_Send_Call) Return(message tgbotapi.Message, err error) *MockClassesBotAPI_Send_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockClassesBotAPI_Send_Call) RunAndReturn(run func(c tgbotapi.Chattable) (tgbotapi.Message, error)) *MockClassesBotAPI_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLogInManager creates a new instance of MockLogInManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLogInManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLogInManager {
+	mock := &MockLogInManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLogInManager is an autogenerated mock type for the LogInManager type
+type MockLogInManager struct {
+	mock.Mock
+}
+
+type MockLogInManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLogInManager) EXPECT() *MockLogInManager_Expecter {
+	return &MockLogInManager_Expecter{mock: &_m.Mock}
+}
+
+// IsAuthenticated provides a mock function for the type MockLogInManager
+func (_mock *MockLogInManager) IsAuthenticated(ctx context.Context, chatID int64) bool {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthenticated")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockLogInManager_IsAuthenticated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthenticated'
+type MockLogInManager_IsAuthenticated_Call struct {
+	*mock.Call
+}
+
+// IsAuthenticated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockLogInManager_Expecter) IsAuthenticated(ctx interface{}, chatID interface{}) *MockLogInManager_IsAuthenticated_Call {
+	return &MockLogInManager_IsAuthenticated_Call{Call: _e.mock.On("IsAuthenticated", ctx, chatID)}
+}
+
+func (_c *MockLogInManager_IsAuthenticated_Call) Run(run func(ctx context.Context, chatID int64)) *MockLogInManager_IsAuthenticated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLogInManager_IsAuthenticated_Call) Return(b bool) *MockLogInManager_IsAuthenticated_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockLogInManager_IsAuthenticated_Call) RunAndReturn(run func(ctx context.Context, chatID int64) bool) *MockLogInManager_IsAuthenticated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogInAndSave provides a mock function for the type MockLogInManager
+func (_mock *MockLogInManager) LogInAndSave(ctx context.Context, chatID int64, email string, password string) error {
+	ret := _mock.Called(ctx, chatID, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogInAndSave")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = returnFunc(ctx, chatID, email, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLogInManager_LogInAndSave_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogInAndSave'
+type MockLogInManager_LogInAndSave_Call struct {
+	*mock.Call
+}
+
+// LogInAndSave is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - email string
+//   - password string
+func (_e *MockLogInManager_Expecter) LogInAndSave(ctx interface{}, chatID interface{}, email interface{}, password interface{}) *MockLogInManager_LogInAndSave_Call {
+	return &MockLogInManager_LogInAndSave_Call{Call: _e.mock.On("LogInAndSave", ctx, chatID, email, password)}
+}
+
+func (_c *MockLogInManager_LogInAndSave_Call) Run(run func(ctx context.Context, chatID int64, email string, password string)) *MockLogInManager_LogInAndSave_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLogInManager_LogInAndSave_Call) Return(err error) *MockLogInManager_LogInAndSave_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLogInManager_LogInAndSave_Call) RunAndReturn(run func(ctx context.Context, chatID int64, email string, password string) error) *MockLogInManager_LogInAndSave_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLogInBotAPI creates a new instance of MockLogInBotAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLogInBotAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLogInBotAPI {
+	mock := &MockLogInBotAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLogInBotAPI is an autogenerated mock type for the LogInBotAPI type
+type MockLogInBotAPI struct {
+	mock.Mock
+}
+
+type MockLogInBotAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLogInBotAPI) EXPECT() *MockLogInBotAPI_Expecter {
+	return &MockLogInBotAPI_Expecter{mock: &_m.Mock}
+}
+
+// Request provides a mock function for the type MockLogInBotAPI
+func (_mock *MockLogInBotAPI) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Request")
+	}
+
+	var r0 *tgbotapi.APIResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) (*tgbotapi.APIResponse, error)); ok {
+		return returnFunc(c)
+	}
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) *tgbotapi.APIResponse); ok {
+		r0 = returnFunc(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tgbotapi.APIResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(tgbotapi.Chattable) error); ok {
+		r1 = returnFunc(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLogInBotAPI_Request_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Request'
+type MockLogInBotAPI_Request_Call struct {
+	*mock.Call
+}
+
+// Request is a helper method to define mock.On call
+//   - c tgbotapi.Chattable
+func (_e *MockLogInBotAPI_Expecter) Request(c interface{}) *MockLogInBotAPI_Request_Call {
+	return &MockLogInBotAPI_Request_Call{Call: _e.mock.On("Request", c)}
+}
+
+func (_c *MockLogInBotAPI_Request_Call) Run(run func(c tgbotapi.Chattable)) *MockLogInBotAPI_Request_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 tgbotapi.Chattable
+		if args[0] != nil {
+			arg0 = args[0].(tgbotapi.Chattable)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLogInBotAPI_Request_Call) Return(aPIResponse *tgbotapi.APIResponse, err error) *MockLogInBotAPI_Request_Call {
+	_c.Call.Return(aPIResponse, err)
+	return _c
+}
+
+func (_c *MockLogInBotAPI_Request_Call) RunAndReturn(run func(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)) *MockLogInBotAPI_Request_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function for the type MockLogInBotAPI
+func (_mock *MockLogInBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 tgbotapi.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) (tgbotapi.Message, error)); ok {
+		return returnFunc(c)
+	}
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) tgbotapi.Message); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Get(0).(tgbotapi.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(tgbotapi.Chattable) error); ok {
+		r1 = returnFunc(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLogInBotAPI_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockLogInBotAPI_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - c tgbotapi.Chattable
+func (_e *MockLogInBotAPI_Expecter) Send(c interface{}) *MockLogInBotAPI_Send_Call {
+	return &MockLogInBotAPI_Send_Call{Call: _e.mock.On("Send", c)}
+}
+
+func (_c *MockLogInBotAPI_Send_Call) Run(run func(c tgbotapi.Chattable)) *MockLogInBotAPI_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 tgbotapi.Chattable
+		if args[0] != nil {
+			arg0 = args[0].(tgbotapi.Chattable)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLogInBotAPI_Send_Call) Return(message tgbotapi.Message, err error) *MockLogInBotAPI_Send_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockLogInBotAPI_Send_Call) RunAndReturn(run func(c tgbotapi.Chattable) (tgbotapi.Message, error)) *MockLogInBotAPI_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMyScheduleManager creates a new instance of MockMyScheduleManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMyScheduleManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMyScheduleManager {
+	mock := &MockMyScheduleManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMyScheduleManager is an autogenerated mock type for the MyScheduleManager type
+type MockMyScheduleManager struct {
+	mock.Mock
+}
+
+type MockMyScheduleManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMyScheduleManager) EXPECT() *MockMyScheduleManager_Expecter {
+	return &MockMyScheduleManager_Expecter{mock: &_m.Mock}
+}
+
+// DeleteSchedule provides a mock function for the type MockMyScheduleManager
+func (_mock *MockMyScheduleManager) DeleteSchedule(ctx context.Context, chatID int64, scheduleID string) error {
+	ret := _mock.Called(ctx, chatID, scheduleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSchedule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMyScheduleManager_DeleteSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSchedule'
+type MockMyScheduleManager_DeleteSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+func (_e *MockMyScheduleManager_Expecter) DeleteSchedule(ctx interface{}, chatID interface{}, scheduleID interface{}) *MockMyScheduleManager_DeleteSchedule_Call {
+	return &MockMyScheduleManager_DeleteSchedule_Call{Call: _e.mock.On("DeleteSchedule", ctx, chatID, scheduleID)}
+}
+
+func (_c *MockMyScheduleManager_DeleteSchedule_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string)) *MockMyScheduleManager_DeleteSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMyScheduleManager_DeleteSchedule_Call) Return(err error) *MockMyScheduleManager_DeleteSchedule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMyScheduleManager_DeleteSchedule_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string) error) *MockMyScheduleManager_DeleteSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditScheduleDates provides a mock function for the type MockMyScheduleManager
+func (_mock *MockMyScheduleManager) EditScheduleDates(ctx context.Context, chatID int64, scheduleID string, startDate string, endDate string) error {
+	ret := _mock.Called(ctx, chatID, scheduleID, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditScheduleDates")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, string) error); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID, startDate, endDate)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMyScheduleManager_EditScheduleDates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditScheduleDates'
+type MockMyScheduleManager_EditScheduleDates_Call struct {
+	*mock.Call
+}
+
+// EditScheduleDates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+//   - startDate string
+//   - endDate string
+func (_e *MockMyScheduleManager_Expecter) EditScheduleDates(ctx interface{}, chatID interface{}, scheduleID interface{}, startDate interface{}, endDate interface{}) *MockMyScheduleManager_EditScheduleDates_Call {
+	return &MockMyScheduleManager_EditScheduleDates_Call{Call: _e.mock.On("EditScheduleDates", ctx, chatID, scheduleID, startDate, endDate)}
+}
+
+func (_c *MockMyScheduleManager_EditScheduleDates_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string, startDate string, endDate string)) *MockMyScheduleManager_EditScheduleDates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMyScheduleManager_EditScheduleDates_Call) Return(err error) *MockMyScheduleManager_EditScheduleDates_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMyScheduleManager_EditScheduleDates_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string, startDate string, endDate string) error) *MockMyScheduleManager_EditScheduleDates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockMyScheduleManager
+func (_mock *MockMyScheduleManager) GetUser(ctx context.Context, chatID int64) (models.User, bool) {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 models.User
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (models.User, bool)); ok {
+		return returnFunc(ctx, chatID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) models.User); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(models.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
+		r1 = returnFunc(ctx, chatID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
+}
+
+// MockMyScheduleManager_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockMyScheduleManager_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockMyScheduleManager_Expecter) GetUser(ctx interface{}, chatID interface{}) *MockMyScheduleManager_GetUser_Call {
+	return &MockMyScheduleManager_GetUser_Call{Call: _e.mock.On("GetUser", ctx, chatID)}
+}
+
+func (_c *MockMyScheduleManager_GetUser_Call) Run(run func(ctx context.Context, chatID int64)) *MockMyScheduleManager_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMyScheduleManager_GetUser_Call) Return(user models.User, b bool) *MockMyScheduleManager_GetUser_Call {
+	_c.Call.Return(user, b)
+	return _c
+}
+
+func (_c *MockMyScheduleManager_GetUser_Call) RunAndReturn(run func(ctx context.Context, chatID int64) (models.User, bool)) *MockMyScheduleManager_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsAuthenticated provides a mock function for the type MockMyScheduleManager
+func (_mock *MockMyScheduleManager) IsAuthenticated(ctx context.Context, chatID int64) bool {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthenticated")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockMyScheduleManager_IsAuthenticated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthenticated'
+type MockMyScheduleManager_IsAuthenticated_Call struct {
+	*mock.Call
+}
+
+// IsAuthenticated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockMyScheduleManager_Expecter) IsAuthenticated(ctx interface{}, chatID interface{}) *MockMyScheduleManager_IsAuthenticated_Call {
+	return &MockMyScheduleManager_IsAuthenticated_Call{Call: _e.mock.On("IsAuthenticated", ctx, chatID)}
+}
+
+func (_c *MockMyScheduleManager_IsAuthenticated_Call) Run(run func(ctx context.Context, chatID int64)) *MockMyScheduleManager_IsAuthenticated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMyScheduleManager_IsAuthenticated_Call) Return(b bool) *MockMyScheduleManager_IsAuthenticated_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockMyScheduleManager_IsAuthenticated_Call) RunAndReturn(run func(ctx context.Context, chatID int64) bool) *MockMyScheduleManager_IsAuthenticated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseSchedules provides a mock function for the type MockMyScheduleManager
+func (_mock *MockMyScheduleManager) PauseSchedules(ctx context.Context, chatID int64, scheduleID string, until string) (int, error) {
+	ret := _mock.Called(ctx, chatID, scheduleID, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseSchedules")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) (int, error)); ok {
+		return returnFunc(ctx, chatID, scheduleID, until)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) int); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID, until)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string, string) error); ok {
+		r1 = returnFunc(ctx, chatID, scheduleID, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMyScheduleManager_PauseSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseSchedules'
+type MockMyScheduleManager_PauseSchedules_Call struct {
+	*mock.Call
+}
+
+// PauseSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+//   - until string
+func (_e *MockMyScheduleManager_Expecter) PauseSchedules(ctx interface{}, chatID interface{}, scheduleID interface{}, until interface{}) *MockMyScheduleManager_PauseSchedules_Call {
+	return &MockMyScheduleManager_PauseSchedules_Call{Call: _e.mock.On("PauseSchedules", ctx, chatID, scheduleID, until)}
+}
+
+func (_c *MockMyScheduleManager_PauseSchedules_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string, until string)) *MockMyScheduleManager_PauseSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMyScheduleManager_PauseSchedules_Call) Return(n int, err error) *MockMyScheduleManager_PauseSchedules_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockMyScheduleManager_PauseSchedules_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string, until string) (int, error)) *MockMyScheduleManager_PauseSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeSchedules provides a mock function for the type MockMyScheduleManager
+func (_mock *MockMyScheduleManager) ResumeSchedules(ctx context.Context, chatID int64, scheduleID string) (int, error) {
+	ret := _mock.Called(ctx, chatID, scheduleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSchedules")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int, error)); ok {
+		return returnFunc(ctx, chatID, scheduleID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, chatID, scheduleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMyScheduleManager_ResumeSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeSchedules'
+type MockMyScheduleManager_ResumeSchedules_Call struct {
+	*mock.Call
+}
+
+// ResumeSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+func (_e *MockMyScheduleManager_Expecter) ResumeSchedules(ctx interface{}, chatID interface{}, scheduleID interface{}) *MockMyScheduleManager_ResumeSchedules_Call {
+	return &MockMyScheduleManager_ResumeSchedules_Call{Call: _e.mock.On("ResumeSchedules", ctx, chatID, scheduleID)}
+}
+
+func (_c *MockMyScheduleManager_ResumeSchedules_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string)) *MockMyScheduleManager_ResumeSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMyScheduleManager_ResumeSchedules_Call) Return(n int, err error) *MockMyScheduleManager_ResumeSchedules_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockMyScheduleManager_ResumeSchedules_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string) (int, error)) *MockMyScheduleManager_ResumeSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMyScheduleBotAPI creates a new instance of MockMyScheduleBotAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMyScheduleBotAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMyScheduleBotAPI {
+	mock := &MockMyScheduleBotAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMyScheduleBotAPI is an autogenerated mock type for the MyScheduleBotAPI type
+type MockMyScheduleBotAPI struct {
+	mock.Mock
+}
+
+type MockMyScheduleBotAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMyScheduleBotAPI) EXPECT() *MockMyScheduleBotAPI_Expecter {
+	return &MockMyScheduleBotAPI_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function for the type MockMyScheduleBotAPI
+func (_mock *MockMyScheduleBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 tgbotapi.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) (tgbotapi.Message, error)); ok {
+		return returnFunc(c)
+	}
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) tgbotapi.Message); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Get(0).(tgbotapi.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(tgbotapi.Chattable) error); ok {
+		r1 = returnFunc(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMyScheduleBotAPI_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockMyScheduleBotAPI_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - c tgbotapi.Chattable
+func (_e *MockMyScheduleBotAPI_Expecter) Send(c interface{}) *MockMyScheduleBotAPI_Send_Call {
+	return &MockMyScheduleBotAPI_Send_Call{Call: _e.mock.On("Send", c)}
+}
+
+func (_c *MockMyScheduleBotAPI_Send_Call) Run(run func(c tgbotapi.Chattable)) *MockMyScheduleBotAPI_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 tgbotapi.Chattable
+		if args[0] != nil {
+			arg0 = args[0].(tgbotapi.Chattable)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMyScheduleBotAPI_Send_Call) Return(message tgbotapi.Message, err error) *MockMyScheduleBotAPI_Send_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockMyScheduleBotAPI_Send_Call) RunAndReturn(run func(c tgbotapi.Chattable) (tgbotapi.Message, error)) *MockMyScheduleBotAPI_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRemoveManager creates a new instance of MockRemoveManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRemoveManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRemoveManager {
+	mock := &MockRemoveManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRemoveManager is an autogenerated mock type for the RemoveManager type
+type MockRemoveManager struct {
+	mock.Mock
+}
+
+type MockRemoveManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRemoveManager) EXPECT() *MockRemoveManager_Expecter {
+	return &MockRemoveManager_Expecter{mock: &_m.Mock}
+}
+
+// ClassTypes provides a mock function for the type MockRemoveManager
+func (_mock *MockRemoveManager) ClassTypes() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClassTypes")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockRemoveManager_ClassTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClassTypes'
+type MockRemoveManager_ClassTypes_Call struct {
+	*mock.Call
+}
+
+// ClassTypes is a helper method to define mock.On call
+func (_e *MockRemoveManager_Expecter) ClassTypes() *MockRemoveManager_ClassTypes_Call {
+	return &MockRemoveManager_ClassTypes_Call{Call: _e.mock.On("ClassTypes")}
+}
+
+func (_c *MockRemoveManager_ClassTypes_Call) Run(run func()) *MockRemoveManager_ClassTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRemoveManager_ClassTypes_Call) Return(strings []string) *MockRemoveManager_ClassTypes_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *MockRemoveManager_ClassTypes_Call) RunAndReturn(run func() []string) *MockRemoveManager_ClassTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsAuthenticated provides a mock function for the type MockRemoveManager
+func (_mock *MockRemoveManager) IsAuthenticated(ctx context.Context, chatID int64) bool {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthenticated")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockRemoveManager_IsAuthenticated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthenticated'
+type MockRemoveManager_IsAuthenticated_Call struct {
+	*mock.Call
+}
+
+// IsAuthenticated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockRemoveManager_Expecter) IsAuthenticated(ctx interface{}, chatID interface{}) *MockRemoveManager_IsAuthenticated_Call {
+	return &MockRemoveManager_IsAuthenticated_Call{Call: _e.mock.On("IsAuthenticated", ctx, chatID)}
+}
+
+func (_c *MockRemoveManager_IsAuthenticated_Call) Run(run func(ctx context.Context, chatID int64)) *MockRemoveManager_IsAuthenticated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRemoveManager_IsAuthenticated_Call) Return(b bool) *MockRemoveManager_IsAuthenticated_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockRemoveManager_IsAuthenticated_Call) RunAndReturn(run func(ctx context.Context, chatID int64) bool) *MockRemoveManager_IsAuthenticated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveClass provides a mock function for the type MockRemoveManager
+func (_mock *MockRemoveManager) RemoveClass(ctx context.Context, chatID int64, day models.Day, hour string, classType string) (bool, error) {
+	ret := _mock.Called(ctx, chatID, day, hour, classType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveClass")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, string, string) (bool, error)); ok {
+		return returnFunc(ctx, chatID, day, hour, classType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, string, string) bool); ok {
+		r0 = returnFunc(ctx, chatID, day, hour, classType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, models.Day, string, string) error); ok {
+		r1 = returnFunc(ctx, chatID, day, hour, classType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRemoveManager_RemoveClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveClass'
+type MockRemoveManager_RemoveClass_Call struct {
+	*mock.Call
+}
+
+// RemoveClass is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - day models.Day
+//   - hour string
+//   - classType string
+func (_e *MockRemoveManager_Expecter) RemoveClass(ctx interface{}, chatID interface{}, day interface{}, hour interface{}, classType interface{}) *MockRemoveManager_RemoveClass_Call {
+	return &MockRemoveManager_RemoveClass_Call{Call: _e.mock.On("RemoveClass", ctx, chatID, day, hour, classType)}
+}
+
+func (_c *MockRemoveManager_RemoveClass_Call) Run(run func(ctx context.Context, chatID int64, day models.Day, hour string, classType string)) *MockRemoveManager_RemoveClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 models.Day
+		if args[2] != nil {
+			arg2 = args[2].(models.Day)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRemoveManager_RemoveClass_Call) Return(b bool, err error) *MockRemoveManager_RemoveClass_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRemoveManager_RemoveClass_Call) RunAndReturn(run func(ctx context.Context, chatID int64, day models.Day, hour string, classType string) (bool, error)) *MockRemoveManager_RemoveClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveClassType provides a mock function for the type MockRemoveManager
+func (_mock *MockRemoveManager) ResolveClassType(ctx context.Context, chatID int64, input string) (string, error) {
+	ret := _mock.Called(ctx, chatID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveClassType")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (string, error)); ok {
+		return returnFunc(ctx, chatID, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) string); ok {
+		r0 = returnFunc(ctx, chatID, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, chatID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRemoveManager_ResolveClassType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveClassType'
+type MockRemoveManager_ResolveClassType_Call struct {
+	*mock.Call
+}
+
+// ResolveClassType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - input string
+func (_e *MockRemoveManager_Expecter) ResolveClassType(ctx interface{}, chatID interface{}, input interface{}) *MockRemoveManager_ResolveClassType_Call {
+	return &MockRemoveManager_ResolveClassType_Call{Call: _e.mock.On("ResolveClassType", ctx, chatID, input)}
+}
+
+func (_c *MockRemoveManager_ResolveClassType_Call) Run(run func(ctx context.Context, chatID int64, input string)) *MockRemoveManager_ResolveClassType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRemoveManager_ResolveClassType_Call) Return(s string, err error) *MockRemoveManager_ResolveClassType_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockRemoveManager_ResolveClassType_Call) RunAndReturn(run func(ctx context.Context, chatID int64, input string) (string, error)) *MockRemoveManager_ResolveClassType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRemoveBotAPI creates a new instance of MockRemoveBotAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRemoveBotAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRemoveBotAPI {
+	mock := &MockRemoveBotAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRemoveBotAPI is an autogenerated mock type for the RemoveBotAPI type
+type MockRemoveBotAPI struct {
+	mock.Mock
+}
+
+type MockRemoveBotAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRemoveBotAPI) EXPECT() *MockRemoveBotAPI_Expecter {
+	return &MockRemoveBotAPI_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function for the type MockRemoveBotAPI
+func (_mock *MockRemoveBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 tgbotapi.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) (tgbotapi.Message, error)); ok {
+		return returnFunc(c)
+	}
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) tgbotapi.Message); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Get(0).(tgbotapi.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(tgbotapi.Chattable) error); ok {
+		r1 = returnFunc(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRemoveBotAPI_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockRemoveBotAPI_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - c tgbotapi.Chattable
+func (_e *MockRemoveBotAPI_Expecter) Send(c interface{}) *MockRemoveBotAPI_Send_Call {
+	return &MockRemoveBotAPI_Send_Call{Call: _e.mock.On("Send", c)}
+}
+
+func (_c *MockRemoveBotAPI_Send_Call) Run(run func(c tgbotapi.Chattable)) *MockRemoveBotAPI_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 tgbotapi.Chattable
+		if args[0] != nil {
+			arg0 = args[0].(tgbotapi.Chattable)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRemoveBotAPI_Send_Call) Return(message tgbotapi.Message, err error) *MockRemoveBotAPI_Send_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockRemoveBotAPI_Send_Call) RunAndReturn(run func(c tgbotapi.Chattable) (tgbotapi.Message, error)) *MockRemoveBotAPI_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSkipManager creates a new instance of MockSkipManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSkipManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSkipManager {
+	mock := &MockSkipManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSkipManager is an autogenerated mock type for the SkipManager type
+type MockSkipManager struct {
+	mock.Mock
+}
+
+type MockSkipManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSkipManager) EXPECT() *MockSkipManager_Expecter {
+	return &MockSkipManager_Expecter{mock: &_m.Mock}
+}
+
+// ClassTypes provides a mock function for the type MockSkipManager
+func (_mock *MockSkipManager) ClassTypes() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClassTypes")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockSkipManager_ClassTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClassTypes'
+type MockSkipManager_ClassTypes_Call struct {
+	*mock.Call
+}
+
+// ClassTypes is a helper method to define mock.On call
+func (_e *MockSkipManager_Expecter) ClassTypes() *MockSkipManager_ClassTypes_Call {
+	return &MockSkipManager_ClassTypes_Call{Call: _e.mock.On("ClassTypes")}
+}
+
+func (_c *MockSkipManager_ClassTypes_Call) Run(run func()) *MockSkipManager_ClassTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSkipManager_ClassTypes_Call) Return(strings []string) *MockSkipManager_ClassTypes_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *MockSkipManager_ClassTypes_Call) RunAndReturn(run func() []string) *MockSkipManager_ClassTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsAuthenticated provides a mock function for the type MockSkipManager
+func (_mock *MockSkipManager) IsAuthenticated(ctx context.Context, chatID int64) bool {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAuthenticated")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockSkipManager_IsAuthenticated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAuthenticated'
+type MockSkipManager_IsAuthenticated_Call struct {
+	*mock.Call
+}
+
+// IsAuthenticated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockSkipManager_Expecter) IsAuthenticated(ctx interface{}, chatID interface{}) *MockSkipManager_IsAuthenticated_Call {
+	return &MockSkipManager_IsAuthenticated_Call{Call: _e.mock.On("IsAuthenticated", ctx, chatID)}
+}
+
+func (_c *MockSkipManager_IsAuthenticated_Call) Run(run func(ctx context.Context, chatID int64)) *MockSkipManager_IsAuthenticated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSkipManager_IsAuthenticated_Call) Return(b bool) *MockSkipManager_IsAuthenticated_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockSkipManager_IsAuthenticated_Call) RunAndReturn(run func(ctx context.Context, chatID int64) bool) *MockSkipManager_IsAuthenticated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveClassType provides a mock function for the type MockSkipManager
+func (_mock *MockSkipManager) ResolveClassType(ctx context.Context, chatID int64, input string) (string, error) {
+	ret := _mock.Called(ctx, chatID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveClassType")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (string, error)); ok {
+		return returnFunc(ctx, chatID, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) string); ok {
+		r0 = returnFunc(ctx, chatID, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, chatID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSkipManager_ResolveClassType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveClassType'
+type MockSkipManager_ResolveClassType_Call struct {
+	*mock.Call
+}
+
+// ResolveClassType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - input string
+func (_e *MockSkipManager_Expecter) ResolveClassType(ctx interface{}, chatID interface{}, input interface{}) *MockSkipManager_ResolveClassType_Call {
+	return &MockSkipManager_ResolveClassType_Call{Call: _e.mock.On("ResolveClassType", ctx, chatID, input)}
+}
+
+func (_c *MockSkipManager_ResolveClassType_Call) Run(run func(ctx context.Context, chatID int64, input string)) *MockSkipManager_ResolveClassType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSkipManager_ResolveClassType_Call) Return(s string, err error) *MockSkipManager_ResolveClassType_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSkipManager_ResolveClassType_Call) RunAndReturn(run func(ctx context.Context, chatID int64, input string) (string, error)) *MockSkipManager_ResolveClassType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SkipScheduledClass provides a mock function for the type MockSkipManager
+func (_mock *MockSkipManager) SkipScheduledClass(ctx context.Context, chatID int64, scheduleID string, classDate string) error {
+	ret := _mock.Called(ctx, chatID, scheduleID, classDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SkipScheduledClass")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID, classDate)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSkipManager_SkipScheduledClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SkipScheduledClass'
+type MockSkipManager_SkipScheduledClass_Call struct {
+	*mock.Call
+}
+
+// SkipScheduledClass is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+//   - classDate string
+func (_e *MockSkipManager_Expecter) SkipScheduledClass(ctx interface{}, chatID interface{}, scheduleID interface{}, classDate interface{}) *MockSkipManager_SkipScheduledClass_Call {
+	return &MockSkipManager_SkipScheduledClass_Call{Call: _e.mock.On("SkipScheduledClass", ctx, chatID, scheduleID, classDate)}
+}
+
+func (_c *MockSkipManager_SkipScheduledClass_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string, classDate string)) *MockSkipManager_SkipScheduledClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSkipManager_SkipScheduledClass_Call) Return(err error) *MockSkipManager_SkipScheduledClass_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSkipManager_SkipScheduledClass_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string, classDate string) error) *MockSkipManager_SkipScheduledClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSkipBotAPI creates a new instance of MockSkipBotAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSkipBotAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSkipBotAPI {
+	mock := &MockSkipBotAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSkipBotAPI is an autogenerated mock type for the SkipBotAPI type
+type MockSkipBotAPI struct {
+	mock.Mock
+}
+
+type MockSkipBotAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSkipBotAPI) EXPECT() *MockSkipBotAPI_Expecter {
+	return &MockSkipBotAPI_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function for the type MockSkipBotAPI
+func (_mock *MockSkipBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 tgbotapi.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) (tgbotapi.Message, error)); ok {
+		return returnFunc(c)
+	}
+	if returnFunc, ok := ret.Get(0).(func(tgbotapi.Chattable) tgbotapi.Message); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Get(0).(tgbotapi.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(tgbotapi.Chattable) error); ok {
+		r1 = returnFunc(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSkipBotAPI_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockSkipBotAPI_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - c tgbotapi.Chattable
+func (_e *MockSkipBotAPI_Expecter) Send(c interface{}) *MockSkipBotAPI_Send_Call {
+	return &MockSkipBotAPI_Send_Call{Call: _e.mock.On("Send", c)}
+}
+
+func (_c *MockSkipBotAPI_Send_Call) Run(run func(c tgbotapi.Chattable)) *MockSkipBotAPI_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 tgbotapi.Chattable
+		if args[0] != nil {
+			arg0 = args[0].(tgbotapi.Chattable)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSkipBotAPI_Send_Call) Return(message tgbotapi.Message, err error) *MockSkipBotAPI_Send_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockSkipBotAPI_Send_Call) RunAndReturn(run func(c tgbotapi.Chattable) (tgbotapi.Message, error)) *MockSkipBotAPI_Send_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const myScheduleUsage = "Manage your booking rules with their IDs:\n" +
+	"/myschedule - List your rules\n" +
+	"/myschedule delete <id>\n" +
+	"/myschedule edit <id> <start-date|-> <end-date|->\n" +
+	"/myschedule pause <id|all> [YYYY-MM-DD]\n" +
+	"/myschedule resume <id|all>"
+
+type MyScheduleManager interface {
+	IsAuthenticated(ctx context.Context, chatID int64) bool
+	GetUser(ctx context.Context, chatID int64) (models.User, bool)
+	DeleteSchedule(ctx context.Context, chatID int64, scheduleID string) error
+	EditScheduleDates(ctx context.Context, chatID int64, scheduleID, startDate, endDate string) error
+	PauseSchedules(ctx context.Context, chatID int64, scheduleID, until string) (int, error)
+	ResumeSchedules(ctx context.Context, chatID int64, scheduleID string) (int, error)
+}
+
+type MyScheduleBotAPI interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+}
+
+// MyScheduleHandler lists the user's weekly booking rules, and deletes, edits,
+// pauses and resumes them by ID
+type MyScheduleHandler struct {
+	api     MyScheduleBotAPI
+	manager MyScheduleManager
+}
+
+func NewMyScheduleHandler(api MyScheduleBotAPI, manager MyScheduleManager) *MyScheduleHandler {
+	return &MyScheduleHandler{
+		api:     api,
+		manager: manager,
+	}
+}
+
+func (h *MyScheduleHandler) Handle(update tgbotapi.Update) {
+	ctx := context.Background()
+	chatID := update.Message.Chat.ID
+
+	if !h.manager.IsAuthenticated(ctx, chatID) {
+		h.sendMessage(chatID, "Please login first using /login command")
+		return
+	}
+
+	// Rule IDs may contain spaces, e.g. Friday-08:00-Open box
+	args := strings.Fields(update.Message.Text)[1:]
+	if len(args) == 0 || strings.EqualFold(args[0], "list") {
+		h.list(ctx, chatID)
+		return
+	}
+
+	action, args := strings.ToLower(args[0]), args[1:]
+	switch action {
+	case "delete":
+		h.delete(ctx, chatID, args)
+	case "edit":
+		h.edit(ctx, chatID, args)
+	case "pause":
+		h.pause(ctx, chatID, args)
+	case "resume":
+		h.resume(ctx, chatID, args)
+	default:
+		h.sendMessage(chatID, myScheduleUsage)
+	}
+}
+
+// list shows the user's rules with their IDs, in the order they are booked
+func (h *MyScheduleHandler) list(ctx context.Context, chatID int64) {
+	user, exists := h.manager.GetUser(ctx, chatID)
+	if !exists || len(user.ClassBookingSchedules) == 0 {
+		h.sendMessage(chatID, "You have no booking rules. Add one with /book.")
+		return
+	}
+
+	today := time.Now().Format(models.DateLayout)
+	var message strings.Builder
+	message.WriteString("📋 Your booking rules\n")
+	for _, schedule := range user.ClassBookingSchedules {
+		fmt.Fprintf(&message, "\n🆔 %s\n%s on %s at %s", schedule.ID, schedule.ClassType, schedule.Day, schedule.Hour)
+		if schedule.StartDate != "" || schedule.EndDate != "" {
+			fmt.Fprintf(&message, " (%s → %s)", schedule.StartDate, schedule.EndDate)
+		}
+		message.WriteString("\n")
+		for _, alternative := range schedule.Alternatives {
+			fmt.Fprintf(&message, "  or %s\n", alternative)
+		}
+		switch {
+		case schedule.Paused && schedule.PausedUntil == "":
+			message.WriteString("⏸ Paused until resumed\n")
+		case schedule.Paused && schedule.PausedUntil >= today:
+			fmt.Fprintf(&message, "⏸ Paused until %s\n", schedule.PausedUntil)
+		}
+	}
+	message.WriteString("\n" + myScheduleUsage)
+
+	h.sendMessage(chatID, message.String())
+}
+
+func (h *MyScheduleHandler) delete(ctx context.Context, chatID int64, args []string) {
+	scheduleID := strings.Join(args, " ")
+	if scheduleID == "" {
+		h.sendMessage(chatID, myScheduleUsage)
+		return
+	}
+
+	if err := h.manager.DeleteSchedule(ctx, chatID, scheduleID); err != nil {
+		h.sendError(chatID, scheduleID, "delete", err)
+		return
+	}
+	h.sendMessage(chatID, fmt.Sprintf("🗑 Booking rule %s deleted. Classes already booked are kept, cancel them with /remove.", scheduleID))
+}
+
+func (h *MyScheduleHandler) edit(ctx context.Context, chatID int64, args []string) {
+	if len(args) < 3 {
+		h.sendMessage(chatID, myScheduleUsage)
+		return
+	}
+	scheduleID := strings.Join(args[:len(args)-2], " ")
+
+	// "-" leaves that end of the range open
+	dates := make([]string, 2)
+	for i, raw := range args[len(args)-2:] {
+		if raw == "-" {
+			continue
+		}
+		date := utils.SanitizeInput(raw)
+		if err := utils.ValidateDate(date); err != nil {
+			h.sendMessage(chatID,
+				"Invalid date. Please use YYYY-MM-DD format (e.g., 2025-09-01), or - for no date")
+			return
+		}
+		dates[i] = date
+	}
+	startDate, endDate := dates[0], dates[1]
+	if startDate != "" && endDate != "" && endDate < startDate {
+		h.sendMessage(chatID,
+			"Invalid end date. Please use YYYY-MM-DD format, on or after the start date")
+		return
+	}
+
+	if err := h.manager.EditScheduleDates(ctx, chatID, scheduleID, startDate, endDate); err != nil {
+		if message, ok := limitsMessage(err); ok {
+			h.sendMessage(chatID, message)
+			return
+		}
+		h.sendError(chatID, scheduleID, "edit", err)
+		return
+	}
+	h.sendMessage(chatID, fmt.Sprintf("✏️ Booking rule %s now books classes from %s to %s.",
+		scheduleID, dateOr(startDate, "now"), dateOr(endDate, "forever")))
+}
+
+func (h *MyScheduleHandler) pause(ctx context.Context, chatID int64, args []string) {
+	// An optional last date ends the pause
+	var until string
+	if len(args) > 1 && utils.ValidateDate(args[len(args)-1]) == nil {
+		until = args[len(args)-1]
+		args = args[:len(args)-1]
+		if until < time.Now().Format(models.DateLayout) {
+			h.sendMessage(chatID, "The pause must end today or later.")
+			return
+		}
+	}
+	scheduleID, ok := h.scheduleID(chatID, args)
+	if !ok {
+		return
+	}
+
+	paused, err := h.manager.PauseSchedules(ctx, chatID, scheduleID, until)
+	if err != nil {
+		h.sendPartialError(chatID, scheduleID, "pause", "paused", paused, err)
+		return
+	}
+	h.sendMessage(chatID, fmt.Sprintf("⏸ %d booking rule(s) paused until %s.", paused, dateOr(until, "you resume them with /myschedule resume")))
+}
+
+func (h *MyScheduleHandler) resume(ctx context.Context, chatID int64, args []string) {
+	scheduleID, ok := h.scheduleID(chatID, args)
+	if !ok {
+		return
+	}
+
+	resumed, err := h.manager.ResumeSchedules(ctx, chatID, scheduleID)
+	if err != nil {
+		h.sendPartialError(chatID, scheduleID, "resume", "resumed", resumed, err)
+		return
+	}
+	h.sendMessage(chatID, fmt.Sprintf("▶️ %d booking rule(s) resumed, their next classes are booked as usual.", resumed))
+}
+
+// scheduleID returns the rule ID of the arguments, or an empty one for all of
+// the user's rules
+func (h *MyScheduleHandler) scheduleID(chatID int64, args []string) (string, bool) {
+	scheduleID := strings.Join(args, " ")
+	switch {
+	case scheduleID == "":
+		h.sendMessage(chatID, myScheduleUsage)
+		return "", false
+	case strings.EqualFold(scheduleID, "all"):
+		return "", true
+	}
+	return scheduleID, true
+}
+
+// sendError tells the user the action on the rule failed
+func (h *MyScheduleHandler) sendError(chatID int64, scheduleID, action string, err error) {
+	if errors.Is(err, usecase.ErrScheduleNotFound) {
+		h.sendMessage(chatID, "No booking rule has that ID. Use /myschedule to list your rules and their IDs.")
+		return
+	}
+
+	h.sendMessage(chatID, fmt.Sprintf("Failed to %s the booking rule. Please try again later.", action))
+	slog.Error("Failed to update booking rule",
+		"error", err,
+		"action", action,
+		"chat_id", chatID,
+		"schedule_id", scheduleID)
+}
+
+// sendPartialError tells the user how many of their rules were changed before
+// the action failed on the next one, or that it failed when none was
+func (h *MyScheduleHandler) sendPartialError(chatID int64, scheduleID, action, done string, changed int, err error) {
+	if changed == 0 {
+		h.sendError(chatID, scheduleID, action, err)
+		return
+	}
+
+	h.sendMessage(chatID, fmt.Sprintf("Only %d booking rule(s) were %s, the others failed. Please try again later.", changed, done))
+	slog.Error("Failed to update booking rules",
+		"error", err,
+		"action", action,
+		"changed", changed,
+		"chat_id", chatID)
+}
+
+// dateOr returns the date, or fallback when it is empty
+func dateOr(date, fallback string) string {
+	if date == "" {
+		return fallback
+	}
+	return date
+}
+
+func (h *MyScheduleHandler) sendMessage(chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	if _, err := h.api.Send(msg); err != nil {
+		slog.Error("Failed to send message",
+			"error", err,
+			"chat_id", chatID)
+	}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMyScheduleHandler_Handle(t *testing.T) {
+	const testChatID int64 = 123
+
+	sentText := func(want string) func(tgbotapi.Chattable) bool {
+		return func(c tgbotapi.Chattable) bool {
+			msg, ok := c.(tgbotapi.MessageConfig)
+			return ok && msg.Text == want
+		}
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		setupMocks func(*MockMyScheduleBotAPI, *MockMyScheduleManager)
+	}{
+		{
+			name:  "list rules",
+			input: "/myschedule",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().GetUser(mock.Anything, testChatID).Return(models.User{
+					ChatID: testChatID,
+					ClassBookingSchedules: []models.ClassBookingSchedule{
+						{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod",
+							Alternatives: []models.ClassAlternative{{Hour: "08:00", ClassType: "Wod"}}},
+						{ID: "Friday-08:00-Open box", Day: "Friday", Hour: "08:00", ClassType: "Open box",
+							EndDate: "2025-12-22", Paused: true},
+					},
+				}, true)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && strings.HasPrefix(msg.Text, "📋 Your booking rules\n\n"+
+						"🆔 Monday-07:00-Wod\nWod on Monday at 07:00\n  or Wod at 08:00\n\n"+
+						"🆔 Friday-08:00-Open box\nOpen box on Friday at 08:00 ( → 2025-12-22)\n⏸ Paused until resumed\n")
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "no rules",
+			input: "/myschedule list",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().GetUser(mock.Anything, testChatID).Return(models.User{ChatID: testChatID}, true)
+				api.EXPECT().Send(mock.MatchedBy(sentText("You have no booking rules. Add one with /book."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "delete a rule whose ID has spaces",
+			input: "/myschedule delete Friday-08:00-Open box",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().DeleteSchedule(mock.Anything, testChatID, "Friday-08:00-Open box").Return(nil)
+				api.EXPECT().Send(mock.MatchedBy(sentText("🗑 Booking rule Friday-08:00-Open box deleted. Classes already booked are kept, cancel them with /remove."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "unknown rule",
+			input: "/myschedule delete Sunday-10:00-Wod",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().DeleteSchedule(mock.Anything, testChatID, "Sunday-10:00-Wod").Return(usecase.ErrScheduleNotFound)
+				api.EXPECT().Send(mock.MatchedBy(sentText("No booking rule has that ID. Use /myschedule to list your rules and their IDs."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "edit the date range",
+			input: "/myschedule edit Monday-07:00-Wod 2025-09-01 -",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().EditScheduleDates(mock.Anything, testChatID, "Monday-07:00-Wod", "2025-09-01", "").Return(nil)
+				api.EXPECT().Send(mock.MatchedBy(sentText("✏️ Booking rule Monday-07:00-Wod now books classes from 2025-09-01 to forever."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "edit with an invalid date",
+			input: "/myschedule edit Monday-07:00-Wod 2025-09-01 soon",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(sentText("Invalid date. Please use YYYY-MM-DD format (e.g., 2025-09-01), or - for no date"))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "pause all rules until a date",
+			input: "/myschedule pause all 2099-08-31",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().PauseSchedules(mock.Anything, testChatID, "", "2099-08-31").Return(3, nil)
+				api.EXPECT().Send(mock.MatchedBy(sentText("⏸ 3 booking rule(s) paused until 2099-08-31."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "pause a rule until resumed",
+			input: "/myschedule pause Monday-07:00-Wod",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().PauseSchedules(mock.Anything, testChatID, "Monday-07:00-Wod", "").Return(1, nil)
+				api.EXPECT().Send(mock.MatchedBy(sentText("⏸ 1 booking rule(s) paused until you resume them with /myschedule resume."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "pause ending in the past",
+			input: "/myschedule pause all 2020-01-01",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(sentText("The pause must end today or later."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "resume all rules",
+			input: "/myschedule resume all",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResumeSchedules(mock.Anything, testChatID, "").Return(2, nil)
+				api.EXPECT().Send(mock.MatchedBy(sentText("▶️ 2 booking rule(s) resumed, their next classes are booked as usual."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "pause fails part way through",
+			input: "/myschedule pause all",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().PauseSchedules(mock.Anything, testChatID, "", "").Return(1, errors.New("storage unavailable"))
+				api.EXPECT().Send(mock.MatchedBy(sentText("Only 1 booking rule(s) were paused, the others failed. Please try again later."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "resume fails",
+			input: "/myschedule resume all",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResumeSchedules(mock.Anything, testChatID, "").Return(0, errors.New("storage unavailable"))
+				api.EXPECT().Send(mock.MatchedBy(sentText("Failed to resume the booking rule. Please try again later."))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "unknown action",
+			input: "/myschedule rename Monday-07:00-Wod",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(sentText(myScheduleUsage))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "not authenticated",
+			input: "/myschedule",
+			setupMocks: func(api *MockMyScheduleBotAPI, manager *MockMyScheduleManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(false)
+				api.EXPECT().Send(mock.MatchedBy(sentText("Please login first using /login command"))).Return(tgbotapi.Message{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewMockMyScheduleBotAPI(t)
+			manager := NewMockMyScheduleManager(t)
+
+			handler := NewMyScheduleHandler(api, manager)
+
+			tt.setupMocks(api, manager)
+
+			update := tgbotapi.Update{
+				Message: &tgbotapi.Message{
+					Chat: &tgbotapi.Chat{ID: testChatID},
+					Text: tt.input,
+				},
+			}
+
+			handler.Handle(update)
+		})
+	}
+}
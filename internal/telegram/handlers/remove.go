@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type RemoveManager interface {
+	IsAuthenticated(ctx context.Context, chatID int64) bool
+	RemoveClass(ctx context.Context, chatID int64, day models.Day, hour, classType string) (bool, error)
+	ResolveClassType(ctx context.Context, chatID int64, input string) (string, error)
+	ClassTypes() []string
+}
+
+type RemoveBotAPI interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+}
+
+// RemoveHandler cancels booked classes and removes their weekly booking rules
+type RemoveHandler struct {
+	api     RemoveBotAPI
+	manager RemoveManager
+}
+
+func NewRemoveHandler(api RemoveBotAPI, manager RemoveManager) *RemoveHandler {
+	return &RemoveHandler{
+		api:     api,
+		manager: manager,
+	}
+}
+
+func (h *RemoveHandler) Handle(update tgbotapi.Update) {
+	ctx := context.Background()
+
+	if !h.manager.IsAuthenticated(ctx, update.Message.Chat.ID) {
+		h.sendMessage(update.Message.Chat.ID, "Please login first using /login command")
+		return
+	}
+
+	args := strings.Split(update.Message.Text, " ")
+	if len(args) != 4 {
+		h.sendMessage(update.Message.Chat.ID,
+			"Please provide the class to remove: /remove <day> <hour> <class-type> (e.g., /remove Monday 10:00 wod)")
+		return
+	}
+
+	rawDay := utils.SanitizeInput(args[1])
+	hour := utils.SanitizeInput(args[2])
+	rawClassType := utils.SanitizeInput(args[3])
+
+	day, err := models.ParseDay(rawDay, time.Now())
+	if err != nil {
+		h.sendMessage(update.Message.Chat.ID,
+			"Invalid day. Please use a day name in English or Spanish (e.g., Monday, mon, lunes), today or tomorrow")
+		return
+	}
+
+	if err := utils.ValidateTime(hour); err != nil {
+		h.sendMessage(update.Message.Chat.ID,
+			"Invalid time format. Please use HH:MM format (e.g., 10:00)")
+		return
+	}
+
+	// Class types are resolved the same way as in /book
+	classType, err := h.manager.ResolveClassType(ctx, update.Message.Chat.ID, rawClassType)
+	if err != nil {
+		h.sendMessage(update.Message.Chat.ID, invalidClassTypeMessage(h.manager.ClassTypes()))
+		return
+	}
+	class := fmt.Sprintf("%s at %s for %s", day, hour, classType)
+
+	scheduleRemoved, err := h.manager.RemoveClass(ctx, update.Message.Chat.ID, day, hour, classType)
+	switch {
+	case err == nil && scheduleRemoved:
+		h.sendMessage(update.Message.Chat.ID,
+			fmt.Sprintf("Booking cancelled and weekly schedule removed: %s", class))
+	case err == nil:
+		h.sendMessage(update.Message.Chat.ID,
+			fmt.Sprintf("Booking cancelled: %s", class))
+	case errors.Is(err, usecase.ErrCancelBookingFailed) && scheduleRemoved:
+		h.sendMessage(update.Message.Chat.ID,
+			fmt.Sprintf("Weekly schedule removed: %s\nThe booking could not be cancelled on WODBuster, please check your reservations on the website.", class))
+	case errors.Is(err, usecase.ErrCancelBookingFailed):
+		h.sendMessage(update.Message.Chat.ID,
+			"Could not cancel the booking on WODBuster and no scheduled class matches that day, hour and class type. Use /status to see your scheduled classes.")
+	default:
+		h.sendMessage(update.Message.Chat.ID,
+			"Failed to remove class. Please try again later.")
+	}
+
+	if err != nil {
+		slog.Error("Failed to remove class",
+			"error", err,
+			"chat_id", update.Message.Chat.ID,
+			"day", day,
+			"hour", hour,
+			"class_type", classType)
+	}
+}
+
+func (h *RemoveHandler) sendMessage(chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	if _, err := h.api.Send(msg); err != nil {
+		slog.Error("Failed to send message",
+			"error", err,
+			"chat_id", chatID)
+	}
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRemoveHandler_Handle(t *testing.T) {
+	const testChatID int64 = 123
+	cancelFailed := fmt.Errorf("%w: %w", usecase.ErrCancelBookingFailed, errors.New("class is not booked"))
+
+	tests := []struct {
+		name       string
+		input      string
+		setupMocks func(*MockRemoveBotAPI, *MockRemoveManager)
+	}{
+		{
+			name:  "cancels booking and removes schedule",
+			input: "/remove monday 10:00 wod",
+			setupMocks: func(api *MockRemoveBotAPI, manager *MockRemoveManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().RemoveClass(mock.Anything, testChatID, models.DayMonday, "10:00", "Wod").Return(true, nil)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Booking cancelled and weekly schedule removed: Monday at 10:00 for Wod"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "cancels booking without schedule",
+			input: "/remove Monday 10:00 wod",
+			setupMocks: func(api *MockRemoveBotAPI, manager *MockRemoveManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().RemoveClass(mock.Anything, testChatID, models.DayMonday, "10:00", "Wod").Return(false, nil)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Booking cancelled: Monday at 10:00 for Wod"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "schedule removed but cancellation failed",
+			input: "/remove Monday 10:00 wod",
+			setupMocks: func(api *MockRemoveBotAPI, manager *MockRemoveManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().RemoveClass(mock.Anything, testChatID, models.DayMonday, "10:00", "Wod").Return(true, cancelFailed)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Weekly schedule removed: Monday at 10:00 for Wod\nThe booking could not be cancelled on WODBuster, please check your reservations on the website."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "nothing to remove",
+			input: "/remove Friday 10:00 wod",
+			setupMocks: func(api *MockRemoveBotAPI, manager *MockRemoveManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().RemoveClass(mock.Anything, testChatID, models.DayFriday, "10:00", "Wod").Return(false, cancelFailed)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Could not cancel the booking on WODBuster and no scheduled class matches that day, hour and class type. Use /status to see your scheduled classes."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "invalid time",
+			input: "/remove Monday 25:00 wod",
+			setupMocks: func(api *MockRemoveBotAPI, manager *MockRemoveManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Invalid time format. Please use HH:MM format (e.g., 10:00)"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "missing arguments",
+			input: "/remove Monday",
+			setupMocks: func(api *MockRemoveBotAPI, manager *MockRemoveManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Please provide the class to remove: /remove <day> <hour> <class-type> (e.g., /remove Monday 10:00 wod)"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "not authenticated",
+			input: "/remove Monday 10:00 wod",
+			setupMocks: func(api *MockRemoveBotAPI, manager *MockRemoveManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(false)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Please login first using /login command"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewMockRemoveBotAPI(t)
+			manager := NewMockRemoveManager(t)
+
+			handler := NewRemoveHandler(api, manager)
+
+			tt.setupMocks(api, manager)
+
+			update := tgbotapi.Update{
+				Message: &tgbotapi.Message{
+					Chat: &tgbotapi.Chat{ID: testChatID},
+					Text: tt.input,
+				},
+			}
+
+			handler.Handle(update)
+		})
+	}
+}
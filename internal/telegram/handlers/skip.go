@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type SkipManager interface {
+	IsAuthenticated(ctx context.Context, chatID int64) bool
+	SkipScheduledClass(ctx context.Context, chatID int64, scheduleID, classDate string) error
+	ResolveClassType(ctx context.Context, chatID int64, input string) (string, error)
+	ClassTypes() []string
+}
+
+type SkipBotAPI interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+}
+
+// SkipHandler adds "skip week" exceptions to weekly booking rules
+type SkipHandler struct {
+	api     SkipBotAPI
+	manager SkipManager
+}
+
+func NewSkipHandler(api SkipBotAPI, manager SkipManager) *SkipHandler {
+	return &SkipHandler{
+		api:     api,
+		manager: manager,
+	}
+}
+
+func (h *SkipHandler) Handle(update tgbotapi.Update) {
+	ctx := context.Background()
+
+	if !h.manager.IsAuthenticated(ctx, update.Message.Chat.ID) {
+		h.sendMessage(update.Message.Chat.ID, "Please login first using /login command")
+		return
+	}
+
+	args := strings.Split(update.Message.Text, " ")
+	if len(args) != 5 {
+		h.sendMessage(update.Message.Chat.ID,
+			"Please provide the scheduled class and the date to skip: /skip <day> <hour> <class-type> <YYYY-MM-DD> (e.g., /skip Monday 10:00 wod 2025-08-18)")
+		return
+	}
+
+	rawDay := utils.SanitizeInput(args[1])
+	hour := utils.SanitizeInput(args[2])
+	rawClassType := utils.SanitizeInput(args[3])
+	classDate := utils.SanitizeInput(args[4])
+
+	if err := utils.ValidateDate(classDate); err != nil {
+		h.sendMessage(update.Message.Chat.ID,
+			"Invalid date. Please use YYYY-MM-DD format (e.g., 2025-08-18)")
+		return
+	}
+
+	day, err := models.ParseDay(rawDay, time.Now())
+	if err != nil {
+		h.sendMessage(update.Message.Chat.ID,
+			"Invalid day. Please use a day name in English or Spanish (e.g., Monday, mon, lunes), today or tomorrow")
+		return
+	}
+
+	classType, err := h.manager.ResolveClassType(ctx, update.Message.Chat.ID, rawClassType)
+	if err != nil {
+		h.sendMessage(update.Message.Chat.ID, invalidClassTypeMessage(h.manager.ClassTypes()))
+		return
+	}
+
+	// Schedule IDs are built the same way as in /book
+	scheduleID := fmt.Sprintf("%s-%s-%s", day, hour, classType)
+
+	if err := h.manager.SkipScheduledClass(ctx, update.Message.Chat.ID, scheduleID, classDate); err != nil {
+		if errors.Is(err, usecase.ErrScheduleNotFound) {
+			h.sendMessage(update.Message.Chat.ID,
+				"No scheduled class matches that day, hour and class type. Use /status to see your scheduled classes.")
+			return
+		}
+
+		h.sendMessage(update.Message.Chat.ID,
+			"Failed to skip class. Please try again later.")
+		slog.Error("Failed to skip scheduled class",
+			"error", err,
+			"chat_id", update.Message.Chat.ID,
+			"schedule_id", scheduleID,
+			"class_date", classDate)
+		return
+	}
+
+	h.sendMessage(update.Message.Chat.ID,
+		fmt.Sprintf("Got it! %s will not be booked on %s.", scheduleID, classDate))
+}
+
+func (h *SkipHandler) sendMessage(chatID int64, text string) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	if _, err := h.api.Send(msg); err != nil {
+		slog.Error("Failed to send message",
+			"error", err,
+			"chat_id", chatID)
+	}
+}
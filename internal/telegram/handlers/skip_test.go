@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/telegram/usecase"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSkipHandler_Handle(t *testing.T) {
+	const testChatID int64 = 123
+
+	tests := []struct {
+		name       string
+		input      string
+		setupMocks func(*MockSkipBotAPI, *MockSkipManager)
+	}{
+		{
+			name:  "successful skip",
+			input: "/skip monday 10:00 wod 2025-08-18",
+			setupMocks: func(api *MockSkipBotAPI, manager *MockSkipManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().SkipScheduledClass(mock.Anything, testChatID, "Monday-10:00-Wod", "2025-08-18").Return(nil)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Got it! Monday-10:00-Wod will not be booked on 2025-08-18."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "unknown schedule",
+			input: "/skip Friday 10:00 wod 2025-08-22",
+			setupMocks: func(api *MockSkipBotAPI, manager *MockSkipManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				manager.EXPECT().ResolveClassType(mock.Anything, testChatID, "wod").Return("Wod", nil)
+				manager.EXPECT().SkipScheduledClass(mock.Anything, testChatID, "Friday-10:00-Wod", "2025-08-22").Return(usecase.ErrScheduleNotFound)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "No scheduled class matches that day, hour and class type. Use /status to see your scheduled classes."
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "invalid date",
+			input: "/skip Monday 10:00 wod 18-08-2025",
+			setupMocks: func(api *MockSkipBotAPI, manager *MockSkipManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(true)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Invalid date. Please use YYYY-MM-DD format (e.g., 2025-08-18)"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name:  "not authenticated",
+			input: "/skip Monday 10:00 wod 2025-08-18",
+			setupMocks: func(api *MockSkipBotAPI, manager *MockSkipManager) {
+				manager.EXPECT().IsAuthenticated(mock.Anything, testChatID).Return(false)
+				api.EXPECT().Send(mock.MatchedBy(func(c tgbotapi.Chattable) bool {
+					msg, ok := c.(tgbotapi.MessageConfig)
+					return ok && msg.Text == "Please login first using /login command"
+				})).Return(tgbotapi.Message{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewMockSkipBotAPI(t)
+			manager := NewMockSkipManager(t)
+
+			handler := NewSkipHandler(api, manager)
+
+			tt.setupMocks(api, manager)
+
+			update := tgbotapi.Update{
+				Message: &tgbotapi.Message{
+					Chat: &tgbotapi.Chat{ID: testChatID},
+					Text: tt.input,
+				},
+			}
+
+			handler.Handle(update)
+		})
+	}
+}
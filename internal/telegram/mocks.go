@@ -40,16 +40,16 @@ func (_m *MockBotManager) EXPECT() *MockBotManager_Expecter {
 }
 
 // CancelBooking provides a mock function for the type MockBotManager
-func (_mock *MockBotManager) CancelBooking(chatID int64) bool {
-	ret := _mock.Called(chatID)
+func (_mock *MockBotManager) CancelBooking(chatID int64, attemptID string) bool {
+	ret := _mock.Called(chatID, attemptID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CancelBooking")
 	}
 
 	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(int64) bool); ok {
-		r0 = returnFunc(chatID)
+	if returnFunc, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = returnFunc(chatID, attemptID)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -63,18 +63,24 @@ type MockBotManager_CancelBooking_Call struct {
 
 // CancelBooking is a helper method to define mock.On call
 //   - chatID int64
-func (_e *MockBotManager_Expecter) CancelBooking(chatID interface{}) *MockBotManager_CancelBooking_Call {
-	return &MockBotManager_CancelBooking_Call{Call: _e.mock.On("CancelBooking", chatID)}
+//   - attemptID string
+func (_e *MockBotManager_Expecter) CancelBooking(chatID interface{}, attemptID interface{}) *MockBotManager_CancelBooking_Call {
+	return &MockBotManager_CancelBooking_Call{Call: _e.mock.On("CancelBooking", chatID, attemptID)}
 }
 
-func (_c *MockBotManager_CancelBooking_Call) Run(run func(chatID int64)) *MockBotManager_CancelBooking_Call {
+func (_c *MockBotManager_CancelBooking_Call) Run(run func(chatID int64, attemptID string)) *MockBotManager_CancelBooking_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int64
 		if args[0] != nil {
 			arg0 = args[0].(int64)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -85,25 +91,209 @@ func (_c *MockBotManager_CancelBooking_Call) Return(b bool) *MockBotManager_Canc
 	return _c
 }
 
-func (_c *MockBotManager_CancelBooking_Call) RunAndReturn(run func(chatID int64) bool) *MockBotManager_CancelBooking_Call {
+func (_c *MockBotManager_CancelBooking_Call) RunAndReturn(run func(chatID int64, attemptID string) bool) *MockBotManager_CancelBooking_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveBookings provides a mock function for the type MockBotManager
-func (_mock *MockBotManager) GetActiveBookings() map[int64]*usecase.BookingContext {
+// ClassTypes provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) ClassTypes() []string {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActiveBookings")
+		panic("no return value specified for ClassTypes")
 	}
 
-	var r0 map[int64]*usecase.BookingContext
-	if returnFunc, ok := ret.Get(0).(func() map[int64]*usecase.BookingContext); ok {
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
 		r0 = returnFunc()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[int64]*usecase.BookingContext)
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockBotManager_ClassTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClassTypes'
+type MockBotManager_ClassTypes_Call struct {
+	*mock.Call
+}
+
+// ClassTypes is a helper method to define mock.On call
+func (_e *MockBotManager_Expecter) ClassTypes() *MockBotManager_ClassTypes_Call {
+	return &MockBotManager_ClassTypes_Call{Call: _e.mock.On("ClassTypes")}
+}
+
+func (_c *MockBotManager_ClassTypes_Call) Run(run func()) *MockBotManager_ClassTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBotManager_ClassTypes_Call) Return(strings []string) *MockBotManager_ClassTypes_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *MockBotManager_ClassTypes_Call) RunAndReturn(run func() []string) *MockBotManager_ClassTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSchedule provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) DeleteSchedule(ctx context.Context, chatID int64, scheduleID string) error {
+	ret := _mock.Called(ctx, chatID, scheduleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSchedule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBotManager_DeleteSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSchedule'
+type MockBotManager_DeleteSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+func (_e *MockBotManager_Expecter) DeleteSchedule(ctx interface{}, chatID interface{}, scheduleID interface{}) *MockBotManager_DeleteSchedule_Call {
+	return &MockBotManager_DeleteSchedule_Call{Call: _e.mock.On("DeleteSchedule", ctx, chatID, scheduleID)}
+}
+
+func (_c *MockBotManager_DeleteSchedule_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string)) *MockBotManager_DeleteSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_DeleteSchedule_Call) Return(err error) *MockBotManager_DeleteSchedule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBotManager_DeleteSchedule_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string) error) *MockBotManager_DeleteSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditScheduleDates provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) EditScheduleDates(ctx context.Context, chatID int64, scheduleID string, startDate string, endDate string) error {
+	ret := _mock.Called(ctx, chatID, scheduleID, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditScheduleDates")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string, string) error); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID, startDate, endDate)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBotManager_EditScheduleDates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditScheduleDates'
+type MockBotManager_EditScheduleDates_Call struct {
+	*mock.Call
+}
+
+// EditScheduleDates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+//   - startDate string
+//   - endDate string
+func (_e *MockBotManager_Expecter) EditScheduleDates(ctx interface{}, chatID interface{}, scheduleID interface{}, startDate interface{}, endDate interface{}) *MockBotManager_EditScheduleDates_Call {
+	return &MockBotManager_EditScheduleDates_Call{Call: _e.mock.On("EditScheduleDates", ctx, chatID, scheduleID, startDate, endDate)}
+}
+
+func (_c *MockBotManager_EditScheduleDates_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string, startDate string, endDate string)) *MockBotManager_EditScheduleDates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_EditScheduleDates_Call) Return(err error) *MockBotManager_EditScheduleDates_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBotManager_EditScheduleDates_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string, startDate string, endDate string) error) *MockBotManager_EditScheduleDates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveBookings provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) GetActiveBookings(chatID int64) []usecase.BookingContext {
+	ret := _mock.Called(chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveBookings")
+	}
+
+	var r0 []usecase.BookingContext
+	if returnFunc, ok := ret.Get(0).(func(int64) []usecase.BookingContext); ok {
+		r0 = returnFunc(chatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]usecase.BookingContext)
 		}
 	}
 	return r0
@@ -115,23 +305,96 @@ type MockBotManager_GetActiveBookings_Call struct {
 }
 
 // GetActiveBookings is a helper method to define mock.On call
-func (_e *MockBotManager_Expecter) GetActiveBookings() *MockBotManager_GetActiveBookings_Call {
-	return &MockBotManager_GetActiveBookings_Call{Call: _e.mock.On("GetActiveBookings")}
+//   - chatID int64
+func (_e *MockBotManager_Expecter) GetActiveBookings(chatID interface{}) *MockBotManager_GetActiveBookings_Call {
+	return &MockBotManager_GetActiveBookings_Call{Call: _e.mock.On("GetActiveBookings", chatID)}
 }
 
-func (_c *MockBotManager_GetActiveBookings_Call) Run(run func()) *MockBotManager_GetActiveBookings_Call {
+func (_c *MockBotManager_GetActiveBookings_Call) Run(run func(chatID int64)) *MockBotManager_GetActiveBookings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_GetActiveBookings_Call) Return(bookingContexts []usecase.BookingContext) *MockBotManager_GetActiveBookings_Call {
+	_c.Call.Return(bookingContexts)
+	return _c
+}
+
+func (_c *MockBotManager_GetActiveBookings_Call) RunAndReturn(run func(chatID int64) []usecase.BookingContext) *MockBotManager_GetActiveBookings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookingLimits provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) GetBookingLimits(ctx context.Context, chatID int64) (models.BookingLimits, error) {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookingLimits")
+	}
+
+	var r0 models.BookingLimits
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (models.BookingLimits, error)); ok {
+		return returnFunc(ctx, chatID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) models.BookingLimits); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(models.BookingLimits)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, chatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBotManager_GetBookingLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookingLimits'
+type MockBotManager_GetBookingLimits_Call struct {
+	*mock.Call
+}
+
+// GetBookingLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockBotManager_Expecter) GetBookingLimits(ctx interface{}, chatID interface{}) *MockBotManager_GetBookingLimits_Call {
+	return &MockBotManager_GetBookingLimits_Call{Call: _e.mock.On("GetBookingLimits", ctx, chatID)}
+}
+
+func (_c *MockBotManager_GetBookingLimits_Call) Run(run func(ctx context.Context, chatID int64)) *MockBotManager_GetBookingLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
 
-func (_c *MockBotManager_GetActiveBookings_Call) Return(int64ToBookingContext map[int64]*usecase.BookingContext) *MockBotManager_GetActiveBookings_Call {
-	_c.Call.Return(int64ToBookingContext)
+func (_c *MockBotManager_GetBookingLimits_Call) Return(bookingLimits models.BookingLimits, err error) *MockBotManager_GetBookingLimits_Call {
+	_c.Call.Return(bookingLimits, err)
 	return _c
 }
 
-func (_c *MockBotManager_GetActiveBookings_Call) RunAndReturn(run func() map[int64]*usecase.BookingContext) *MockBotManager_GetActiveBookings_Call {
+func (_c *MockBotManager_GetBookingLimits_Call) RunAndReturn(run func(ctx context.Context, chatID int64) (models.BookingLimits, error)) *MockBotManager_GetBookingLimits_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -180,6 +443,86 @@ func (_c *MockBotManager_GetScheduleInfo_Call) RunAndReturn(run func() string) *
 	return _c
 }
 
+// GetTimetable provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) GetTimetable(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error) {
+	ret := _mock.Called(ctx, chatID, day, nextWeek)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimetable")
+	}
+
+	var r0 []models.ClassSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, bool) ([]models.ClassSchedule, error)); ok {
+		return returnFunc(ctx, chatID, day, nextWeek)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, bool) []models.ClassSchedule); ok {
+		r0 = returnFunc(ctx, chatID, day, nextWeek)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ClassSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, models.Day, bool) error); ok {
+		r1 = returnFunc(ctx, chatID, day, nextWeek)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBotManager_GetTimetable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTimetable'
+type MockBotManager_GetTimetable_Call struct {
+	*mock.Call
+}
+
+// GetTimetable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - day models.Day
+//   - nextWeek bool
+func (_e *MockBotManager_Expecter) GetTimetable(ctx interface{}, chatID interface{}, day interface{}, nextWeek interface{}) *MockBotManager_GetTimetable_Call {
+	return &MockBotManager_GetTimetable_Call{Call: _e.mock.On("GetTimetable", ctx, chatID, day, nextWeek)}
+}
+
+func (_c *MockBotManager_GetTimetable_Call) Run(run func(ctx context.Context, chatID int64, day models.Day, nextWeek bool)) *MockBotManager_GetTimetable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 models.Day
+		if args[2] != nil {
+			arg2 = args[2].(models.Day)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_GetTimetable_Call) Return(classSchedules []models.ClassSchedule, err error) *MockBotManager_GetTimetable_Call {
+	_c.Call.Return(classSchedules, err)
+	return _c
+}
+
+func (_c *MockBotManager_GetTimetable_Call) RunAndReturn(run func(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error)) *MockBotManager_GetTimetable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUser provides a mock function for the type MockBotManager
 func (_mock *MockBotManager) GetUser(ctx context.Context, chatID int64) (models.User, bool) {
 	ret := _mock.Called(ctx, chatID)
@@ -372,17 +715,323 @@ func (_c *MockBotManager_LogInAndSave_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
-// ScheduleBookClass provides a mock function for the type MockBotManager
-func (_mock *MockBotManager) ScheduleBookClass(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
-	ret := _mock.Called(ctx, chatID, class)
+// PauseSchedules provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) PauseSchedules(ctx context.Context, chatID int64, scheduleID string, until string) (int, error) {
+	ret := _mock.Called(ctx, chatID, scheduleID, until)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ScheduleBookClass")
+		panic("no return value specified for PauseSchedules")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.ClassBookingSchedule) error); ok {
-		r0 = returnFunc(ctx, chatID, class)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) (int, error)); ok {
+		return returnFunc(ctx, chatID, scheduleID, until)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) int); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID, until)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string, string) error); ok {
+		r1 = returnFunc(ctx, chatID, scheduleID, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBotManager_PauseSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseSchedules'
+type MockBotManager_PauseSchedules_Call struct {
+	*mock.Call
+}
+
+// PauseSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+//   - until string
+func (_e *MockBotManager_Expecter) PauseSchedules(ctx interface{}, chatID interface{}, scheduleID interface{}, until interface{}) *MockBotManager_PauseSchedules_Call {
+	return &MockBotManager_PauseSchedules_Call{Call: _e.mock.On("PauseSchedules", ctx, chatID, scheduleID, until)}
+}
+
+func (_c *MockBotManager_PauseSchedules_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string, until string)) *MockBotManager_PauseSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_PauseSchedules_Call) Return(n int, err error) *MockBotManager_PauseSchedules_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockBotManager_PauseSchedules_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string, until string) (int, error)) *MockBotManager_PauseSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveClass provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) RemoveClass(ctx context.Context, chatID int64, day models.Day, hour string, classType string) (bool, error) {
+	ret := _mock.Called(ctx, chatID, day, hour, classType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveClass")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, string, string) (bool, error)); ok {
+		return returnFunc(ctx, chatID, day, hour, classType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.Day, string, string) bool); ok {
+		r0 = returnFunc(ctx, chatID, day, hour, classType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, models.Day, string, string) error); ok {
+		r1 = returnFunc(ctx, chatID, day, hour, classType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBotManager_RemoveClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveClass'
+type MockBotManager_RemoveClass_Call struct {
+	*mock.Call
+}
+
+// RemoveClass is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - day models.Day
+//   - hour string
+//   - classType string
+func (_e *MockBotManager_Expecter) RemoveClass(ctx interface{}, chatID interface{}, day interface{}, hour interface{}, classType interface{}) *MockBotManager_RemoveClass_Call {
+	return &MockBotManager_RemoveClass_Call{Call: _e.mock.On("RemoveClass", ctx, chatID, day, hour, classType)}
+}
+
+func (_c *MockBotManager_RemoveClass_Call) Run(run func(ctx context.Context, chatID int64, day models.Day, hour string, classType string)) *MockBotManager_RemoveClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 models.Day
+		if args[2] != nil {
+			arg2 = args[2].(models.Day)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_RemoveClass_Call) Return(b bool, err error) *MockBotManager_RemoveClass_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockBotManager_RemoveClass_Call) RunAndReturn(run func(ctx context.Context, chatID int64, day models.Day, hour string, classType string) (bool, error)) *MockBotManager_RemoveClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResolveClassType provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) ResolveClassType(ctx context.Context, chatID int64, input string) (string, error) {
+	ret := _mock.Called(ctx, chatID, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveClassType")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (string, error)); ok {
+		return returnFunc(ctx, chatID, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) string); ok {
+		r0 = returnFunc(ctx, chatID, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, chatID, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBotManager_ResolveClassType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveClassType'
+type MockBotManager_ResolveClassType_Call struct {
+	*mock.Call
+}
+
+// ResolveClassType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - input string
+func (_e *MockBotManager_Expecter) ResolveClassType(ctx interface{}, chatID interface{}, input interface{}) *MockBotManager_ResolveClassType_Call {
+	return &MockBotManager_ResolveClassType_Call{Call: _e.mock.On("ResolveClassType", ctx, chatID, input)}
+}
+
+func (_c *MockBotManager_ResolveClassType_Call) Run(run func(ctx context.Context, chatID int64, input string)) *MockBotManager_ResolveClassType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_ResolveClassType_Call) Return(s string, err error) *MockBotManager_ResolveClassType_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockBotManager_ResolveClassType_Call) RunAndReturn(run func(ctx context.Context, chatID int64, input string) (string, error)) *MockBotManager_ResolveClassType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeSchedules provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) ResumeSchedules(ctx context.Context, chatID int64, scheduleID string) (int, error) {
+	ret := _mock.Called(ctx, chatID, scheduleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSchedules")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) (int, error)); ok {
+		return returnFunc(ctx, chatID, scheduleID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) int); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = returnFunc(ctx, chatID, scheduleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBotManager_ResumeSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeSchedules'
+type MockBotManager_ResumeSchedules_Call struct {
+	*mock.Call
+}
+
+// ResumeSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+func (_e *MockBotManager_Expecter) ResumeSchedules(ctx interface{}, chatID interface{}, scheduleID interface{}) *MockBotManager_ResumeSchedules_Call {
+	return &MockBotManager_ResumeSchedules_Call{Call: _e.mock.On("ResumeSchedules", ctx, chatID, scheduleID)}
+}
+
+func (_c *MockBotManager_ResumeSchedules_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string)) *MockBotManager_ResumeSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_ResumeSchedules_Call) Return(n int, err error) *MockBotManager_ResumeSchedules_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockBotManager_ResumeSchedules_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string) (int, error)) *MockBotManager_ResumeSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleBookClass provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) ScheduleBookClass(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
+	ret := _mock.Called(ctx, chatID, class)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleBookClass")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.ClassBookingSchedule) error); ok {
+		r0 = returnFunc(ctx, chatID, class)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -435,21 +1084,162 @@ func (_c *MockBotManager_ScheduleBookClass_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// SetBookingLimits provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) SetBookingLimits(ctx context.Context, chatID int64, limits *models.BookingLimits) error {
+	ret := _mock.Called(ctx, chatID, limits)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBookingLimits")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, *models.BookingLimits) error); ok {
+		r0 = returnFunc(ctx, chatID, limits)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBotManager_SetBookingLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBookingLimits'
+type MockBotManager_SetBookingLimits_Call struct {
+	*mock.Call
+}
+
+// SetBookingLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - limits *models.BookingLimits
+func (_e *MockBotManager_Expecter) SetBookingLimits(ctx interface{}, chatID interface{}, limits interface{}) *MockBotManager_SetBookingLimits_Call {
+	return &MockBotManager_SetBookingLimits_Call{Call: _e.mock.On("SetBookingLimits", ctx, chatID, limits)}
+}
+
+func (_c *MockBotManager_SetBookingLimits_Call) Run(run func(ctx context.Context, chatID int64, limits *models.BookingLimits)) *MockBotManager_SetBookingLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 *models.BookingLimits
+		if args[2] != nil {
+			arg2 = args[2].(*models.BookingLimits)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_SetBookingLimits_Call) Return(err error) *MockBotManager_SetBookingLimits_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBotManager_SetBookingLimits_Call) RunAndReturn(run func(ctx context.Context, chatID int64, limits *models.BookingLimits) error) *MockBotManager_SetBookingLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SkipScheduledClass provides a mock function for the type MockBotManager
+func (_mock *MockBotManager) SkipScheduledClass(ctx context.Context, chatID int64, scheduleID string, classDate string) error {
+	ret := _mock.Called(ctx, chatID, scheduleID, classDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SkipScheduledClass")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID, classDate)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBotManager_SkipScheduledClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SkipScheduledClass'
+type MockBotManager_SkipScheduledClass_Call struct {
+	*mock.Call
+}
+
+// SkipScheduledClass is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - scheduleID string
+//   - classDate string
+func (_e *MockBotManager_Expecter) SkipScheduledClass(ctx interface{}, chatID interface{}, scheduleID interface{}, classDate interface{}) *MockBotManager_SkipScheduledClass_Call {
+	return &MockBotManager_SkipScheduledClass_Call{Call: _e.mock.On("SkipScheduledClass", ctx, chatID, scheduleID, classDate)}
+}
+
+func (_c *MockBotManager_SkipScheduledClass_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string, classDate string)) *MockBotManager_SkipScheduledClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBotManager_SkipScheduledClass_Call) Return(err error) *MockBotManager_SkipScheduledClass_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBotManager_SkipScheduledClass_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string, classDate string) error) *MockBotManager_SkipScheduledClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TestUserSession provides a mock function for the type MockBotManager
-func (_mock *MockBotManager) TestUserSession(ctx context.Context, chatID int64) error {
+func (_mock *MockBotManager) TestUserSession(ctx context.Context, chatID int64) (usecase.SessionStatus, error) {
 	ret := _mock.Called(ctx, chatID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TestUserSession")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+	var r0 usecase.SessionStatus
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (usecase.SessionStatus, error)); ok {
+		return returnFunc(ctx, chatID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) usecase.SessionStatus); ok {
 		r0 = returnFunc(ctx, chatID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(usecase.SessionStatus)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, chatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockBotManager_TestUserSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TestUserSession'
@@ -482,12 +1272,12 @@ func (_c *MockBotManager_TestUserSession_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockBotManager_TestUserSession_Call) Return(err error) *MockBotManager_TestUserSession_Call {
-	_c.Call.Return(err)
+func (_c *MockBotManager_TestUserSession_Call) Return(sessionStatus usecase.SessionStatus, err error) *MockBotManager_TestUserSession_Call {
+	_c.Call.Return(sessionStatus, err)
 	return _c
 }
 
-func (_c *MockBotManager_TestUserSession_Call) RunAndReturn(run func(ctx context.Context, chatID int64) error) *MockBotManager_TestUserSession_Call {
+func (_c *MockBotManager_TestUserSession_Call) RunAndReturn(run func(ctx context.Context, chatID int64) (usecase.SessionStatus, error)) *MockBotManager_TestUserSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
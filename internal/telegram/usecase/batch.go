@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+)
+
+// batchEntry is one of the attempts a user's batch books, with what its
+// booking went through
+type batchEntry struct {
+	attempt models.BookingAttempt
+	ctx     context.Context // Cancelled when the user cancels the attempt
+	booking models.BookingWindow
+	class   models.ClassRequest
+	opensAt time.Time
+	run     bookingRun
+	err     error
+	done    bool
+}
+
+// finish marks the entry as booked, or failed with err
+func (e *batchEntry) finish(err error) {
+	e.err = err
+	e.done = true
+}
+
+// processUserBatch books the attempts of a single user that are due in the
+// same run, in priority order, within one logged-in session. Each attempt is
+// tracked, saved and notified as processUserBooking does, and returned in its
+// final state.
+func (bs *BookingScheduler) processUserBatch(ctx context.Context, attempts []models.BookingAttempt) []models.BookingAttempt {
+	batchCtx, cancel := context.WithTimeout(ctx, 15*time.Minute)
+	defer cancel()
+
+	chatID := attempts[0].ChatID
+	user, exists := bs.storage.GetUser(ctx, chatID)
+	if !exists {
+		err := fmt.Errorf("user %d not found", chatID)
+		results := make([]models.BookingAttempt, 0, len(attempts))
+		for _, attempt := range attempts {
+			results = append(results, bs.finishAttempt(ctx, attempt, bookingRun{}, err))
+		}
+		return results
+	}
+
+	// Add random delay to avoid synchronized requests (800-1200ms)
+	delay := time.Duration(800+chatID%400) * time.Millisecond
+	bs.logger.Info("Starting batch booking with delay",
+		"chat_id", chatID,
+		"delay_ms", delay.Milliseconds(),
+		"classes", len(attempts))
+	time.Sleep(delay)
+
+	var results []models.BookingAttempt
+	var entries []*batchEntry
+	for _, attempt := range prioritize(user, attempts) {
+		classStart, err := bs.classStart(attempt)
+		if err != nil {
+			bs.logger.Error("Invalid booking attempt", "booking_id", attempt.ID, "error", err)
+			results = append(results, bs.finishAttempt(ctx, attempt, bookingRun{}, err))
+			continue
+		}
+
+		attemptCtx, cancelAttempt := context.WithCancel(batchCtx)
+		defer cancelAttempt()
+
+		bookingContext := &BookingContext{
+			AttemptID: attempt.ID,
+			ChatID:    chatID,
+			BookingData: models.BookingWindow{
+				Day:          attempt.Day,
+				Hour:         attempt.Hour,
+				ClassType:    attempt.ClassType,
+				Alternatives: attempt.Alternatives,
+				ClassStart:   classStart,
+				OpensAt:      attempt.AttemptTime,
+			},
+			Cancel: cancelAttempt,
+			Status: "active",
+		}
+		bs.trackActiveBooking(bookingContext)
+		defer bs.untrackActiveBooking(bookingContext)
+
+		entries = append(entries, &batchEntry{
+			attempt: attempt,
+			ctx:     attemptCtx,
+			booking: bookingContext.BookingData,
+			class: models.ClassRequest{
+				Date:         classStart,
+				ClassType:    attempt.ClassType,
+				Hour:         attempt.Hour,
+				Alternatives: attempt.Alternatives,
+			},
+			opensAt: attempt.AttemptTime,
+		})
+	}
+
+	if len(entries) > 0 {
+		bs.performBatchForUser(batchCtx, user, entries)
+	}
+
+	for _, entry := range entries {
+		err := entry.err
+		if err != nil && errors.Is(entry.ctx.Err(), context.Canceled) {
+			err = context.Canceled
+		}
+		results = append(results, bs.finishAttempt(ctx, entry.attempt, entry.run, err))
+	}
+	return results
+}
+
+// performBatchForUser logs the user in once and opens the day of the first
+// entry before the earliest window of the entries opens by the site's clock,
+// as bookOnce does, then books the entries in order until each of them is
+// booked or gives up. A class not bookable yet is tried
+// again until the burst window runs out, retryable errors until maxRetries or
+// the retry window runs out.
+func (bs *BookingScheduler) performBatchForUser(ctx context.Context, user models.User, entries []*batchEntry) {
+	failAll := func(err error) {
+		for _, entry := range entries {
+			if !entry.done {
+				entry.finish(err)
+			}
+		}
+	}
+
+	client, err := bs.clientPool.Acquire(ctx)
+	if err != nil {
+		failAll(fmt.Errorf("failed to acquire browser context: %w", err))
+		return
+	}
+	defer bs.clientPool.Release(client)
+
+	password, err := bs.authenticate(ctx, client, user)
+	if err != nil {
+		failAll(err)
+		return
+	}
+	if err := bs.prepareBatch(ctx, client, user, password, entries[0].class.Date); err != nil {
+		failAll(err)
+		return
+	}
+
+	clockSkew := bs.measureClockSkew(ctx, client)
+	earliest := slices.MinFunc(entries, func(a, b *batchEntry) int { return a.opensAt.Compare(b.opensAt) }).opensAt
+	opensAt := earliest.Add(-clockSkew)
+	if err := bs.waitForBookingWindow(ctx, opensAt); err != nil {
+		failAll(fmt.Errorf("failed while waiting for booking window: %w", err))
+		return
+	}
+
+	// A late run still gets a full burst and retry window
+	burstDeadline := time.Now().Add(bs.burstWindow)
+	retryDeadline := earliest
+	if now := time.Now(); now.After(retryDeadline) {
+		retryDeadline = now
+	}
+	retryDeadline = retryDeadline.Add(bs.retryWindow)
+	sessionRetries := 0
+
+	for {
+		var pending []*batchEntry
+		for _, entry := range entries {
+			entry.run.clockSkew = clockSkew
+			switch {
+			case entry.done:
+			case entry.ctx.Err() != nil:
+				entry.finish(entry.ctx.Err())
+			default:
+				pending = append(pending, entry)
+			}
+		}
+		if len(pending) == 0 {
+			return
+		}
+
+		classes := make([]models.ClassRequest, len(pending))
+		for i, entry := range pending {
+			classes[i] = entry.class
+			entry.run.tries++
+		}
+
+		results, err := client.BookClasses(ctx, classes)
+		if err != nil {
+			kind := bs.classify(err)
+			if !kind.Retryable() || sessionRetries >= bs.maxRetries || time.Now().After(retryDeadline) {
+				failAll(err)
+				return
+			}
+			sessionRetries++
+			bs.logger.Warn("Batch booking failed, retrying",
+				"chat_id", user.ChatID,
+				"retry", sessionRetries,
+				"error_kind", kind.String(),
+				"error", err)
+			if err := bs.reopenBatch(ctx, client, user, err, entries[0].class.Date); err != nil {
+				failAll(err)
+				return
+			}
+		} else if sessionErr := bs.recordBatchResults(ctx, client, user, pending, results, burstDeadline, retryDeadline, opensAt); sessionErr != nil {
+			if err := bs.reopenBatch(ctx, client, user, sessionErr, entries[0].class.Date); err != nil {
+				failAll(err)
+				return
+			}
+		}
+
+		if !slices.ContainsFunc(entries, func(entry *batchEntry) bool { return !entry.done }) {
+			return
+		}
+
+		select {
+		case <-time.After(bs.burstInterval):
+		case <-ctx.Done():
+			failAll(ctx.Err())
+			return
+		}
+	}
+}
+
+// prepareBatch logs the client in, with the same password semantics as
+// PrepareBooking, and opens the day of the class date, retrying retryable
+// errors as performBookingForUser does
+func (bs *BookingScheduler) prepareBatch(ctx context.Context, client APIClient, user models.User, password string, classDate time.Time) error {
+	for retries := 0; ; retries++ {
+		err := client.PrepareBooking(ctx, user.Email, password, classDate)
+		if err == nil {
+			return nil
+		}
+
+		kind := bs.classify(err)
+		if !kind.Retryable() || retries >= bs.maxRetries {
+			return err
+		}
+		bs.logger.Warn("Failed to prepare batch booking, retrying",
+			"chat_id", user.ChatID,
+			"retry", retries+1,
+			"error_kind", kind.String(),
+			"error", err)
+		if kind == BookingErrorSessionExpired {
+			if password, err = bs.passwordLogin(ctx, user, err); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-time.After(retryBackoff(retries + 1)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// reopenBatch prepares the batch again after a call to BookClasses failed with
+// err, logging in again when the site ended the session
+func (bs *BookingScheduler) reopenBatch(ctx context.Context, client APIClient, user models.User, err error, classDate time.Time) error {
+	// The client stays logged in after preparing the batch
+	password := ""
+	if bs.classify(err) == BookingErrorSessionExpired {
+		if password, err = bs.passwordLogin(ctx, user, err); err != nil {
+			return err
+		}
+	}
+	return bs.prepareBatch(ctx, client, user, password, classDate)
+}
+
+// recordBatchResults updates the pending entries with the results of a call
+// to BookClasses, joining the waiting list of the full classes, and returns
+// the error of a class that found the session expired, to log in again
+func (bs *BookingScheduler) recordBatchResults(ctx context.Context, client APIClient, user models.User, pending []*batchEntry, results []models.ClassResult, burstDeadline, retryDeadline, opensAt time.Time) error {
+	var sessionErr error
+	for i, entry := range pending {
+		if i >= len(results) {
+			break
+		}
+		result := results[i]
+
+		switch {
+		case result.Booked:
+			entry.run.latency = time.Since(opensAt)
+			entry.run.alternative = result.BookedAlternative
+			entry.finish(nil)
+		case result.Err != nil:
+			kind := bs.classify(result.Err)
+			if kind == BookingErrorClassFull && bs.waitlistInterval > 0 {
+				// BookClasses already tried the alternatives
+				entry.run.waitlist = bs.joinBatchWaitlist(ctx, client, user, entry.booking)
+				entry.finish(result.Err)
+				continue
+			}
+			if !kind.Retryable() || entry.run.retries >= bs.maxRetries || time.Now().After(retryDeadline) {
+				entry.finish(result.Err)
+				continue
+			}
+			entry.run.retries++
+			if err := bs.storage.UpdateBookingRetry(ctx, entry.attempt.ID, entry.run.retries, result.Err.Error()); err != nil {
+				bs.logger.Error("Failed to record booking retry", "booking_id", entry.attempt.ID, "error", err)
+			}
+			if kind == BookingErrorSessionExpired {
+				sessionErr = result.Err
+			}
+		case time.Now().After(burstDeadline):
+			entry.finish(fmt.Errorf("%w: %d tries", ErrBurstWindowExpired, entry.run.tries))
+		}
+	}
+	return sessionErr
+}
+
+// joinBatchWaitlist opens the day of the full class, which BookClasses leaves
+// on the last class it booked, and joins its waiting list as joinWaitlist does
+func (bs *BookingScheduler) joinBatchWaitlist(ctx context.Context, client APIClient, user models.User, booking models.BookingWindow) string {
+	// prepareBatch left the client logged in
+	if err := client.PrepareBooking(ctx, user.Email, "", booking.ClassStart); err != nil {
+		bs.logger.Warn("Failed to open the day of the full class, monitoring it instead", "chat_id", user.ChatID, "error", err)
+		return "monitoring"
+	}
+	return bs.joinWaitlist(ctx, client, user.ChatID, booking)
+}
+
+// finishAttempt saves the attempt in the state its booking ended in and
+// notifies the user of the outcome
+func (bs *BookingScheduler) finishAttempt(ctx context.Context, attempt models.BookingAttempt, run bookingRun, err error) models.BookingAttempt {
+	switch {
+	case err == nil:
+		attempt.Status = "success"
+		attempt.ErrorMsg = ""
+		bs.logger.Info("Booking successful",
+			"chat_id", attempt.ChatID,
+			"alternative", run.alternative != nil,
+			"booking_id", attempt.ID,
+			"latency_ms", run.latency.Milliseconds(),
+			"tries", run.tries,
+			"retries", run.retries)
+	case errors.Is(err, context.Canceled):
+		attempt.Status = "cancelled"
+		attempt.ErrorMsg = "booking cancelled"
+		bs.logger.Info("Booking cancelled", "chat_id", attempt.ChatID, "booking_id", attempt.ID)
+	case run.waitlist != "":
+		// The waitlist job keeps checking the full class
+		attempt.Status = run.waitlist
+		attempt.ErrorMsg = err.Error()
+		bs.logger.Info("Class is full, waiting for a spot", "chat_id", attempt.ChatID, "status", run.waitlist)
+	default:
+		attempt.Status = "failed"
+		attempt.ErrorMsg = err.Error()
+		bs.logger.Error("Booking failed", "chat_id", attempt.ChatID, "booking_id", attempt.ID, "error", err)
+	}
+	attempt.BookedAlternative = run.alternative
+	attempt.ClockSkewMs = run.clockSkew.Milliseconds()
+	attempt.LatencyMs = run.latency.Milliseconds()
+	attempt.BookingTries = run.tries
+	attempt.RetryCount = run.retries
+
+	if saveErr := bs.storage.SaveBookingAttempt(ctx, attempt); saveErr != nil {
+		bs.logger.Error("Failed to update final booking status", "booking_id", attempt.ID, "error", saveErr)
+	}
+
+	// The user asked for it, there is nothing to tell them
+	if attempt.Status != "cancelled" {
+		bs.notify(ctx, attempt.ChatID, bs.bookingResultMessage(attempt, err))
+	}
+	return attempt
+}
+
+// prioritize orders the user's attempts by the order of the rules that made
+// them, so the rules added first are booked first, then by class date and hour
+func prioritize(user models.User, attempts []models.BookingAttempt) []models.BookingAttempt {
+	rank := make(map[string]int, len(user.ClassBookingSchedules))
+	for i, schedule := range user.ClassBookingSchedules {
+		rank[schedule.ID] = i
+	}
+	ruleRank := func(attempt models.BookingAttempt) int {
+		if i, ok := rank[attempt.ScheduleID]; ok {
+			return i
+		}
+		return len(rank)
+	}
+
+	attempts = slices.Clone(attempts)
+	slices.SortStableFunc(attempts, func(a, b models.BookingAttempt) int {
+		return cmp.Or(
+			cmp.Compare(ruleRank(a), ruleRank(b)),
+			cmp.Compare(a.ClassDate, b.ClassDate),
+			cmp.Compare(a.Hour, b.Hour),
+		)
+	})
+	return attempts
+}
+
+// groupByUser splits the attempts by user, keeping their order
+func groupByUser(attempts []models.BookingAttempt) [][]models.BookingAttempt {
+	index := make(map[int64]int)
+	var groups [][]models.BookingAttempt
+	for _, attempt := range attempts {
+		i, ok := index[attempt.ChatID]
+		if !ok {
+			i = len(groups)
+			index[attempt.ChatID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], attempt)
+	}
+	return groups
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingScheduler_ProcessUserBatch(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{
+		ChatID: chatID,
+		Email:  "a@b.com",
+		// Wednesday's rule was added first, so it is booked first
+		ClassBookingSchedules: []models.ClassBookingSchedule{
+			{ID: "wednesday", Day: "Wednesday", Hour: "07:00", ClassType: "Wod"},
+			{ID: "monday", Day: "Monday", Hour: "07:00", ClassType: "Wod"},
+		},
+	}))
+
+	// The batch starts after a delay of up to 1.2s
+	opensAt := time.Now().Add(1500 * time.Millisecond)
+	attempt := func(scheduleID string, day models.Day, classDate string) models.BookingAttempt {
+		return models.BookingAttempt{
+			ID:          bookingAttemptID(chatID, scheduleID, classDate),
+			ChatID:      chatID,
+			ScheduleID:  scheduleID,
+			ClassDate:   classDate,
+			Day:         day,
+			Hour:        "07:00",
+			ClassType:   "Wod",
+			Status:      "pending",
+			AttemptTime: opensAt,
+		}
+	}
+	monday := attempt("monday", "Monday", "2025-08-18")
+	wednesday := attempt("wednesday", "Wednesday", "2025-08-20")
+	friday := attempt("friday", "Friday", "2025-08-22")
+	for _, a := range []models.BookingAttempt{monday, wednesday, friday} {
+		require.NoError(t, store.SaveBookingAttempt(ctx, a))
+	}
+
+	classDates := func(classes []models.ClassRequest) []string {
+		var dates []string
+		for _, class := range classes {
+			dates = append(dates, class.Date.Format(models.DateLayout))
+		}
+		return dates
+	}
+
+	client := NewMockAPIClient(t)
+	// The user is logged in on the day of the first class before the window opens
+	client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", time.Date(2025, 8, 20, 7, 0, 0, 0, time.UTC)).
+		RunAndReturn(func(context.Context, string, string, time.Time) error {
+			assert.True(t, time.Now().Before(opensAt), "logged in after the window opened")
+			return nil
+		}).Once()
+	client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+	// The first call books the classes in priority order once the window opens
+	client.EXPECT().BookClasses(mock.Anything, mock.MatchedBy(func(classes []models.ClassRequest) bool {
+		return assert.ObjectsAreEqual([]string{"2025-08-20", "2025-08-18", "2025-08-22"}, classDates(classes))
+	})).RunAndReturn(func(_ context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+		assert.False(t, time.Now().Before(opensAt), "booked before the window opened")
+		return []models.ClassResult{
+			{ClassRequest: classes[0], Booked: true},
+			{ClassRequest: classes[1]},
+			{ClassRequest: classes[2], Err: errors.New("class not found")},
+		}, nil
+	}).Once()
+	// Later calls only retry the class not open yet
+	client.EXPECT().BookClasses(mock.Anything, mock.MatchedBy(func(classes []models.ClassRequest) bool {
+		return assert.ObjectsAreEqual([]string{"2025-08-18"}, classDates(classes))
+	})).RunAndReturn(func(_ context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+		return []models.ClassResult{{ClassRequest: classes[0], Booked: true}}, nil
+	}).Once()
+
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return()
+
+	notifier := NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, chatID, mock.Anything).Return(nil).Times(3)
+
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger, WithBurst(time.Second, 10*time.Millisecond))
+	scheduler.SetNotifier(notifier)
+
+	results := scheduler.processUserBatch(ctx, []models.BookingAttempt{monday, wednesday, friday})
+	require.Len(t, results, 3)
+	assert.Empty(t, scheduler.GetActiveBookings(chatID))
+
+	want := map[string]struct {
+		status string
+		tries  int
+	}{
+		wednesday.ID: {"success", 1},
+		monday.ID:    {"success", 2},
+		friday.ID:    {"failed", 1},
+	}
+	for id, w := range want {
+		saved, exists := store.GetBookingAttempt(ctx, id)
+		require.True(t, exists)
+		assert.Equal(t, w.status, saved.Status, id)
+		assert.Equal(t, w.tries, saved.BookingTries, id)
+	}
+}
+
+func TestBookingScheduler_ProcessUserBatchFullClass(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	errFull := errors.New("class is full")
+	classify := func(err error) BookingErrorKind {
+		if errors.Is(err, errFull) {
+			return BookingErrorClassFull
+		}
+		return ClassifyBookingError(err)
+	}
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+	monday := models.BookingAttempt{ID: "monday", ChatID: chatID, ClassDate: "2025-08-18", Day: "Monday", Hour: "07:00",
+		ClassType: "Wod", Status: "pending", AttemptTime: time.Now()}
+	tuesday := models.BookingAttempt{ID: "tuesday", ChatID: chatID, ClassDate: "2025-08-19", Day: "Tuesday", Hour: "07:00",
+		ClassType: "Wod", Status: "pending", AttemptTime: time.Now()}
+	for _, a := range []models.BookingAttempt{monday, tuesday} {
+		require.NoError(t, store.SaveBookingAttempt(ctx, a))
+	}
+
+	client := NewMockAPIClient(t)
+	client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)).Return(nil).Once()
+	client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+	client.EXPECT().BookClasses(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+			return []models.ClassResult{
+				{ClassRequest: classes[0], Err: errFull},
+				{ClassRequest: classes[1], Booked: true},
+			}, nil
+		}).Once()
+	// The full Monday class is opened again within the session to join its waiting list
+	client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "", time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)).Return(nil).Once()
+	client.EXPECT().JoinWaitlist(mock.Anything, "Wod", "07:00").Return(true, nil).Once()
+
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return()
+
+	notifier := NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, chatID, mock.Anything).Return(nil).Times(2)
+
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger,
+		WithBurst(time.Second, 10*time.Millisecond),
+		WithErrorClassifier(classify),
+		WithWaitlist(time.Minute, time.Hour))
+	scheduler.SetNotifier(notifier)
+
+	results := scheduler.processUserBatch(ctx, []models.BookingAttempt{monday, tuesday})
+	require.Len(t, results, 2)
+
+	saved, _ := store.GetBookingAttempt(ctx, monday.ID)
+	assert.Equal(t, "waitlisted", saved.Status)
+	saved, _ = store.GetBookingAttempt(ctx, tuesday.ID)
+	assert.Equal(t, "success", saved.Status)
+}
+
+func TestBookingScheduler_ProcessUserBatchAlternatives(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	alternatives := []models.ClassAlternative{{Hour: "08:00", ClassType: "Wod"}, {Hour: "09:00", ClassType: "Open box"}}
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+	monday := models.BookingAttempt{ID: "monday", ChatID: chatID, ClassDate: "2025-08-18", Day: "Monday", Hour: "07:00",
+		ClassType: "Wod", Alternatives: alternatives, Status: "pending", AttemptTime: time.Now()}
+	tuesday := models.BookingAttempt{ID: "tuesday", ChatID: chatID, ClassDate: "2025-08-19", Day: "Tuesday", Hour: "07:00",
+		ClassType: "Wod", Status: "pending", AttemptTime: time.Now()}
+	for _, a := range []models.BookingAttempt{monday, tuesday} {
+		require.NoError(t, store.SaveBookingAttempt(ctx, a))
+	}
+
+	var scheduler *BookingScheduler
+	client := NewMockAPIClient(t)
+	client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)).Return(nil).Once()
+	client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+	client.EXPECT().BookClasses(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+			// The alternatives are booked along with the class and shown by /active
+			assert.Equal(t, alternatives, classes[0].Alternatives)
+			for _, active := range scheduler.GetActiveBookings(chatID) {
+				if active.AttemptID == monday.ID {
+					assert.Equal(t, alternatives, active.BookingData.Alternatives)
+				}
+			}
+			return []models.ClassResult{
+				{ClassRequest: classes[0], Booked: true, BookedAlternative: &alternatives[1]},
+				{ClassRequest: classes[1], Booked: true},
+			}, nil
+		}).Once()
+
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return()
+
+	notifier := NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, chatID, mock.Anything).Return(nil).Times(2)
+
+	scheduler = NewBookingScheduler(store, pool, creds, newTestWindow(t), logger, WithBurst(time.Second, 10*time.Millisecond))
+	scheduler.SetNotifier(notifier)
+
+	results := scheduler.processUserBatch(ctx, []models.BookingAttempt{monday, tuesday})
+	require.Len(t, results, 2)
+
+	saved, _ := store.GetBookingAttempt(ctx, monday.ID)
+	assert.Equal(t, "success", saved.Status)
+	assert.Equal(t, &alternatives[1], saved.BookedAlternative)
+}
+
+func TestBookingScheduler_ProcessUserBatchSessionExpired(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+	monday := models.BookingAttempt{ID: "monday", ChatID: chatID, ClassDate: "2025-08-18", Day: "Monday", Hour: "07:00",
+		ClassType: "Wod", Status: "pending", AttemptTime: time.Now()}
+	tuesday := models.BookingAttempt{ID: "tuesday", ChatID: chatID, ClassDate: "2025-08-19", Day: "Tuesday", Hour: "07:00",
+		ClassType: "Wod", Status: "pending", AttemptTime: time.Now()}
+	for _, a := range []models.BookingAttempt{monday, tuesday} {
+		require.NoError(t, store.SaveBookingAttempt(ctx, a))
+	}
+
+	client := NewMockAPIClient(t)
+	// The restored session is used first, then the password once it ended
+	client.EXPECT().LoadStoredSession(mock.Anything, mock.Anything).Return(nil).Once()
+	client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "", time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)).Return(nil).Once()
+	client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+	client.EXPECT().BookClasses(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+			return []models.ClassResult{
+				{ClassRequest: classes[0], Booked: true},
+				{ClassRequest: classes[1], Err: errTestSessionExpired},
+			}, nil
+		}).Once()
+	client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)).Return(nil).Once()
+	client.EXPECT().BookClasses(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+			return []models.ClassResult{{ClassRequest: classes[0], Booked: true}}, nil
+		}).Once()
+
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{
+		Email:          "a@b.com",
+		Password:       "secret",
+		SessionCookies: []*http.Cookie{{Name: ".WBAuth", Value: "old"}},
+	}, nil)
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return()
+
+	notifier := NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, chatID, mock.Anything).Return(nil).Times(2)
+
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger,
+		WithBurst(time.Second, 10*time.Millisecond),
+		WithErrorClassifier(classifyTestError))
+	scheduler.SetNotifier(notifier)
+
+	results := scheduler.processUserBatch(ctx, []models.BookingAttempt{monday, tuesday})
+	require.Len(t, results, 2)
+
+	for _, id := range []string{monday.ID, tuesday.ID} {
+		saved, _ := store.GetBookingAttempt(ctx, id)
+		assert.Equal(t, "success", saved.Status, id)
+	}
+}
+
+func TestGroupByUser(t *testing.T) {
+	attempts := []models.BookingAttempt{{ID: "a", ChatID: 1}, {ID: "b", ChatID: 2}, {ID: "c", ChatID: 1}}
+
+	groups := groupByUser(attempts)
+	require.Len(t, groups, 2)
+	assert.Equal(t, []models.BookingAttempt{attempts[0], attempts[2]}, groups[0])
+	assert.Equal(t, []models.BookingAttempt{attempts[1]}, groups[1])
+}
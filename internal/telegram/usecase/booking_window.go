@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+)
+
+var ErrInvalidBookingWindow = errors.New("invalid booking window")
+
+// Booking window modes
+const (
+	// BookingWindowWeekly opens the whole next week (Monday to Sunday) at a
+	// fixed weekday and time, e.g. every Saturday at 12:00
+	BookingWindowWeekly = "weekly"
+	// BookingWindowRolling opens each class a fixed time before it starts,
+	// e.g. 48 hours before
+	BookingWindowRolling = "rolling"
+)
+
+// dueSlack absorbs the delay between a scheduled run and the moment it
+// actually checks which attempts are due
+const dueSlack = time.Minute
+
+// BookingWindowConfig describes when a box opens its class bookings
+type BookingWindowConfig struct {
+	Mode        string        // BookingWindowWeekly or BookingWindowRolling
+	Timezone    string        // IANA zone of the box, e.g. "Europe/Madrid"
+	OpenWeekday string        // Weekly mode: day name, e.g. "Saturday"
+	OpenTime    string        // Weekly mode: HH:MM
+	OpensBefore time.Duration // Rolling mode: how long before a class it opens
+	RunLead     time.Duration // How early a run starts before the window opens
+}
+
+// BookingWindowPolicy computes when the classes of a booking rule open, in the
+// box's timezone. The scheduler uses it to create attempts and to decide when
+// to run them.
+type BookingWindowPolicy struct {
+	mode        string
+	location    *time.Location
+	openWeekday time.Weekday
+	openHour    int
+	openMinute  int
+	opensBefore time.Duration
+	runLead     time.Duration
+}
+
+// NewBookingWindowPolicy validates the configuration and creates the policy
+func NewBookingWindowPolicy(config BookingWindowConfig) (*BookingWindowPolicy, error) {
+	location, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q: %w", ErrInvalidBookingWindow, config.Timezone, err)
+	}
+	if config.RunLead < 0 || config.RunLead >= 24*time.Hour {
+		return nil, fmt.Errorf("%w: run lead must be between 0 and 24h", ErrInvalidBookingWindow)
+	}
+
+	policy := &BookingWindowPolicy{
+		mode:     config.Mode,
+		location: location,
+		runLead:  config.RunLead,
+	}
+
+	switch config.Mode {
+	case BookingWindowWeekly:
+		day, err := models.ParseDay(config.OpenWeekday, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown weekday %q", ErrInvalidBookingWindow, config.OpenWeekday)
+		}
+		weekday, _ := day.Weekday()
+		openTime, err := time.Parse("15:04", config.OpenTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: open time must be HH:MM", ErrInvalidBookingWindow)
+		}
+		policy.openWeekday = weekday
+		policy.openHour, policy.openMinute = openTime.Hour(), openTime.Minute()
+	case BookingWindowRolling:
+		// Only the next class of a rule is scheduled, so it must open after the previous one starts
+		if config.OpensBefore <= 0 || config.OpensBefore > 7*24*time.Hour {
+			return nil, fmt.Errorf("%w: classes must open between 0 and 7 days before they start", ErrInvalidBookingWindow)
+		}
+		policy.opensBefore = config.OpensBefore
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidBookingWindow, config.Mode)
+	}
+
+	return policy, nil
+}
+
+// Mode returns BookingWindowWeekly or BookingWindowRolling
+func (p *BookingWindowPolicy) Mode() string {
+	return p.mode
+}
+
+// Location returns the timezone of the box
+func (p *BookingWindowPolicy) Location() *time.Location {
+	return p.location
+}
+
+// NextClass returns the start of the next class of a weekly rule whose booking
+// window has not been processed yet, and when that window opens.
+//
+// In weekly mode that is the class in the week opened by the next opening
+// after now. In rolling mode it is the next class that has not started yet,
+// whose window may already be open.
+func (p *BookingWindowPolicy) NextClass(day models.Day, hour string, now time.Time) (time.Time, time.Time, error) {
+	weekday, ok := day.Weekday()
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s", ErrInvalidDay, day)
+	}
+	classTime, err := time.Parse("15:04", hour)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s", ErrInvalidHour, hour)
+	}
+
+	local := now.In(p.location)
+
+	if p.mode == BookingWindowRolling {
+		classStart := p.dateOn(local, weekday, classTime.Hour(), classTime.Minute())
+		if !classStart.After(local) {
+			classStart = classStart.AddDate(0, 0, 7)
+		}
+		return classStart, classStart.Add(-p.opensBefore), nil
+	}
+
+	opensAt := p.dateOn(local, p.openWeekday, p.openHour, p.openMinute)
+	if !opensAt.After(local) {
+		opensAt = opensAt.AddDate(0, 0, 7)
+	}
+
+	// The opening makes the following Monday to Sunday bookable
+	daysUntilMonday := (int(time.Monday) - int(opensAt.Weekday()) + 7) % 7
+	if daysUntilMonday == 0 {
+		daysUntilMonday = 7
+	}
+	offset := (int(weekday) - int(time.Monday) + 7) % 7
+	classStart := time.Date(opensAt.Year(), opensAt.Month(), opensAt.Day()+daysUntilMonday+offset,
+		classTime.Hour(), classTime.Minute(), 0, 0, p.location)
+
+	return classStart, opensAt, nil
+}
+
+// ClassStart returns when the class on the date (YYYY-MM-DD) at the hour
+// (HH:MM) starts, in the box's timezone
+func (p *BookingWindowPolicy) ClassStart(classDate, hour string) (time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02 15:04", classDate+" "+hour, p.location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid class date %q at %q: %w", classDate, hour, err)
+	}
+	return start, nil
+}
+
+// WeekDates returns the dates, Monday to Sunday in the box's timezone, of the
+// week of now or of the week after it
+func (p *BookingWindowPolicy) WeekDates(now time.Time, nextWeek bool) []time.Time {
+	local := now.In(p.location)
+	daysSinceMonday := (int(local.Weekday()) - int(time.Monday) + 7) % 7
+	if nextWeek {
+		daysSinceMonday -= 7
+	}
+
+	dates := make([]time.Time, 0, 7)
+	for i := range 7 {
+		dates = append(dates, time.Date(local.Year(), local.Month(), local.Day()-daysSinceMonday+i, 0, 0, 0, 0, p.location))
+	}
+	return dates
+}
+
+// IsDue reports whether a run starting now must process an attempt whose
+// window opens at opensAt
+func (p *BookingWindowPolicy) IsDue(opensAt, now time.Time) bool {
+	return !opensAt.After(now.Add(p.runLead + dueSlack))
+}
+
+// Today returns the current date in the box's timezone (YYYY-MM-DD)
+func (p *BookingWindowPolicy) Today(now time.Time) string {
+	return now.In(p.location).Format("2006-01-02")
+}
+
+// CronSpec returns the cron schedule of the booking runs: RunLead before each
+// weekly opening, or every minute in rolling mode where windows open at any time
+func (p *BookingWindowPolicy) CronSpec() string {
+	if p.mode == BookingWindowRolling {
+		return "@every 1m"
+	}
+	return p.weeklyCronSpec(p.runLead)
+}
+
+// KeepAliveCronSpec returns the cron schedule of the session checks: lead
+// before each weekly opening, or every lead in rolling mode where windows open
+// at any time
+func (p *BookingWindowPolicy) KeepAliveCronSpec(lead time.Duration) string {
+	if p.mode == BookingWindowRolling {
+		return "@every " + lead.String()
+	}
+	return p.weeklyCronSpec(lead)
+}
+
+// weeklyCronSpec returns the cron schedule running lead before each weekly
+// opening
+func (p *BookingWindowPolicy) weeklyCronSpec(lead time.Duration) string {
+	const minutesPerWeek = 7 * 24 * 60
+	opening := int(p.openWeekday)*24*60 + p.openHour*60 + p.openMinute
+	run := ((opening-int(lead/time.Minute))%minutesPerWeek + minutesPerWeek) % minutesPerWeek
+
+	return fmt.Sprintf("CRON_TZ=%s %d %d * * %d", p.location, run%60, run/60%24, run/(24*60))
+}
+
+// String describes the policy for users and logs
+func (p *BookingWindowPolicy) String() string {
+	if p.mode == BookingWindowRolling {
+		return fmt.Sprintf("bookings open %s before each class", formatDuration(p.opensBefore))
+	}
+	return fmt.Sprintf("bookings open every %s at %02d:%02d (%s)", p.openWeekday, p.openHour, p.openMinute, p.location)
+}
+
+// dateOn returns the time at hour:minute on the next date, today included,
+// falling on the weekday
+func (p *BookingWindowPolicy) dateOn(local time.Time, weekday time.Weekday, hour, minute int) time.Time {
+	daysAhead := (int(weekday) - int(local.Weekday()) + 7) % 7
+	return time.Date(local.Year(), local.Month(), local.Day()+daysAhead, hour, minute, 0, 0, p.location)
+}
+
+// formatDuration prints whole hours as "48h" instead of "48h0m0s"
+func formatDuration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return d.String()
+}
@@ -0,0 +1,312 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBookingWindowPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  BookingWindowConfig
+		wantErr bool
+	}{
+		{
+			name:   "weekly",
+			config: BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "Europe/Madrid", OpenWeekday: "saturday", OpenTime: "12:00"},
+		},
+		{
+			name:   "rolling",
+			config: BookingWindowConfig{Mode: BookingWindowRolling, Timezone: "Europe/Madrid", OpensBefore: 48 * time.Hour},
+		},
+		{
+			name:    "unknown mode",
+			config:  BookingWindowConfig{Mode: "daily", Timezone: "UTC"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown timezone",
+			config:  BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "Mars/Olympus", OpenWeekday: "Saturday", OpenTime: "12:00"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown weekday",
+			config:  BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "UTC", OpenWeekday: "Funday", OpenTime: "12:00"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid open time",
+			config:  BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "UTC", OpenWeekday: "Saturday", OpenTime: "noon"},
+			wantErr: true,
+		},
+		{
+			name:    "rolling window longer than a week",
+			config:  BookingWindowConfig{Mode: BookingWindowRolling, Timezone: "UTC", OpensBefore: 8 * 24 * time.Hour},
+			wantErr: true,
+		},
+		{
+			name:    "rolling window not set",
+			config:  BookingWindowConfig{Mode: BookingWindowRolling, Timezone: "UTC"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBookingWindowPolicy(tt.config)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidBookingWindow)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBookingWindowPolicy_NextClass(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	weekly, err := NewBookingWindowPolicy(BookingWindowConfig{
+		Mode:        BookingWindowWeekly,
+		Timezone:    "Europe/Madrid",
+		OpenWeekday: "Saturday",
+		OpenTime:    "12:00",
+	})
+	require.NoError(t, err)
+
+	rolling, err := NewBookingWindowPolicy(BookingWindowConfig{
+		Mode:        BookingWindowRolling,
+		Timezone:    "Europe/Madrid",
+		OpensBefore: 48 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		policy        *BookingWindowPolicy
+		now           time.Time
+		day           models.Day
+		hour          string
+		wantClass     time.Time
+		wantOpensAt   time.Time
+		wantErrTarget error
+	}{
+		{
+			name:        "weekly opens the following week",
+			policy:      weekly,
+			now:         time.Date(2025, 8, 15, 18, 0, 0, 0, madrid),
+			day:         models.DayMonday,
+			hour:        "07:00",
+			wantClass:   time.Date(2025, 8, 18, 7, 0, 0, 0, madrid),
+			wantOpensAt: time.Date(2025, 8, 16, 12, 0, 0, 0, madrid),
+		},
+		{
+			name:        "weekly sunday is the end of the opened week",
+			policy:      weekly,
+			now:         time.Date(2025, 8, 15, 18, 0, 0, 0, madrid),
+			day:         models.DaySunday,
+			hour:        "10:00",
+			wantClass:   time.Date(2025, 8, 24, 10, 0, 0, 0, madrid),
+			wantOpensAt: time.Date(2025, 8, 16, 12, 0, 0, 0, madrid),
+		},
+		{
+			// 11:30 in Madrid is already past 09:00 UTC, the opening must still be today's
+			name:        "weekly just before the opening in the box's timezone",
+			policy:      weekly,
+			now:         time.Date(2025, 8, 16, 9, 30, 0, 0, time.UTC),
+			day:         models.DayMonday,
+			hour:        "07:00",
+			wantClass:   time.Date(2025, 8, 18, 7, 0, 0, 0, madrid),
+			wantOpensAt: time.Date(2025, 8, 16, 12, 0, 0, 0, madrid),
+		},
+		{
+			name:        "weekly after the opening moves to the next one",
+			policy:      weekly,
+			now:         time.Date(2025, 8, 16, 12, 0, 0, 0, madrid),
+			day:         models.DayMonday,
+			hour:        "07:00",
+			wantClass:   time.Date(2025, 8, 25, 7, 0, 0, 0, madrid),
+			wantOpensAt: time.Date(2025, 8, 23, 12, 0, 0, 0, madrid),
+		},
+		{
+			name:        "rolling next class this week",
+			policy:      rolling,
+			now:         time.Date(2025, 8, 18, 8, 0, 0, 0, madrid),
+			day:         models.DayWednesday,
+			hour:        "19:00",
+			wantClass:   time.Date(2025, 8, 20, 19, 0, 0, 0, madrid),
+			wantOpensAt: time.Date(2025, 8, 18, 19, 0, 0, 0, madrid),
+		},
+		{
+			name:        "rolling class already started today",
+			policy:      rolling,
+			now:         time.Date(2025, 8, 18, 8, 0, 0, 0, madrid),
+			day:         models.DayMonday,
+			hour:        "07:00",
+			wantClass:   time.Date(2025, 8, 25, 7, 0, 0, 0, madrid),
+			wantOpensAt: time.Date(2025, 8, 23, 7, 0, 0, 0, madrid),
+		},
+		{
+			name:          "invalid day",
+			policy:        weekly,
+			now:           time.Date(2025, 8, 15, 18, 0, 0, 0, madrid),
+			day:           "Funday",
+			hour:          "07:00",
+			wantErrTarget: ErrInvalidDay,
+		},
+		{
+			name:          "invalid hour",
+			policy:        rolling,
+			now:           time.Date(2025, 8, 15, 18, 0, 0, 0, madrid),
+			day:           models.DayMonday,
+			hour:          "7am",
+			wantErrTarget: ErrInvalidHour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classStart, opensAt, err := tt.policy.NextClass(tt.day, tt.hour, tt.now)
+			if tt.wantErrTarget != nil {
+				assert.ErrorIs(t, err, tt.wantErrTarget)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.wantClass.Equal(classStart), "class start %s, want %s", classStart, tt.wantClass)
+			assert.True(t, tt.wantOpensAt.Equal(opensAt), "opens at %s, want %s", opensAt, tt.wantOpensAt)
+		})
+	}
+}
+
+func TestBookingWindowPolicy_CronSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		config BookingWindowConfig
+		want   string
+	}{
+		{
+			name:   "weekly runs ahead of the opening",
+			config: BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "Europe/Madrid", OpenWeekday: "Saturday", OpenTime: "12:00", RunLead: 5 * time.Minute},
+			want:   "CRON_TZ=Europe/Madrid 55 11 * * 6",
+		},
+		{
+			name:   "weekly lead wraps to the previous week",
+			config: BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "UTC", OpenWeekday: "Sunday", OpenTime: "00:02", RunLead: 5 * time.Minute},
+			want:   "CRON_TZ=UTC 57 23 * * 6",
+		},
+		{
+			name:   "rolling checks every minute",
+			config: BookingWindowConfig{Mode: BookingWindowRolling, Timezone: "UTC", OpensBefore: 48 * time.Hour},
+			want:   "@every 1m",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewBookingWindowPolicy(tt.config)
+			require.NoError(t, err)
+
+			spec := policy.CronSpec()
+			assert.Equal(t, tt.want, spec)
+			_, err = cron.ParseStandard(spec)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBookingWindowPolicy_KeepAliveCronSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		config BookingWindowConfig
+		lead   time.Duration
+		want   string
+	}{
+		{
+			name:   "weekly checks ahead of the opening",
+			config: BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "Europe/Madrid", OpenWeekday: "Saturday", OpenTime: "12:00"},
+			lead:   2 * time.Hour,
+			want:   "CRON_TZ=Europe/Madrid 0 10 * * 6",
+		},
+		{
+			name:   "weekly lead longer than a day",
+			config: BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "UTC", OpenWeekday: "Monday", OpenTime: "08:30"},
+			lead:   36 * time.Hour,
+			want:   "CRON_TZ=UTC 30 20 * * 6",
+		},
+		{
+			name:   "rolling checks every lead",
+			config: BookingWindowConfig{Mode: BookingWindowRolling, Timezone: "UTC", OpensBefore: 48 * time.Hour},
+			lead:   2 * time.Hour,
+			want:   "@every 2h0m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewBookingWindowPolicy(tt.config)
+			require.NoError(t, err)
+
+			spec := policy.KeepAliveCronSpec(tt.lead)
+			assert.Equal(t, tt.want, spec)
+			_, err = cron.ParseStandard(spec)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBookingWindowPolicy_IsDue(t *testing.T) {
+	policy := newTestWindow(t)
+	opensAt := time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, policy.IsDue(opensAt, time.Date(2025, 8, 16, 11, 55, 0, 0, time.UTC)))
+	assert.True(t, policy.IsDue(opensAt, time.Date(2025, 8, 16, 13, 0, 0, 0, time.UTC)))
+	assert.False(t, policy.IsDue(opensAt, time.Date(2025, 8, 16, 11, 50, 0, 0, time.UTC)))
+}
+
+func TestBookingWindowPolicy_ClassStart(t *testing.T) {
+	policy, err := NewBookingWindowPolicy(BookingWindowConfig{
+		Mode:        BookingWindowWeekly,
+		Timezone:    "Europe/Madrid",
+		OpenWeekday: "Saturday",
+		OpenTime:    "12:00",
+	})
+	require.NoError(t, err)
+
+	start, err := policy.ClassStart("2025-08-18", "07:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 8, 18, 5, 0, 0, 0, time.UTC), start.UTC())
+
+	_, err = policy.ClassStart("", "07:00")
+	assert.Error(t, err)
+}
+
+func TestBookingWindowPolicy_WeekDates(t *testing.T) {
+	policy, err := NewBookingWindowPolicy(BookingWindowConfig{Mode: BookingWindowWeekly, Timezone: "Europe/Madrid", OpenWeekday: "Saturday", OpenTime: "12:00"})
+	require.NoError(t, err)
+
+	// Sunday 23:30 UTC is already Monday in Madrid
+	now := time.Date(2025, 8, 17, 23, 30, 0, 0, time.UTC)
+
+	format := func(dates []time.Time) []string {
+		var formatted []string
+		for _, date := range dates {
+			formatted = append(formatted, date.Format("2006-01-02 Mon"))
+		}
+		return formatted
+	}
+
+	assert.Equal(t, []string{
+		"2025-08-18 Mon", "2025-08-19 Tue", "2025-08-20 Wed", "2025-08-21 Thu",
+		"2025-08-22 Fri", "2025-08-23 Sat", "2025-08-24 Sun",
+	}, format(policy.WeekDates(now, false)))
+
+	next := policy.WeekDates(now, true)
+	assert.Equal(t, "2025-08-25 Mon", format(next)[0])
+	assert.Equal(t, "2025-08-31 Sun", format(next)[6])
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// minPrefixLength is the shortest input matched as the start of a class type,
+// so "hyr" finds "HYROX" but "o" doesn't pick one of the "Open" classes
+const minPrefixLength = 3
+
+// catalogueRetryDelay is how long a catalogue whose refresh failed waits
+// before it is refreshed again, so a failing site isn't logged in to on every
+// command
+const catalogueRetryDelay = 15 * time.Minute
+
+// ClassCatalogue holds the class types of a gym, exactly as its timetable
+// shows them in h3.entrenamiento, and resolves what users type into one of
+// them. It starts from the known types and grows with every timetable read
+// from the gym.
+type ClassCatalogue struct {
+	gym string
+	ttl time.Duration
+
+	mu         sync.RWMutex
+	classTypes []string
+	aliases    map[string]string // normalized alias -> class type
+	updatedAt  time.Time
+	failedAt   time.Time
+}
+
+// NewClassCatalogue creates the catalogue of the gym from the known class
+// types and the aliases mapping user-friendly names to them. It is stale,
+// and should be refreshed from the gym's timetable, once ttl has passed since
+// it was last refreshed.
+func NewClassCatalogue(gym string, known []string, aliases map[string]string, ttl time.Duration) *ClassCatalogue {
+	c := &ClassCatalogue{
+		gym:     gym,
+		ttl:     ttl,
+		aliases: make(map[string]string, len(aliases)),
+	}
+	for _, classType := range known {
+		c.add(classType)
+	}
+	for alias, classType := range aliases {
+		c.aliases[normalizeClassType(alias)] = classType
+	}
+	return c
+}
+
+// Gym returns the gym the catalogue belongs to
+func (c *ClassCatalogue) Gym() string {
+	return c.gym
+}
+
+// ClassTypes returns the class types of the catalogue, sorted
+func (c *ClassCatalogue) ClassTypes() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	classTypes := slices.Clone(c.classTypes)
+	slices.SortFunc(classTypes, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return classTypes
+}
+
+// Learn adds the class types of a timetable read from the gym and marks the
+// catalogue as refreshed. It returns the class types that were new.
+func (c *ClassCatalogue) Learn(classes []models.ClassSchedule, now time.Time) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var added []string
+	for _, class := range classes {
+		if c.add(class.ClassType) {
+			added = append(added, class.ClassType)
+		}
+	}
+	c.updatedAt = now
+	return added
+}
+
+// RefreshFailed records a failed refresh, which is not retried until
+// catalogueRetryDelay has passed
+func (c *ClassCatalogue) RefreshFailed(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failedAt = now
+}
+
+// Stale reports whether the catalogue should be refreshed from the gym
+func (c *ClassCatalogue) Stale(now time.Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.failedAt.After(c.updatedAt) && now.Sub(c.failedAt) < catalogueRetryDelay {
+		return false
+	}
+	return c.updatedAt.IsZero() || now.Sub(c.updatedAt) >= c.ttl
+}
+
+// Resolve returns the class type the input stands for. Case, accents, spaces
+// and punctuation are ignored; the input may be a class type, an alias, the
+// start of a single class type or either of them with a typo or two.
+func (c *ClassCatalogue) Resolve(input string) (string, error) {
+	name := normalizeClassType(input)
+	if name == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidClassType, input)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, classType := range c.classTypes {
+		if normalizeClassType(classType) == name {
+			return classType, nil
+		}
+	}
+	if classType, ok := c.aliases[name]; ok {
+		return classType, nil
+	}
+
+	if len(name) >= minPrefixLength {
+		var matches []string
+		for _, classType := range c.classTypes {
+			if strings.HasPrefix(normalizeClassType(classType), name) {
+				matches = append(matches, classType)
+			}
+		}
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+	}
+
+	// Typos: the closest class type or alias within the allowed distance, as
+	// long as it is the only one that close
+	maxDistance := 1
+	if len(name) > 5 {
+		maxDistance = 2
+	}
+	best, bestDistance, ambiguous := "", maxDistance+1, false
+	consider := func(candidate, classType string) {
+		distance := editDistance(name, candidate)
+		switch {
+		case distance < bestDistance:
+			best, bestDistance, ambiguous = classType, distance, false
+		case distance == bestDistance && classType != best:
+			ambiguous = true
+		}
+	}
+	for _, classType := range c.classTypes {
+		consider(normalizeClassType(classType), classType)
+	}
+	for alias, classType := range c.aliases {
+		consider(alias, classType)
+	}
+	if best != "" && !ambiguous {
+		return best, nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidClassType, input)
+}
+
+// add adds the class type unless the catalogue already has it
+func (c *ClassCatalogue) add(classType string) bool {
+	name := normalizeClassType(classType)
+	if name == "" {
+		return false
+	}
+	for _, known := range c.classTypes {
+		if normalizeClassType(known) == name {
+			return false
+		}
+	}
+	c.classTypes = append(c.classTypes, classType)
+	return true
+}
+
+// normalizeClassType lowercases the class type and drops accents and anything
+// but letters and digits, so "Pierna/Glúteo" and "pierna gluteo" compare equal
+func normalizeClassType(classType string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(stripAccents, classType)
+	if err != nil {
+		folded = classType
+	}
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, folded)
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCatalogue() *ClassCatalogue {
+	return NewClassCatalogue("https://box.wodbuster.com",
+		[]string{"Wod", "Open box", "Open TOTAL", "HYROX", "GYMaquinas", "Pierna/Gluteo", "BOMBEROS"},
+		map[string]string{"open": "Open box", "legs": "Pierna/Gluteo", "machines": "GYMaquinas"},
+		24*time.Hour)
+}
+
+func TestClassCatalogue_Resolve(t *testing.T) {
+	catalogue := newTestCatalogue()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"exact", "Wod", "Wod", false},
+		{"lowercase", "wod", "Wod", false},
+		{"without spaces", "openbox", "Open box", false},
+		{"without punctuation", "piernagluteo", "Pierna/Gluteo", false},
+		{"with accents", "Pierna/Glúteo", "Pierna/Gluteo", false},
+		{"alias", "open", "Open box", false},
+		{"alias with typo", "legz", "Pierna/Gluteo", false},
+		{"unique prefix", "hyr", "HYROX", false},
+		{"typo", "hyrox!", "HYROX", false},
+		{"two typos in a long name", "bomberso", "BOMBEROS", false},
+		{"prefix of an alias", "ope", "Open box", false},
+		{"too short", "o", "", true},
+		{"unknown", "yoga", "", true},
+		{"empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := catalogue.Resolve(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidClassType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClassCatalogue_Learn(t *testing.T) {
+	catalogue := newTestCatalogue()
+	now := time.Date(2025, 8, 18, 10, 0, 0, 0, time.UTC)
+	assert.True(t, catalogue.Stale(now), "a catalogue never read from the gym is stale")
+
+	_, err := catalogue.Resolve("yoga")
+	assert.ErrorIs(t, err, ErrInvalidClassType)
+
+	added := catalogue.Learn([]models.ClassSchedule{
+		{Hour: "07:00", ClassType: "Wod"},
+		{Hour: "09:00", ClassType: "Yoga Flow"},
+		{Hour: "10:00", ClassType: "yoga flow"},
+	}, now)
+	assert.Equal(t, []string{"Yoga Flow"}, added)
+
+	got, err := catalogue.Resolve("yogaflow")
+	require.NoError(t, err)
+	assert.Equal(t, "Yoga Flow", got)
+	assert.Contains(t, catalogue.ClassTypes(), "Yoga Flow")
+
+	assert.False(t, catalogue.Stale(now.Add(time.Hour)))
+	assert.True(t, catalogue.Stale(now.Add(24*time.Hour)))
+}
+
+func TestClassCatalogue_RefreshFailed(t *testing.T) {
+	catalogue := newTestCatalogue()
+	now := time.Date(2025, 8, 18, 10, 0, 0, 0, time.UTC)
+
+	// A failed refresh is retried after a while, not on every command
+	catalogue.RefreshFailed(now)
+	assert.False(t, catalogue.Stale(now.Add(time.Minute)))
+	assert.True(t, catalogue.Stale(now.Add(catalogueRetryDelay)))
+
+	catalogue.Learn(nil, now.Add(catalogueRetryDelay))
+	assert.False(t, catalogue.Stale(now.Add(catalogueRetryDelay+time.Minute)))
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+)
+
+var (
+	ErrCredentialsUnavailable = errors.New("stored credentials unavailable")
+	ErrSessionRestoreFailed   = errors.New("failed to restore WODBuster session")
+)
+
+// Credentials holds what is needed to act on WODBuster on behalf of a user
+type Credentials struct {
+	Email          string
+	Password       string         // Decrypted password, empty if it could not be decrypted
+	SessionCookies []*http.Cookie // Stored session cookies, only set while the session is still valid
+}
+
+// CredentialProvider resolves the credentials of a stored user
+type CredentialProvider interface {
+	Credentials(ctx context.Context, user models.User) (Credentials, error)
+}
+
+// SessionStore loads the browser sessions saved when users log in
+type SessionStore interface {
+	GetUserSession(ctx context.Context, chatID int64) (models.UserSession, bool)
+}
+
+// EncryptedCredentialProvider decrypts the password stored on the user with
+// the keyring and hands out the stored session cookies while they are valid
+type EncryptedCredentialProvider struct {
+	keyring  *utils.Keyring
+	sessions SessionStore
+	logger   *slog.Logger
+}
+
+func NewEncryptedCredentialProvider(keyring *utils.Keyring, sessions SessionStore, logger *slog.Logger) *EncryptedCredentialProvider {
+	return &EncryptedCredentialProvider{
+		keyring:  keyring,
+		sessions: sessions,
+		logger:   logger,
+	}
+}
+
+// Credentials returns the user's credentials. A password that cannot be
+// decrypted is only an error when there is no valid session to fall back on.
+func (p *EncryptedCredentialProvider) Credentials(ctx context.Context, user models.User) (Credentials, error) {
+	creds := Credentials{Email: user.Email, SessionCookies: p.sessionCookies(ctx, user)}
+
+	password, err := p.keyring.Decrypt(user.Password)
+	if err != nil {
+		if len(creds.SessionCookies) == 0 {
+			return Credentials{}, fmt.Errorf("%w: %w", ErrCredentialsUnavailable, err)
+		}
+		p.logger.Warn("Failed to decrypt password, relying on stored session",
+			"chat_id", user.ChatID,
+			"error", err)
+		return creds, nil
+	}
+
+	creds.Password = password
+	return creds, nil
+}
+
+// sessionCookies returns the cookies of the user's stored session while it is
+// valid. Users who logged in before whole sessions were stored only have
+// their session cookie.
+func (p *EncryptedCredentialProvider) sessionCookies(ctx context.Context, user models.User) []*http.Cookie {
+	if session, ok := p.sessions.GetUserSession(ctx, user.ChatID); ok {
+		if !session.Valid(time.Now()) {
+			return nil
+		}
+		return session.HTTPCookies()
+	}
+
+	if user.HasValidSession() {
+		return []*http.Cookie{user.WODBusterSessionCookie}
+	}
+	return nil
+}
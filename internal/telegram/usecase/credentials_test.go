@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedCredentialProvider_Credentials(t *testing.T) {
+	const key = "12345678901234567890123456789012"
+
+	keyring, err := utils.NewKeyring("v1", map[string]string{"v1": key})
+	require.NoError(t, err)
+	encrypted, err := keyring.Encrypt("secret")
+	require.NoError(t, err)
+	// Passwords stored before keyrings were encrypted with the key directly
+	legacy, err := utils.EncryptPassword("secret", key)
+	require.NoError(t, err)
+
+	validSession := &http.Cookie{Name: ".WBAuth", Value: "session", Expires: time.Now().Add(time.Hour)}
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	// Users 2 and 3 logged in after whole sessions were stored
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUserSession(context.Background(), models.NewUserSession(2, []*http.Cookie{
+		validSession, {Name: "ASP.NET_SessionId", Value: "aspnet"},
+	}, time.Now())))
+	require.NoError(t, store.InvalidateUserSession(context.Background(), 3))
+	provider := NewEncryptedCredentialProvider(keyring, store, logger)
+
+	tests := []struct {
+		name         string
+		user         models.User
+		wantPassword string
+		wantCookies  int
+		wantErr      error
+	}{
+		{
+			name:         "password only",
+			user:         models.User{Email: "a@b.com", Password: encrypted},
+			wantPassword: "secret",
+		},
+		{
+			name:         "password encrypted with the key directly",
+			user:         models.User{Email: "a@b.com", Password: legacy},
+			wantPassword: "secret",
+		},
+		{
+			name: "password and valid session",
+			user: models.User{Email: "a@b.com", Password: encrypted, WODBusterSessionCookie: validSession,
+				SessionValid: true, SessionExpiresAt: validSession.Expires},
+			wantPassword: "secret",
+			wantCookies:  1,
+		},
+		{
+			name: "undecryptable password falls back to session",
+			user: models.User{Email: "a@b.com", Password: "garbage", WODBusterSessionCookie: validSession,
+				SessionValid: true, SessionExpiresAt: validSession.Expires},
+			wantCookies: 1,
+		},
+		{
+			name:         "password and stored session",
+			user:         models.User{ChatID: 2, Email: "a@b.com", Password: encrypted},
+			wantPassword: "secret",
+			wantCookies:  2,
+		},
+		{
+			name: "invalidated session hides the session cookie",
+			user: models.User{ChatID: 3, Email: "a@b.com", Password: encrypted, WODBusterSessionCookie: validSession,
+				SessionValid: true, SessionExpiresAt: validSession.Expires},
+			wantPassword: "secret",
+		},
+		{
+			name: "undecryptable password and expired session",
+			user: models.User{Email: "a@b.com", Password: "garbage", WODBusterSessionCookie: validSession,
+				SessionValid: true, SessionExpiresAt: time.Now().Add(-time.Hour)},
+			wantErr: ErrCredentialsUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := provider.Credentials(context.Background(), tt.user)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.user.Email, creds.Email)
+			assert.Equal(t, tt.wantPassword, creds.Password)
+			assert.Len(t, creds.SessionCookies, tt.wantCookies)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// keepAliveTimeout bounds the check of a single user's session
+const keepAliveTimeout = 2 * time.Minute
+
+const credentialsBrokenMessage = "⚠️ I couldn't log in to WODBuster with your saved credentials, so your next bookings would fail.\n💡 Log in again with /login before booking opens."
+
+// processKeepAlive checks the session of every logged in user ahead of the
+// booking window (called by the keep-alive cronjob). Users with a booking due
+// are left out: their booking logs in by itself, and the check would take a
+// pooled client from the bookings.
+func (bs *BookingScheduler) processKeepAlive() {
+	ctx := context.Background()
+
+	users, err := bs.storage.GetAllUsers(ctx)
+	if err != nil {
+		bs.logger.Error("Failed to get users", "error", err)
+		return
+	}
+	booking := bs.usersBooking(ctx, time.Now())
+
+	// The client pool bounds how many sessions are checked at once
+	var wg sync.WaitGroup
+	checked := 0
+	for _, user := range users {
+		if !user.IsAuthenticated || booking[user.ChatID] {
+			continue
+		}
+		checked++
+		wg.Add(1)
+		go func(chatID int64) {
+			defer wg.Done()
+			bs.keepSessionAlive(ctx, chatID)
+		}(user.ChatID)
+	}
+	wg.Wait()
+
+	bs.logger.Info("Checked stored sessions", "users", checked)
+}
+
+// usersBooking returns the users with a booking running, or due by the time
+// a session check started now would be over
+func (bs *BookingScheduler) usersBooking(ctx context.Context, now time.Time) map[int64]bool {
+	booking := make(map[int64]bool)
+
+	bs.activeBookingsMux.RLock()
+	for chatID := range bs.activeBookings {
+		booking[chatID] = true
+	}
+	bs.activeBookingsMux.RUnlock()
+
+	pending, err := bs.storage.GetAllPendingBookings(ctx)
+	if err != nil {
+		bs.logger.Warn("Failed to get pending bookings, checking every session", "error", err)
+		return booking
+	}
+	for _, attempt := range pending {
+		if bs.window.IsDue(attempt.AttemptTime, now.Add(keepAliveTimeout)) {
+			booking[attempt.ChatID] = true
+		}
+	}
+	return booking
+}
+
+// keepSessionAlive probes the user's session, which logs in again if it died,
+// and warns the user once when their credentials no longer work
+func (bs *BookingScheduler) keepSessionAlive(ctx context.Context, chatID int64) {
+	probeCtx, cancel := context.WithTimeout(ctx, keepAliveTimeout)
+	defer cancel()
+
+	status, err := bs.ProbeSession(probeCtx, chatID)
+	switch {
+	case err == nil && status.Alive:
+		bs.keepAliveMux.Lock()
+		delete(bs.keepAliveWarned, chatID)
+		bs.keepAliveMux.Unlock()
+		return
+	case err == nil, errors.Is(err, ErrCredentialsUnavailable):
+		bs.logger.Warn("No way left to log in", "chat_id", chatID, "error", err)
+	case errors.Is(err, ErrInvalidWODBusterLogin) && bs.classify(err) != BookingErrorTransient:
+		bs.logger.Warn("Saved credentials no longer work", "chat_id", chatID, "error", err)
+	default:
+		// WODBuster or the browser failed, the next check tries again
+		bs.logger.Error("Failed to check stored session", "chat_id", chatID, "error", err)
+		return
+	}
+
+	bs.keepAliveMux.Lock()
+	warned := bs.keepAliveWarned[chatID]
+	bs.keepAliveWarned[chatID] = true
+	bs.keepAliveMux.Unlock()
+
+	if !warned {
+		bs.notify(ctx, chatID, credentialsBrokenMessage)
+	}
+}
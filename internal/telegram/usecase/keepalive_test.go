@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingScheduler_KeepSessionAlive(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	stored := []*http.Cookie{{Name: ".WBAuth", Value: "old", Expires: time.Now().Add(24 * time.Hour)}}
+	fresh := []*http.Cookie{{Name: ".WBAuth", Value: "new", Expires: time.Now().Add(48 * time.Hour)}}
+
+	tests := []struct {
+		name        string
+		credentials Credentials
+		setupClient func(*MockAPIClient)
+		wantWarning bool
+		wantCookie  string // Value of the stored session cookie afterwards, empty if invalid
+	}{
+		{
+			name:        "stored session is alive",
+			credentials: Credentials{Email: "a@b.com", Password: "secret", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(nil)
+			},
+			wantCookie: "old",
+		},
+		{
+			name:        "dead session is logged in again",
+			credentials: Credentials{Email: "a@b.com", Password: "secret", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(errTestSessionExpired)
+				client.EXPECT().LogIn(mock.Anything, "a@b.com", "secret").Return(fresh[0], nil)
+				client.EXPECT().GetCookies().Return(fresh)
+			},
+			wantCookie: "new",
+		},
+		{
+			name:        "credentials no longer work",
+			credentials: Credentials{Email: "a@b.com", Password: "changed", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(errTestSessionExpired)
+				client.EXPECT().LogIn(mock.Anything, "a@b.com", "changed").Return(nil, errors.New("invalid credentials"))
+			},
+			wantWarning: true,
+		},
+		{
+			name:        "dead session without password",
+			credentials: Credentials{Email: "a@b.com", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(errTestSessionExpired)
+			},
+			wantWarning: true,
+		},
+		{
+			name:        "WODBuster times out",
+			credentials: Credentials{Email: "a@b.com", Password: "secret"},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LogIn(mock.Anything, "a@b.com", "secret").Return(nil, context.DeadlineExceeded)
+			},
+			wantCookie: "old",
+		},
+		{
+			name:        "stored session can't be checked",
+			credentials: Credentials{Email: "a@b.com", Password: "secret", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(errTestTimeout)
+			},
+			wantCookie: "old",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, IsAuthenticated: true, Email: "a@b.com"}))
+			require.NoError(t, store.SaveUserSession(ctx, models.NewUserSession(chatID, stored, time.Now())))
+
+			client := NewMockAPIClient(t)
+			tt.setupClient(client)
+			creds := NewMockCredentialProvider(t)
+			creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(tt.credentials, nil)
+
+			pool := NewMockClientPool(t)
+			pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+			pool.EXPECT().Release(client).Return()
+
+			notifier := NewMockNotifier(t)
+			if tt.wantWarning {
+				// Broken credentials are only reported once, not on every check
+				notifier.EXPECT().Notify(mock.Anything, chatID, credentialsBrokenMessage).Return(nil).Once()
+			}
+
+			scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger,
+				WithKeepAlive(2*time.Hour),
+				WithErrorClassifier(classifyTestError))
+			scheduler.SetNotifier(notifier)
+
+			scheduler.keepSessionAlive(ctx, chatID)
+			scheduler.keepSessionAlive(ctx, chatID)
+
+			// A dead session is replaced by the new login's cookies
+			session, _ := store.GetUserSession(ctx, chatID)
+			if tt.wantCookie == "" {
+				assert.False(t, session.Valid(time.Now()))
+				return
+			}
+			require.True(t, session.Valid(time.Now()))
+			assert.Equal(t, tt.wantCookie, session.Cookies[0].Value)
+		})
+	}
+}
+
+func TestBookingScheduler_ProcessKeepAliveSkipsUsers(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: 1, IsAuthenticated: true, Email: "a@b.com"}))
+	// Logged out
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: 2, Email: "c@d.com"}))
+	// About to book, or booking a class
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: 3, IsAuthenticated: true, Email: "e@f.com"}))
+	require.NoError(t, store.SaveBookingAttempt(ctx, models.BookingAttempt{ID: "due", ChatID: 3, Status: "pending",
+		AttemptTime: time.Now().Add(5 * time.Minute)}))
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: 4, IsAuthenticated: true, Email: "g@h.com"}))
+	require.NoError(t, store.SaveBookingAttempt(ctx, models.BookingAttempt{ID: "later", ChatID: 1, Status: "pending",
+		AttemptTime: time.Now().Add(24 * time.Hour)}))
+
+	client := NewMockAPIClient(t)
+	client.EXPECT().LogIn(mock.Anything, "a@b.com", "secret").Return(&http.Cookie{Name: ".WBAuth"}, nil).Once()
+	client.EXPECT().GetCookies().Return([]*http.Cookie{{Name: ".WBAuth"}})
+
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.MatchedBy(func(user models.User) bool {
+		return user.ChatID == 1
+	})).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil).Once()
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return()
+
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger, WithKeepAlive(2*time.Hour))
+	scheduler.trackActiveBooking(&BookingContext{AttemptID: "active", ChatID: 4, Cancel: func() {}})
+	scheduler.processKeepAlive()
+}
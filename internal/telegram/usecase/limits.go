@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+)
+
+var (
+	ErrWeeklyLimitReached = errors.New("weekly class limit reached")
+	ErrDailyLimitReached  = errors.New("daily class limit reached")
+	ErrOverlappingClass   = errors.New("class overlaps another scheduled class")
+)
+
+// BookingLimits returns the limits the user's classes are booked within: their
+// own, or the bot's defaults
+func (bs *BookingScheduler) BookingLimits(user models.User) models.BookingLimits {
+	if user.BookingLimits != nil {
+		return *user.BookingLimits
+	}
+	return bs.limits
+}
+
+// CheckScheduleLimits reports whether adding the rule keeps the user within
+// their limits, counting the user's other rules whose date ranges overlap its
+// own. A rule with the same ID is replaced by it, so it doesn't count.
+func (bs *BookingScheduler) CheckScheduleLimits(ctx context.Context, chatID int64, schedule models.ClassBookingSchedule) error {
+	user, exists := bs.storage.GetUser(ctx, chatID)
+	if !exists {
+		return ErrUserNotFound
+	}
+
+	var others []models.ClassBookingSchedule
+	for _, other := range user.ClassBookingSchedules {
+		if other.ID != schedule.ID && datesOverlap(other, schedule) {
+			others = append(others, other)
+		}
+	}
+	return bs.checkLimits(bs.BookingLimits(user), others, schedule)
+}
+
+// checkWeekLimits reports whether the rule's class on classDate keeps the user
+// within their limits that week. The classes of the user's other rules are
+// taken in the order the rules were added, leaving out the ones that would
+// break the limits themselves, so the rules added first win.
+func (bs *BookingScheduler) checkWeekLimits(user models.User, schedule models.ClassBookingSchedule, classDate time.Time) error {
+	limits := bs.BookingLimits(user)
+	weekStart := startOfWeek(classDate)
+
+	var booked []models.ClassBookingSchedule
+	for _, other := range user.ClassBookingSchedules {
+		if other.ID == schedule.ID {
+			break
+		}
+		date, ok := dateInWeek(weekStart, other.Day)
+		if !ok || !other.AppliesOn(date) {
+			continue
+		}
+		if bs.checkLimits(limits, booked, other) == nil {
+			booked = append(booked, other)
+		}
+	}
+	return bs.checkLimits(limits, booked, schedule)
+}
+
+// checkLimits reports whether the candidate's class can be booked along with
+// the classes of the booked rules, all taking place within the same week
+func (bs *BookingScheduler) checkLimits(limits models.BookingLimits, booked []models.ClassBookingSchedule, candidate models.ClassBookingSchedule) error {
+	sameDay := 0
+	for _, other := range booked {
+		if other.Day != candidate.Day {
+			continue
+		}
+		sameDay++
+		if classesOverlap(other.Hour, candidate.Hour, bs.classDuration) {
+			return fmt.Errorf("%w: %s at %s", ErrOverlappingClass, other.ClassType, other.Hour)
+		}
+	}
+
+	if limits.MaxPerDay > 0 && sameDay >= limits.MaxPerDay {
+		return fmt.Errorf("%w: %d per day", ErrDailyLimitReached, limits.MaxPerDay)
+	}
+	if limits.MaxPerWeek > 0 && len(booked) >= limits.MaxPerWeek {
+		return fmt.Errorf("%w: %d per week", ErrWeeklyLimitReached, limits.MaxPerWeek)
+	}
+	return nil
+}
+
+// classesOverlap reports whether two classes starting at the hours (HH:MM) of
+// the same day overlap, lasting duration each. A zero duration never overlaps.
+func classesOverlap(a, b string, duration time.Duration) bool {
+	start, errA := time.Parse("15:04", a)
+	other, errB := time.Parse("15:04", b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return start.Sub(other).Abs() < duration
+}
+
+// datesOverlap reports whether the date ranges of two rules overlap, empty
+// dates leaving the range open
+func datesOverlap(a, b models.ClassBookingSchedule) bool {
+	return (a.StartDate == "" || b.EndDate == "" || a.StartDate <= b.EndDate) &&
+		(b.StartDate == "" || a.EndDate == "" || b.StartDate <= a.EndDate)
+}
+
+// startOfWeek returns the midnight of the Monday of t's week, in t's location
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// dateInWeek returns the date of the day in the week starting on weekStart
+func dateInWeek(weekStart time.Time, day models.Day) (time.Time, bool) {
+	weekday, ok := day.Weekday()
+	if !ok {
+		return time.Time{}, false
+	}
+	return weekStart.AddDate(0, 0, (int(weekday)+6)%7), true
+}
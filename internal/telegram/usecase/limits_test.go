@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingScheduler_CheckScheduleLimits(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+
+	mondayWod := models.ClassBookingSchedule{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod"}
+	tuesdayWod := models.ClassBookingSchedule{ID: "Tuesday-07:00-Wod", Day: "Tuesday", Hour: "07:00", ClassType: "Wod"}
+
+	tests := []struct {
+		name      string
+		limits    *models.BookingLimits
+		schedules []models.ClassBookingSchedule
+		candidate models.ClassBookingSchedule
+		wantErr   error
+	}{
+		{
+			name:      "within the limits",
+			limits:    &models.BookingLimits{MaxPerWeek: 3, MaxPerDay: 2},
+			schedules: []models.ClassBookingSchedule{mondayWod, tuesdayWod},
+			candidate: models.ClassBookingSchedule{ID: "Monday-19:00-Wod", Day: "Monday", Hour: "19:00", ClassType: "Wod"},
+		},
+		{
+			name:      "weekly limit reached",
+			limits:    &models.BookingLimits{MaxPerWeek: 2},
+			schedules: []models.ClassBookingSchedule{mondayWod, tuesdayWod},
+			candidate: models.ClassBookingSchedule{ID: "Friday-07:00-Wod", Day: "Friday", Hour: "07:00", ClassType: "Wod"},
+			wantErr:   ErrWeeklyLimitReached,
+		},
+		{
+			name:      "daily limit reached",
+			limits:    &models.BookingLimits{MaxPerDay: 1},
+			schedules: []models.ClassBookingSchedule{mondayWod, tuesdayWod},
+			candidate: models.ClassBookingSchedule{ID: "Monday-19:00-Wod", Day: "Monday", Hour: "19:00", ClassType: "Wod"},
+			wantErr:   ErrDailyLimitReached,
+		},
+		{
+			name:      "overlapping class",
+			schedules: []models.ClassBookingSchedule{mondayWod},
+			candidate: models.ClassBookingSchedule{ID: "Monday-07:30-Open box", Day: "Monday", Hour: "07:30", ClassType: "Open box"},
+			wantErr:   ErrOverlappingClass,
+		},
+		{
+			name:      "class right after another",
+			schedules: []models.ClassBookingSchedule{mondayWod},
+			candidate: models.ClassBookingSchedule{ID: "Monday-08:00-Open box", Day: "Monday", Hour: "08:00", ClassType: "Open box"},
+		},
+		{
+			name:   "rules of other dates don't count",
+			limits: &models.BookingLimits{MaxPerWeek: 1},
+			schedules: []models.ClassBookingSchedule{
+				{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod", EndDate: "2025-08-31"},
+			},
+			candidate: models.ClassBookingSchedule{ID: "Tuesday-07:00-Wod", Day: "Tuesday", Hour: "07:00", ClassType: "Wod", StartDate: "2025-09-01"},
+		},
+		{
+			name:      "the replaced rule doesn't count",
+			limits:    &models.BookingLimits{MaxPerWeek: 2},
+			schedules: []models.ClassBookingSchedule{mondayWod, tuesdayWod},
+			candidate: models.ClassBookingSchedule{ID: "Tuesday-07:00-Wod", Day: "Tuesday", Hour: "07:00", ClassType: "Wod", EndDate: "2025-12-22"},
+		},
+		{
+			name:      "default limits",
+			schedules: []models.ClassBookingSchedule{mondayWod, tuesdayWod},
+			candidate: models.ClassBookingSchedule{ID: "Friday-07:00-Wod", Day: "Friday", Hour: "07:00", ClassType: "Wod"},
+			wantErr:   ErrWeeklyLimitReached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler, store := newTestScheduler(t)
+			WithLimits(models.BookingLimits{MaxPerWeek: 2}, time.Hour)(scheduler)
+			require.NoError(t, store.SaveUser(ctx, models.User{
+				ChatID:                chatID,
+				BookingLimits:         tt.limits,
+				ClassBookingSchedules: tt.schedules,
+			}))
+
+			err := scheduler.CheckScheduleLimits(ctx, chatID, tt.candidate)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBookingScheduler_ExpandSchedulesRejectsClassesOverLimits(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+
+	// Friday before the booking run of Saturday 2025-08-16
+	now := time.Date(2025, 8, 15, 18, 0, 0, 0, time.UTC)
+
+	scheduler, store := newTestScheduler(t)
+	require.NoError(t, store.SaveUser(ctx, models.User{
+		ChatID:        chatID,
+		BookingLimits: &models.BookingLimits{MaxPerWeek: 2, MaxPerDay: 1},
+		ClassBookingSchedules: []models.ClassBookingSchedule{
+			{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod"},
+			{ID: "Monday-07:30-Open box", Day: "Monday", Hour: "07:30", ClassType: "Open box"},
+			{ID: "Tuesday-07:00-Wod", Day: "Tuesday", Hour: "07:00", ClassType: "Wod", SkipDates: []string{"2025-08-19"}},
+			{ID: "Wednesday-07:00-Wod", Day: "Wednesday", Hour: "07:00", ClassType: "Wod"},
+			{ID: "Friday-07:00-Wod", Day: "Friday", Hour: "07:00", ClassType: "Wod"},
+		},
+	}))
+
+	notifier := NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, chatID, mock.MatchedBy(func(message string) bool {
+		return strings.HasPrefix(message, "🚫 Open box on Monday 2025-08-18 at 07:30 won't be booked: class overlaps another scheduled class: Wod at 07:00.")
+	})).Return(nil).Once()
+	notifier.EXPECT().Notify(mock.Anything, chatID, mock.MatchedBy(func(message string) bool {
+		return strings.HasPrefix(message, "🚫 Wod on Friday 2025-08-22 at 07:00 won't be booked: weekly class limit reached: 2 per week.")
+	})).Return(nil).Once()
+	scheduler.SetNotifier(notifier)
+
+	require.NoError(t, scheduler.ExpandSchedules(ctx, now))
+	// Rejected attempts are not checked or notified again
+	require.NoError(t, scheduler.ExpandSchedules(ctx, now))
+
+	// The skipped Tuesday leaves room for Wednesday, the rules added first win
+	wantStatus := map[string]string{
+		"42-Monday-07:00-Wod-2025-08-18":      "pending",
+		"42-Monday-07:30-Open box-2025-08-18": "rejected",
+		"42-Wednesday-07:00-Wod-2025-08-20":   "pending",
+		"42-Friday-07:00-Wod-2025-08-22":      "rejected",
+	}
+	for attemptID, status := range wantStatus {
+		attempt, exists := store.GetBookingAttempt(ctx, attemptID)
+		require.True(t, exists, attemptID)
+		assert.Equal(t, status, attempt.Status, attemptID)
+	}
+	_, exists := store.GetBookingAttempt(ctx, "42-Tuesday-07:00-Wod-2025-08-19")
+	assert.False(t, exists)
+}
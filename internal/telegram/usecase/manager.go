@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
@@ -24,51 +26,112 @@ var (
 	ErrInvalidBookingAttemptStatus   = errors.New("invalid booking attempt status")
 	ErrInvalidBookingAttemptErrorMsg = errors.New("invalid booking attempt error msg")
 	ErrInvalidWODBusterLogin         = errors.New("invalid WODBuster login")
+	ErrScheduleNotFound              = errors.New("class booking schedule not found")
+	ErrCancelBookingFailed           = errors.New("failed to cancel booking")
+	ErrBurstWindowExpired            = errors.New("class could not be booked within the burst window")
 )
 
 // Storage defines the interface that all storage implementations must satisfy
 type Storage interface {
 	SaveUser(ctx context.Context, user models.User) error
 	GetUser(ctx context.Context, chatID int64) (models.User, bool)
+	GetAllUsers(ctx context.Context) ([]models.User, error)
 	SaveClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error
 	GetClassBookingSchedules(ctx context.Context, chatID int64) ([]models.ClassBookingSchedule, bool)
+	// UpdateClassBookingSchedule replaces the user's schedule with the same ID,
+	// and fails if there is none
+	UpdateClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error
+	DeleteClassBookingSchedule(ctx context.Context, chatID int64, scheduleID string) error
+	// User session methods
+	SaveUserSession(ctx context.Context, session models.UserSession) error
+	GetUserSession(ctx context.Context, chatID int64) (models.UserSession, bool)
+	InvalidateUserSession(ctx context.Context, chatID int64) error
 	// Booking attempt methods
 	SaveBookingAttempt(ctx context.Context, attempt models.BookingAttempt) error
+	GetBookingAttempt(ctx context.Context, attemptID string) (models.BookingAttempt, bool)
 	GetAllPendingBookings(ctx context.Context) ([]models.BookingAttempt, error)
+	GetBookingsByStatus(ctx context.Context, status string) ([]models.BookingAttempt, error)
 	UpdateBookingStatus(ctx context.Context, attemptID string, status string, errorMsg string) error
+	UpdateBookingRetry(ctx context.Context, attemptID string, retryCount int, errorMsg string) error
 }
 
 type APIClient interface {
 	LogIn(ctx context.Context, email, password string) (*http.Cookie, error)
-	BookClass(ctx context.Context, email, password string, day, classType, hour string) error
+	LoadStoredSession(ctx context.Context, cookies []*http.Cookie) error
+	// GetCookies returns every cookie of the client's session, to restore it
+	// later with LoadStoredSession
+	GetCookies() []*http.Cookie
+	// BookClass books a class; an empty password books within the session
+	// previously restored with LoadStoredSession
+	BookClass(ctx context.Context, email, password string, day models.Day, classType, hour string) error
+	// RemoveBooking cancels the reservation of the next class on that day,
+	// with the same password semantics as BookClass
+	RemoveBooking(ctx context.Context, email, password string, day models.Day, classType, hour string) error
+	// PrepareBooking logs in, with the same password semantics as BookClass,
+	// and opens the day of the class date ahead of the booking window
+	PrepareBooking(ctx context.Context, email, password string, classDate time.Time) error
+	// TryBookClass makes one booking attempt on the prepared day and reports
+	// false without an error while the class cannot be booked yet
+	TryBookClass(ctx context.Context, classType, hour string) (bool, error)
+	// BookClasses books the classes in order within the session opened by
+	// PrepareBooking, opening the day of each class and making a single
+	// attempt per class
+	BookClasses(ctx context.Context, classes []models.ClassRequest) ([]models.ClassResult, error)
+	// JoinWaitlist joins the waiting list of the full class on the prepared
+	// day and reports false when the class offers none
+	JoinWaitlist(ctx context.Context, classType, hour string) (bool, error)
+	// ServerTime returns the site's clock
+	ServerTime(ctx context.Context) (time.Time, error)
+	// GetAvailableClasses returns the timetable of the class date, with the
+	// same password semantics as BookClass
+	GetAvailableClasses(ctx context.Context, email, password string, classDate time.Time) ([]models.ClassSchedule, error)
+}
+
+// ClientPool hands out API clients that each run in their own isolated
+// browser context, so concurrent bookings don't share navigation or cookies
+type ClientPool interface {
+	Acquire(ctx context.Context) (APIClient, error)
+	Release(client APIClient)
+	WarmUp(ctx context.Context, count int) error
+}
+
+// Notifier sends messages to users outside of the replies to their commands,
+// e.g. when a background booking changes state
+type Notifier interface {
+	Notify(ctx context.Context, chatID int64, message string) error
 }
 
 type Manager struct {
 	storage          Storage
-	clientAPI        APIClient
-	encryptionKey    string
+	clientPool       ClientPool
+	keyring          *utils.Keyring
 	bookingScheduler *BookingScheduler
+	classCatalogue   *ClassCatalogue
+	timetables       *timetableCache
 	logger           *slog.Logger
 }
 
 // NewManager creates a new manager with injected dependencies
 func NewManager(
 	storage Storage,
-	clientAPI APIClient,
-	encryptionKey string,
+	clientPool ClientPool,
+	keyring *utils.Keyring,
 	bookingScheduler *BookingScheduler,
+	classCatalogue *ClassCatalogue,
 	logger *slog.Logger,
 ) *Manager {
 	return &Manager{
 		storage:          storage,
-		clientAPI:        clientAPI,
-		encryptionKey:    encryptionKey,
+		clientPool:       clientPool,
+		keyring:          keyring,
 		bookingScheduler: bookingScheduler,
+		classCatalogue:   classCatalogue,
+		timetables:       newTimetableCache(timetableCacheTTL),
 		logger:           logger,
 	}
 }
 
-// StartBookingScheduler starts the Saturday cronjob
+// StartBookingScheduler starts the booking cronjob
 func (m *Manager) StartBookingScheduler() error {
 	return m.bookingScheduler.Start()
 }
@@ -88,47 +151,73 @@ func (m *Manager) GetUser(ctx context.Context, chatID int64) (models.User, bool)
 }
 
 func (m *Manager) LogInAndSave(ctx context.Context, chatID int64, email, password string) error {
-	// Test login with WODBuster first to validate credentials and get session cookie
-	sessionCookie, err := m.testWODBusterLogin(ctx, email, password)
+	// Test login with WODBuster first to validate credentials and get the session
+	sessionCookie, cookies, err := m.testWODBusterLogin(ctx, email, password)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrInvalidWODBusterLogin, err)
 	}
 
 	// Encrypt the password before storing
-	encryptedPassword, err := utils.EncryptPassword(password, m.encryptionKey)
+	encryptedPassword, err := m.keyring.Encrypt(password)
 	if err != nil {
 		m.logger.Error("Failed to encrypt password", "error", err, "chat_id", chatID)
 		return err
 	}
 
-	user := models.User{
-		ChatID:                 chatID,
-		IsAuthenticated:        true,
-		Email:                  email,
-		Password:               encryptedPassword,
-		ClassBookingSchedules:  []models.ClassBookingSchedule{},
-		WODBusterSessionCookie: sessionCookie,
-		SessionExpiresAt:       sessionCookie.Expires,
-		SessionValid:           true,
-		LastLoginTime:          time.Now(),
-		CreatedAt:              time.Now(),
-		UpdatedAt:              time.Now(),
+	// Logging in again only renews the credentials and the session, the
+	// user's rules and limits are kept
+	now := time.Now()
+	user, exists := m.storage.GetUser(ctx, chatID)
+	if !exists {
+		user = models.User{
+			ChatID:                chatID,
+			ClassBookingSchedules: []models.ClassBookingSchedule{},
+			CreatedAt:             now,
+		}
+	}
+	user.IsAuthenticated = true
+	user.Email = email
+	user.Password = encryptedPassword
+	user.WODBusterSessionCookie = sessionCookie
+	user.SessionExpiresAt = sessionCookie.Expires
+	user.SessionValid = true
+	user.LastLoginTime = now
+	user.UpdatedAt = now
+
+	if err := m.storage.SaveUser(ctx, user); err != nil {
+		return err
+	}
+
+	// The whole cookie jar is kept so bookings can restore the session
+	session := models.NewUserSession(chatID, cookies, time.Now())
+	if err := m.storage.SaveUserSession(ctx, session); err != nil {
+		m.logger.Warn("Failed to save user session", "error", err, "chat_id", chatID)
 	}
 
 	m.logger.Info("Successfully validated login and saved user", "chat_id", chatID, "email", email)
-	return m.storage.SaveUser(ctx, user)
+	return nil
 }
 
-// testWODBusterLogin validates credentials using the injected API client
-func (m *Manager) testWODBusterLogin(ctx context.Context, email, password string) (*http.Cookie, error) {
-	// Use the injected client to validate credentials and get session cookie
-	sessionCookie, err := m.clientAPI.LogIn(ctx, email, password)
+// testWODBusterLogin validates credentials on a client of its own, so logins
+// of different chats running at once don't share a session. It returns the
+// session cookie and every cookie of the session.
+func (m *Manager) testWODBusterLogin(ctx context.Context, email, password string) (*http.Cookie, []*http.Cookie, error) {
+	client, err := m.clientPool.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("login validation failed: %w", err)
+		return nil, nil, fmt.Errorf("failed to acquire WODBuster client: %w", err)
+	}
+	defer m.clientPool.Release(client)
+
+	sessionCookie, err := client.LogIn(ctx, email, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("login validation failed: %w", err)
+	}
+	if sessionCookie == nil {
+		return nil, nil, fmt.Errorf("login validation failed: no session cookie after login")
 	}
 
 	m.logger.Info("Login validation successful", "email", email)
-	return sessionCookie, nil
+	return sessionCookie, client.GetCookies(), nil
 }
 
 func (m *Manager) GetDecryptedPassword(ctx context.Context, chatID int64) (string, error) {
@@ -137,75 +226,286 @@ func (m *Manager) GetDecryptedPassword(ctx context.Context, chatID int64) (strin
 		return "", ErrUserNotFound
 	}
 
-	return utils.DecryptPassword(user.Password, m.encryptionKey)
+	return m.keyring.Decrypt(user.Password)
+}
+
+// ResolveClassType returns the class type, exactly as the gym's timetable
+// shows it, that the user's input stands for. A stale catalogue is first
+// refreshed from the timetable through the user's account.
+func (m *Manager) ResolveClassType(ctx context.Context, chatID int64, input string) (string, error) {
+	if m.classCatalogue.Stale(time.Now()) {
+		m.refreshClassCatalogue(ctx, chatID)
+	}
+	return m.classCatalogue.Resolve(input)
+}
+
+// ClassTypes returns the class types of the gym's catalogue
+func (m *Manager) ClassTypes() []string {
+	return m.classCatalogue.ClassTypes()
+}
+
+// refreshClassCatalogue adds the class types of this week's timetable to the
+// catalogue, reading it through the user's session on a client of its own.
+// Failures are only logged and retried later: the catalogue still resolves
+// the types it already knows.
+func (m *Manager) refreshClassCatalogue(ctx context.Context, chatID int64) {
+	classes, err := m.GetTimetable(ctx, chatID, "", false)
+	if err != nil {
+		m.classCatalogue.RefreshFailed(time.Now())
+		m.logger.Warn("Failed to refresh class catalogue", "error", err, "chat_id", chatID)
+		return
+	}
+
+	// The timetable already added its class types, this marks the refresh
+	m.classCatalogue.Learn(classes, time.Now())
+	m.logger.Info("Refreshed class catalogue",
+		"gym", m.classCatalogue.Gym(),
+		"classes", len(classes),
+		"class_types", len(m.classCatalogue.ClassTypes()))
 }
 
 func (m *Manager) ScheduleBookClass(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
-	// Save the class booking schedule to user's profile
+	// The rule must fit within the user's limits along with their other rules
+	if err := m.bookingScheduler.CheckScheduleLimits(ctx, chatID, class); err != nil {
+		return err
+	}
+
+	// Save the weekly booking rule to user's profile
 	err := m.storage.SaveClassBookingSchedule(ctx, chatID, class)
 	if err != nil {
 		return err
 	}
 
-	// Create a booking attempt for the Saturday cronjob
-	bookingAttempt := models.BookingAttempt{
-		ID:          fmt.Sprintf("%d-%s-%s-%s-%d", chatID, class.Day, class.Hour, class.ClassType, time.Now().Unix()),
-		ChatID:      chatID,
-		Day:         class.Day,
-		Hour:        class.Hour,
-		ClassType:   class.ClassType,
-		Status:      "pending",
-		AttemptTime: calculateNextSaturday(), // When the booking should be attempted
-		RetryCount:  0,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	// Create the pending attempt for the upcoming booking run; later weeks are
+	// expanded by the scheduler before each run
+	return m.bookingScheduler.ScheduleNextAttempt(ctx, chatID, class)
+}
+
+// GetBookingLimits returns the limits the user's classes are booked within
+func (m *Manager) GetBookingLimits(ctx context.Context, chatID int64) (models.BookingLimits, error) {
+	user, exists := m.storage.GetUser(ctx, chatID)
+	if !exists {
+		return models.BookingLimits{}, ErrUserNotFound
+	}
+	return m.bookingScheduler.BookingLimits(user), nil
+}
+
+// SetBookingLimits sets the user's own limits, or brings back the defaults
+// when limits is nil. Rules already added are kept; the classes that break the
+// new limits are rejected when their attempts are created.
+func (m *Manager) SetBookingLimits(ctx context.Context, chatID int64, limits *models.BookingLimits) error {
+	user, exists := m.storage.GetUser(ctx, chatID)
+	if !exists {
+		return ErrUserNotFound
+	}
+
+	user.BookingLimits = limits
+	user.UpdatedAt = time.Now()
+	if err := m.storage.SaveUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to save booking limits: %w", err)
 	}
 
-	return m.storage.SaveBookingAttempt(ctx, bookingAttempt)
+	m.logger.Info("Set booking limits", "chat_id", chatID, "default", limits == nil)
+	return nil
 }
 
-// calculateNextSaturday calculates when the next Saturday 12:00 will be
-func calculateNextSaturday() time.Time {
-	now := time.Now()
+// SkipScheduledClass adds a "skip week" exception to a booking rule so the class
+// on classDate (YYYY-MM-DD) is not booked
+func (m *Manager) SkipScheduledClass(ctx context.Context, chatID int64, scheduleID, classDate string) error {
+	schedules, exists := m.storage.GetClassBookingSchedules(ctx, chatID)
+	if !exists {
+		return ErrUserNotFound
+	}
 
-	// Find next Saturday
-	daysUntilSaturday := (int(time.Saturday) - int(now.Weekday()) + 7) % 7
-	if daysUntilSaturday == 0 && now.Hour() >= 12 {
-		daysUntilSaturday = 7 // If it's Saturday and past 12:00, go to next Saturday
+	for _, schedule := range schedules {
+		if schedule.ID != scheduleID {
+			continue
+		}
+
+		if !slices.Contains(schedule.SkipDates, classDate) {
+			schedule.SkipDates = append(schedule.SkipDates, classDate)
+			if err := m.storage.SaveClassBookingSchedule(ctx, chatID, schedule); err != nil {
+				return err
+			}
+		}
+
+		// The attempt may already have been expanded for that week
+		attemptID := bookingAttemptID(chatID, scheduleID, classDate)
+		if attempt, ok := m.storage.GetBookingAttempt(ctx, attemptID); ok && attempt.Status == "pending" {
+			return m.storage.UpdateBookingStatus(ctx, attemptID, "skipped", "")
+		}
+		return nil
 	}
 
-	nextSaturday := now.AddDate(0, 0, daysUntilSaturday)
+	return ErrScheduleNotFound
+}
 
-	// Set to 12:00 PM (booking time)
-	return time.Date(nextSaturday.Year(), nextSaturday.Month(), nextSaturday.Day(), 12, 0, 0, 0, time.UTC)
+// DeleteSchedule removes the user's booking rule and cancels its attempts,
+// leaving the classes already booked on WODBuster as they are
+func (m *Manager) DeleteSchedule(ctx context.Context, chatID int64, scheduleID string) error {
+	schedule, err := m.findSchedule(ctx, chatID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	if err := m.storage.DeleteClassBookingSchedule(ctx, chatID, schedule.ID); err != nil {
+		return err
+	}
+	if err := m.bookingScheduler.CancelScheduleAttempts(ctx, chatID, schedule.ID); err != nil {
+		return err
+	}
+
+	m.logger.Info("Deleted booking rule", "chat_id", chatID, "schedule_id", schedule.ID)
+	return nil
+}
+
+// EditScheduleDates sets the date range (YYYY-MM-DD) of the user's booking
+// rule, empty dates leaving it open, as long as it keeps the user within their
+// limits
+func (m *Manager) EditScheduleDates(ctx context.Context, chatID int64, scheduleID, startDate, endDate string) error {
+	schedule, err := m.findSchedule(ctx, chatID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	schedule.StartDate, schedule.EndDate = startDate, endDate
+	if err := m.bookingScheduler.CheckScheduleLimits(ctx, chatID, schedule); err != nil {
+		return err
+	}
+	return m.updateSchedule(ctx, chatID, schedule)
 }
 
-// GetActiveBookings returns currently active booking attempts
-func (m *Manager) GetActiveBookings() map[int64]*BookingContext {
-	return m.bookingScheduler.GetActiveBookings()
+// PauseSchedules pauses the user's booking rule, or all of them when scheduleID
+// is empty, until the class date until (YYYY-MM-DD) or until resumed when it
+// is empty. It returns how many rules were paused, also when pausing the
+// others failed.
+func (m *Manager) PauseSchedules(ctx context.Context, chatID int64, scheduleID, until string) (int, error) {
+	return m.updateSchedules(ctx, chatID, scheduleID, func(schedule *models.ClassBookingSchedule) {
+		schedule.Paused = true
+		schedule.PausedUntil = until
+	})
 }
 
-// CancelBooking cancels an active booking attempt
-func (m *Manager) CancelBooking(chatID int64) bool {
-	return m.bookingScheduler.CancelBooking(chatID)
+// ResumeSchedules resumes the user's paused booking rule, or all of them when
+// scheduleID is empty. It returns how many rules were resumed, also when
+// resuming the others failed.
+func (m *Manager) ResumeSchedules(ctx context.Context, chatID int64, scheduleID string) (int, error) {
+	return m.updateSchedules(ctx, chatID, scheduleID, func(schedule *models.ClassBookingSchedule) {
+		schedule.Paused = false
+		schedule.PausedUntil = ""
+	})
 }
 
-// TestUserSession validates if user has a working session
-func (m *Manager) TestUserSession(ctx context.Context, chatID int64) error {
-	user, exists := m.storage.GetUser(ctx, chatID)
+// updateSchedules applies the change to the user's booking rule, or all of them
+// when scheduleID is empty, and returns how many rules it changed, also when
+// it fails part way through
+func (m *Manager) updateSchedules(ctx context.Context, chatID int64, scheduleID string, change func(*models.ClassBookingSchedule)) (int, error) {
+	if scheduleID != "" {
+		schedule, err := m.findSchedule(ctx, chatID, scheduleID)
+		if err != nil {
+			return 0, err
+		}
+		change(&schedule)
+		if err := m.updateSchedule(ctx, chatID, schedule); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	schedules, exists := m.storage.GetClassBookingSchedules(ctx, chatID)
 	if !exists {
-		return ErrUserNotFound
+		return 0, ErrUserNotFound
 	}
+	for i, schedule := range schedules {
+		change(&schedule)
+		if err := m.updateSchedule(ctx, chatID, schedule); err != nil {
+			return i, err
+		}
+	}
+	return len(schedules), nil
+}
 
-	if !user.HasValidSession() {
-		return fmt.Errorf("user session is invalid or expired")
+// updateSchedule saves the edited booking rule and brings its attempts in line
+// with it
+func (m *Manager) updateSchedule(ctx context.Context, chatID int64, schedule models.ClassBookingSchedule) error {
+	if err := m.storage.UpdateClassBookingSchedule(ctx, chatID, schedule); err != nil {
+		return err
+	}
+	if err := m.bookingScheduler.SyncScheduleAttempts(ctx, chatID, schedule); err != nil {
+		return err
 	}
 
-	// Could optionally test the session by creating a temporary client and checking
-	// if the session cookie still works, but for now just check if it exists and hasn't expired
+	m.logger.Info("Updated booking rule",
+		"chat_id", chatID,
+		"schedule_id", schedule.ID,
+		"paused", schedule.Paused,
+		"paused_until", schedule.PausedUntil)
 	return nil
 }
 
+// findSchedule returns the user's booking rule with the ID, ignoring case
+func (m *Manager) findSchedule(ctx context.Context, chatID int64, scheduleID string) (models.ClassBookingSchedule, error) {
+	schedules, exists := m.storage.GetClassBookingSchedules(ctx, chatID)
+	if !exists {
+		return models.ClassBookingSchedule{}, ErrUserNotFound
+	}
+
+	for _, schedule := range schedules {
+		if strings.EqualFold(schedule.ID, scheduleID) {
+			return schedule, nil
+		}
+	}
+	return models.ClassBookingSchedule{}, ErrScheduleNotFound
+}
+
+// RemoveClass removes the weekly booking rule matching the class, if any, and
+// cancels the user's reservation of the class on WODBuster. It reports whether
+// a rule was removed, which also happens when the cancellation then fails.
+func (m *Manager) RemoveClass(ctx context.Context, chatID int64, day models.Day, hour, classType string) (bool, error) {
+	schedules, exists := m.storage.GetClassBookingSchedules(ctx, chatID)
+	if !exists {
+		return false, ErrUserNotFound
+	}
+
+	scheduleRemoved := false
+	for _, schedule := range schedules {
+		if schedule.Day != day || schedule.Hour != hour || !strings.EqualFold(schedule.ClassType, classType) {
+			continue
+		}
+
+		if err := m.storage.DeleteClassBookingSchedule(ctx, chatID, schedule.ID); err != nil {
+			return false, err
+		}
+		if err := m.bookingScheduler.CancelScheduleAttempts(ctx, chatID, schedule.ID); err != nil {
+			return true, err
+		}
+		scheduleRemoved = true
+	}
+
+	if err := m.bookingScheduler.RemoveBookedClass(ctx, chatID, day, classType, hour); err != nil {
+		return scheduleRemoved, fmt.Errorf("%w: %w", ErrCancelBookingFailed, err)
+	}
+
+	m.logger.Info("Removed class", "chat_id", chatID, "day", day, "hour", hour, "class_type", classType)
+	return scheduleRemoved, nil
+}
+
+// GetActiveBookings returns the user's active booking attempts
+func (m *Manager) GetActiveBookings(chatID int64) []BookingContext {
+	return m.bookingScheduler.GetActiveBookings(chatID)
+}
+
+// CancelBooking cancels one of the user's active booking attempts
+func (m *Manager) CancelBooking(chatID int64, attemptID string) bool {
+	return m.bookingScheduler.CancelBooking(chatID, attemptID)
+}
+
+// TestUserSession probes the user's WODBuster session, refreshing a dead one
+// when the stored password allows it
+func (m *Manager) TestUserSession(ctx context.Context, chatID int64) (SessionStatus, error) {
+	return m.bookingScheduler.ProbeSession(ctx, chatID)
+}
+
 // GetScheduleInfo returns information about the next scheduled booking run
 func (m *Manager) GetScheduleInfo() string {
 	return m.bookingScheduler.GetScheduleInfo()
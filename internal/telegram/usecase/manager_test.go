@@ -0,0 +1,379 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_RemoveClass(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+
+	schedule := models.ClassBookingSchedule{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod"}
+	attempt := models.BookingAttempt{ID: "42-Monday-07:00-Wod-2025-08-18", ChatID: chatID, ScheduleID: schedule.ID, Status: "pending"}
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name                string
+		schedules           []models.ClassBookingSchedule
+		cancelErr           error
+		wantScheduleRemoved bool
+		wantErr             error
+	}{
+		{
+			name:                "cancels booking and removes schedule",
+			schedules:           []models.ClassBookingSchedule{schedule},
+			wantScheduleRemoved: true,
+		},
+		{
+			name:      "cancels booking without schedule",
+			schedules: []models.ClassBookingSchedule{},
+		},
+		{
+			name:                "removes schedule when cancellation fails",
+			schedules:           []models.ClassBookingSchedule{schedule},
+			cancelErr:           errors.New("class is not booked"),
+			wantScheduleRemoved: true,
+			wantErr:             ErrCancelBookingFailed,
+		},
+		{
+			name:      "nothing to remove",
+			schedules: []models.ClassBookingSchedule{},
+			cancelErr: errors.New("class is not booked"),
+			wantErr:   ErrCancelBookingFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com", ClassBookingSchedules: tt.schedules}))
+			require.NoError(t, store.SaveBookingAttempt(ctx, attempt))
+
+			client := NewMockAPIClient(t)
+			client.EXPECT().RemoveBooking(mock.Anything, "a@b.com", "secret", models.DayMonday, "Wod", "07:00").Return(tt.cancelErr)
+
+			creds := NewMockCredentialProvider(t)
+			creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+
+			pool := NewMockClientPool(t)
+			pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+			pool.EXPECT().Release(client).Return()
+
+			manager := NewManager(store, nil, nil, NewBookingScheduler(store, pool, creds, newTestWindow(t), logger), nil, logger)
+
+			scheduleRemoved, err := manager.RemoveClass(ctx, chatID, models.DayMonday, "07:00", "Wod")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantScheduleRemoved, scheduleRemoved)
+
+			schedules, _ := store.GetClassBookingSchedules(ctx, chatID)
+			assert.Empty(t, schedules)
+
+			stored, _ := store.GetBookingAttempt(ctx, attempt.ID)
+			if tt.wantScheduleRemoved {
+				assert.Equal(t, "cancelled", stored.Status, "a removed rule must not be booked by the next run")
+			} else {
+				assert.Equal(t, "pending", stored.Status)
+			}
+		})
+	}
+}
+
+func TestManager_ManageSchedules(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{
+		ChatID: chatID,
+		ClassBookingSchedules: []models.ClassBookingSchedule{
+			{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod"},
+			{ID: "Friday-07:00-Wod", Day: "Friday", Hour: "07:00", ClassType: "Wod"},
+		},
+	}))
+	monday := models.BookingAttempt{ID: "42-Monday-07:00-Wod-2099-01-05", ChatID: chatID, ScheduleID: "Monday-07:00-Wod", ClassDate: "2099-01-05", Status: "pending"}
+	friday := models.BookingAttempt{ID: "42-Friday-07:00-Wod-2099-01-09", ChatID: chatID, ScheduleID: "Friday-07:00-Wod", ClassDate: "2099-01-09", Status: "pending"}
+	require.NoError(t, store.SaveBookingAttempt(ctx, monday))
+	require.NoError(t, store.SaveBookingAttempt(ctx, friday))
+
+	manager := NewManager(store, nil, nil, NewBookingScheduler(store, nil, nil, newTestWindow(t), logger), nil, logger)
+	assertStatus := func(attempt models.BookingAttempt, want string) {
+		t.Helper()
+		stored, exists := store.GetBookingAttempt(ctx, attempt.ID)
+		require.True(t, exists)
+		assert.Equal(t, want, stored.Status, attempt.ID)
+	}
+
+	// Classes after the end of the pause are still booked
+	paused, err := manager.PauseSchedules(ctx, chatID, "", "2099-01-06")
+	require.NoError(t, err)
+	assert.Equal(t, 2, paused)
+	assertStatus(monday, "paused")
+	assertStatus(friday, "pending")
+
+	resumed, err := manager.ResumeSchedules(ctx, chatID, "monday-07:00-wod")
+	require.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+	assertStatus(monday, "pending")
+
+	_, err = manager.ResumeSchedules(ctx, chatID, "Sunday-07:00-Wod")
+	assert.ErrorIs(t, err, ErrScheduleNotFound)
+
+	require.NoError(t, manager.EditScheduleDates(ctx, chatID, "Friday-07:00-Wod", "", "2099-01-08"))
+	assertStatus(friday, "skipped")
+	require.NoError(t, manager.EditScheduleDates(ctx, chatID, "Friday-07:00-Wod", "", ""))
+	assertStatus(friday, "pending")
+
+	require.NoError(t, manager.DeleteSchedule(ctx, chatID, "Friday-07:00-Wod"))
+	assertStatus(friday, "cancelled")
+	schedules, _ := store.GetClassBookingSchedules(ctx, chatID)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, "Monday-07:00-Wod", schedules[0].ID)
+	assert.False(t, schedules[0].Paused)
+}
+
+// failingScheduleStorage fails to update the rule with the given ID
+type failingScheduleStorage struct {
+	*storage.MemoryStorage
+	failID string
+}
+
+func (s failingScheduleStorage) UpdateClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
+	if class.ID == s.failID {
+		return errors.New("storage unavailable")
+	}
+	return s.MemoryStorage.UpdateClassBookingSchedule(ctx, chatID, class)
+}
+
+func TestManager_PauseSchedulesPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	memory := storage.NewMemoryStorage()
+	require.NoError(t, memory.SaveUser(ctx, models.User{
+		ChatID: chatID,
+		ClassBookingSchedules: []models.ClassBookingSchedule{
+			{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod"},
+			{ID: "Friday-07:00-Wod", Day: "Friday", Hour: "07:00", ClassType: "Wod"},
+		},
+	}))
+	store := failingScheduleStorage{MemoryStorage: memory, failID: "Friday-07:00-Wod"}
+	manager := NewManager(store, nil, nil, NewBookingScheduler(store, nil, nil, newTestWindow(t), logger), nil, logger)
+
+	paused, err := manager.PauseSchedules(ctx, chatID, "", "")
+	require.Error(t, err)
+	assert.Equal(t, 1, paused)
+
+	schedules, _ := memory.GetClassBookingSchedules(ctx, chatID)
+	require.Len(t, schedules, 2)
+	assert.True(t, schedules[0].Paused)
+	assert.False(t, schedules[1].Paused)
+}
+
+func TestManager_ResolveClassType(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+
+	// The stale catalogue is refreshed once from this week's timetable, with
+	// class types that only run on some days
+	client := NewMockAPIClient(t)
+	client.EXPECT().GetAvailableClasses(mock.Anything, "a@b.com", "secret", mock.Anything).
+		RunAndReturn(func(_ context.Context, _, _ string, classDate time.Time) ([]models.ClassSchedule, error) {
+			if classDate.Weekday() != time.Sunday {
+				return nil, nil
+			}
+			return []models.ClassSchedule{{Hour: "09:00", ClassType: "Yoga Flow"}}, nil
+		}).Times(7)
+
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil).Once()
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return().Once()
+
+	catalogue := NewClassCatalogue("https://box.wodbuster.com", []string{"Wod"}, nil, time.Hour)
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger)
+	manager := NewManager(store, pool, nil, scheduler, catalogue, logger)
+
+	classType, err := manager.ResolveClassType(ctx, chatID, "yoga flow")
+	require.NoError(t, err)
+	assert.Equal(t, "Yoga Flow", classType)
+
+	classType, err = manager.ResolveClassType(ctx, chatID, "WOD")
+	require.NoError(t, err)
+	assert.Equal(t, "Wod", classType)
+
+	_, err = manager.ResolveClassType(ctx, chatID, "pilates")
+	assert.ErrorIs(t, err, ErrInvalidClassType)
+	assert.Equal(t, []string{"Wod", "Yoga Flow"}, manager.ClassTypes())
+}
+
+func TestManager_ResolveClassTypeRefreshFailure(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+
+	// A failed refresh is not retried by the next commands
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(nil, errors.New("browser unavailable")).Once()
+
+	catalogue := NewClassCatalogue("https://box.wodbuster.com", []string{"Wod"}, nil, time.Hour)
+	scheduler := NewBookingScheduler(store, pool, nil, newTestWindow(t), logger)
+	manager := NewManager(store, pool, nil, scheduler, catalogue, logger)
+
+	for range 2 {
+		classType, err := manager.ResolveClassType(ctx, chatID, "wod")
+		require.NoError(t, err)
+		assert.Equal(t, "Wod", classType)
+	}
+}
+
+func TestManager_LogInAndSaveStoresSession(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	keyring, err := utils.NewKeyring("v1", map[string]string{"v1": "0123456789abcdef0123456789abcdef"})
+	require.NoError(t, err)
+
+	session := &http.Cookie{Name: ".WBAuth", Value: "session", Expires: time.Now().Add(time.Hour)}
+	client := NewMockAPIClient(t)
+	client.EXPECT().LogIn(mock.Anything, "a@b.com", "secret").Return(session, nil)
+	client.EXPECT().GetCookies().Return([]*http.Cookie{session, {Name: "ASP.NET_SessionId", Value: "aspnet"}})
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return().Once()
+
+	store := storage.NewMemoryStorage()
+	manager := NewManager(store, pool, keyring, nil, nil, logger)
+	require.NoError(t, manager.LogInAndSave(ctx, chatID, "a@b.com", "secret"))
+
+	// The whole cookie jar is stored, not only the session cookie
+	stored, ok := store.GetUserSession(ctx, chatID)
+	require.True(t, ok)
+	assert.True(t, stored.Valid(time.Now()))
+	assert.Equal(t, []*http.Cookie{
+		{Name: ".WBAuth", Value: "session", Expires: session.Expires},
+		{Name: "ASP.NET_SessionId", Value: "aspnet"},
+	}, stored.HTTPCookies())
+}
+
+func TestManager_LogInAndSaveConcurrentLogins(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	keyring, err := utils.NewKeyring("v1", map[string]string{"v1": "0123456789abcdef0123456789abcdef"})
+	require.NoError(t, err)
+
+	// Each login gets a client of its own, with the session of its account
+	logins := map[int64]string{41: "ana@b.com", 42: "bob@b.com"}
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).RunAndReturn(func(context.Context) (APIClient, error) {
+		client := NewMockAPIClient(t)
+		var cookie *http.Cookie
+		client.EXPECT().LogIn(mock.Anything, mock.Anything, "secret").
+			RunAndReturn(func(_ context.Context, email, _ string) (*http.Cookie, error) {
+				cookie = &http.Cookie{Name: ".WBAuth", Value: email, Expires: time.Now().Add(time.Hour)}
+				return cookie, nil
+			})
+		client.EXPECT().GetCookies().RunAndReturn(func() []*http.Cookie {
+			return []*http.Cookie{cookie}
+		})
+		return client, nil
+	}).Times(len(logins))
+	pool.EXPECT().Release(mock.Anything).Return().Times(len(logins))
+
+	store := storage.NewMemoryStorage()
+	manager := NewManager(store, pool, keyring, nil, nil, logger)
+
+	var wg sync.WaitGroup
+	for chatID, email := range logins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, manager.LogInAndSave(ctx, chatID, email, "secret"))
+		}()
+	}
+	wg.Wait()
+
+	for chatID, email := range logins {
+		stored, ok := store.GetUserSession(ctx, chatID)
+		require.True(t, ok)
+		cookies := stored.HTTPCookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, email, cookies[0].Value, "chat %d must store its own session", chatID)
+	}
+}
+
+func TestManager_LogInAndSaveKeepsRulesAndLimits(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	keyring, err := utils.NewKeyring("v1", map[string]string{"v1": "0123456789abcdef0123456789abcdef"})
+	require.NoError(t, err)
+
+	createdAt := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)
+	schedules := []models.ClassBookingSchedule{{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod"}}
+	limits := &models.BookingLimits{MaxPerWeek: 3, MaxPerDay: 1}
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{
+		ChatID:                chatID,
+		Email:                 "old@b.com",
+		Password:              "expired",
+		ClassBookingSchedules: schedules,
+		BookingLimits:         limits,
+		CreatedAt:             createdAt,
+	}))
+
+	session := &http.Cookie{Name: ".WBAuth", Value: "session", Expires: time.Now().Add(time.Hour)}
+	client := NewMockAPIClient(t)
+	client.EXPECT().LogIn(mock.Anything, "a@b.com", "secret").Return(session, nil)
+	client.EXPECT().GetCookies().Return([]*http.Cookie{session})
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return().Once()
+
+	manager := NewManager(store, pool, keyring, nil, nil, logger)
+	require.NoError(t, manager.LogInAndSave(ctx, chatID, "a@b.com", "secret"))
+
+	user, exists := store.GetUser(ctx, chatID)
+	require.True(t, exists)
+	assert.True(t, user.IsAuthenticated)
+	assert.Equal(t, "a@b.com", user.Email)
+	password, err := keyring.Decrypt(user.Password)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", password)
+	assert.Equal(t, schedules, user.ClassBookingSchedules, "logging in again must keep the rules")
+	assert.Equal(t, limits, user.BookingLimits, "logging in again must keep the limits")
+	assert.Equal(t, createdAt, user.CreatedAt)
+}
@@ -7,18 +7,19 @@ package usecase
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
-// NewMockStorage creates a new instance of MockStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockCredentialProvider creates a new instance of MockCredentialProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockStorage(t interface {
+func NewMockCredentialProvider(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockStorage {
-	mock := &MockStorage{}
+}) *MockCredentialProvider {
+	mock := &MockCredentialProvider{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -26,100 +27,129 @@ func NewMockStorage(t interface {
 	return mock
 }
 
-// MockStorage is an autogenerated mock type for the Storage type
-type MockStorage struct {
+// MockCredentialProvider is an autogenerated mock type for the CredentialProvider type
+type MockCredentialProvider struct {
 	mock.Mock
 }
 
-type MockStorage_Expecter struct {
+type MockCredentialProvider_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
-	return &MockStorage_Expecter{mock: &_m.Mock}
+func (_m *MockCredentialProvider) EXPECT() *MockCredentialProvider_Expecter {
+	return &MockCredentialProvider_Expecter{mock: &_m.Mock}
 }
 
-// GetAllPendingBookings provides a mock function for the type MockStorage
-func (_mock *MockStorage) GetAllPendingBookings(ctx context.Context) ([]models.BookingAttempt, error) {
-	ret := _mock.Called(ctx)
+// Credentials provides a mock function for the type MockCredentialProvider
+func (_mock *MockCredentialProvider) Credentials(ctx context.Context, user models.User) (Credentials, error) {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllPendingBookings")
+		panic("no return value specified for Credentials")
 	}
 
-	var r0 []models.BookingAttempt
+	var r0 Credentials
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.BookingAttempt, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.User) (Credentials, error)); ok {
+		return returnFunc(ctx, user)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.BookingAttempt); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.User) Credentials); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.BookingAttempt)
-		}
+		r0 = ret.Get(0).(Credentials)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.User) error); ok {
+		r1 = returnFunc(ctx, user)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStorage_GetAllPendingBookings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllPendingBookings'
-type MockStorage_GetAllPendingBookings_Call struct {
+// MockCredentialProvider_Credentials_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Credentials'
+type MockCredentialProvider_Credentials_Call struct {
 	*mock.Call
 }
 
-// GetAllPendingBookings is a helper method to define mock.On call
+// Credentials is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockStorage_Expecter) GetAllPendingBookings(ctx interface{}) *MockStorage_GetAllPendingBookings_Call {
-	return &MockStorage_GetAllPendingBookings_Call{Call: _e.mock.On("GetAllPendingBookings", ctx)}
+//   - user models.User
+func (_e *MockCredentialProvider_Expecter) Credentials(ctx interface{}, user interface{}) *MockCredentialProvider_Credentials_Call {
+	return &MockCredentialProvider_Credentials_Call{Call: _e.mock.On("Credentials", ctx, user)}
 }
 
-func (_c *MockStorage_GetAllPendingBookings_Call) Run(run func(ctx context.Context)) *MockStorage_GetAllPendingBookings_Call {
+func (_c *MockCredentialProvider_Credentials_Call) Run(run func(ctx context.Context, user models.User)) *MockCredentialProvider_Credentials_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 models.User
+		if args[1] != nil {
+			arg1 = args[1].(models.User)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_GetAllPendingBookings_Call) Return(bookingAttempts []models.BookingAttempt, err error) *MockStorage_GetAllPendingBookings_Call {
-	_c.Call.Return(bookingAttempts, err)
+func (_c *MockCredentialProvider_Credentials_Call) Return(credentials Credentials, err error) *MockCredentialProvider_Credentials_Call {
+	_c.Call.Return(credentials, err)
 	return _c
 }
 
-func (_c *MockStorage_GetAllPendingBookings_Call) RunAndReturn(run func(ctx context.Context) ([]models.BookingAttempt, error)) *MockStorage_GetAllPendingBookings_Call {
+func (_c *MockCredentialProvider_Credentials_Call) RunAndReturn(run func(ctx context.Context, user models.User) (Credentials, error)) *MockCredentialProvider_Credentials_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetClassBookingSchedules provides a mock function for the type MockStorage
-func (_mock *MockStorage) GetClassBookingSchedules(ctx context.Context, chatID int64) ([]models.ClassBookingSchedule, bool) {
+// NewMockSessionStore creates a new instance of MockSessionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionStore {
+	mock := &MockSessionStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSessionStore is an autogenerated mock type for the SessionStore type
+type MockSessionStore struct {
+	mock.Mock
+}
+
+type MockSessionStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionStore) EXPECT() *MockSessionStore_Expecter {
+	return &MockSessionStore_Expecter{mock: &_m.Mock}
+}
+
+// GetUserSession provides a mock function for the type MockSessionStore
+func (_mock *MockSessionStore) GetUserSession(ctx context.Context, chatID int64) (models.UserSession, bool) {
 	ret := _mock.Called(ctx, chatID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetClassBookingSchedules")
+		panic("no return value specified for GetUserSession")
 	}
 
-	var r0 []models.ClassBookingSchedule
+	var r0 models.UserSession
 	var r1 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]models.ClassBookingSchedule, bool)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (models.UserSession, bool)); ok {
 		return returnFunc(ctx, chatID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []models.ClassBookingSchedule); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) models.UserSession); ok {
 		r0 = returnFunc(ctx, chatID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.ClassBookingSchedule)
-		}
+		r0 = ret.Get(0).(models.UserSession)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
 		r1 = returnFunc(ctx, chatID)
@@ -129,19 +159,19 @@ func (_mock *MockStorage) GetClassBookingSchedules(ctx context.Context, chatID i
 	return r0, r1
 }
 
-// MockStorage_GetClassBookingSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClassBookingSchedules'
-type MockStorage_GetClassBookingSchedules_Call struct {
+// MockSessionStore_GetUserSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSession'
+type MockSessionStore_GetUserSession_Call struct {
 	*mock.Call
 }
 
-// GetClassBookingSchedules is a helper method to define mock.On call
+// GetUserSession is a helper method to define mock.On call
 //   - ctx context.Context
 //   - chatID int64
-func (_e *MockStorage_Expecter) GetClassBookingSchedules(ctx interface{}, chatID interface{}) *MockStorage_GetClassBookingSchedules_Call {
-	return &MockStorage_GetClassBookingSchedules_Call{Call: _e.mock.On("GetClassBookingSchedules", ctx, chatID)}
+func (_e *MockSessionStore_Expecter) GetUserSession(ctx interface{}, chatID interface{}) *MockSessionStore_GetUserSession_Call {
+	return &MockSessionStore_GetUserSession_Call{Call: _e.mock.On("GetUserSession", ctx, chatID)}
 }
 
-func (_c *MockStorage_GetClassBookingSchedules_Call) Run(run func(ctx context.Context, chatID int64)) *MockStorage_GetClassBookingSchedules_Call {
+func (_c *MockSessionStore_GetUserSession_Call) Run(run func(ctx context.Context, chatID int64)) *MockSessionStore_GetUserSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -159,55 +189,74 @@ func (_c *MockStorage_GetClassBookingSchedules_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockStorage_GetClassBookingSchedules_Call) Return(classBookingSchedules []models.ClassBookingSchedule, b bool) *MockStorage_GetClassBookingSchedules_Call {
-	_c.Call.Return(classBookingSchedules, b)
+func (_c *MockSessionStore_GetUserSession_Call) Return(userSession models.UserSession, b bool) *MockSessionStore_GetUserSession_Call {
+	_c.Call.Return(userSession, b)
 	return _c
 }
 
-func (_c *MockStorage_GetClassBookingSchedules_Call) RunAndReturn(run func(ctx context.Context, chatID int64) ([]models.ClassBookingSchedule, bool)) *MockStorage_GetClassBookingSchedules_Call {
+func (_c *MockSessionStore_GetUserSession_Call) RunAndReturn(run func(ctx context.Context, chatID int64) (models.UserSession, bool)) *MockSessionStore_GetUserSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUser provides a mock function for the type MockStorage
-func (_mock *MockStorage) GetUser(ctx context.Context, chatID int64) (models.User, bool) {
-	ret := _mock.Called(ctx, chatID)
+// NewMockStorage creates a new instance of MockStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStorage {
+	mock := &MockStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStorage is an autogenerated mock type for the Storage type
+type MockStorage struct {
+	mock.Mock
+}
+
+type MockStorage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
+	return &MockStorage_Expecter{mock: &_m.Mock}
+}
+
+// DeleteClassBookingSchedule provides a mock function for the type MockStorage
+func (_mock *MockStorage) DeleteClassBookingSchedule(ctx context.Context, chatID int64, scheduleID string) error {
+	ret := _mock.Called(ctx, chatID, scheduleID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUser")
+		panic("no return value specified for DeleteClassBookingSchedule")
 	}
 
-	var r0 models.User
-	var r1 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (models.User, bool)); ok {
-		return returnFunc(ctx, chatID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) models.User); ok {
-		r0 = returnFunc(ctx, chatID)
-	} else {
-		r0 = ret.Get(0).(models.User)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
-		r1 = returnFunc(ctx, chatID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = returnFunc(ctx, chatID, scheduleID)
 	} else {
-		r1 = ret.Get(1).(bool)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStorage_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
-type MockStorage_GetUser_Call struct {
+// MockStorage_DeleteClassBookingSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteClassBookingSchedule'
+type MockStorage_DeleteClassBookingSchedule_Call struct {
 	*mock.Call
 }
 
-// GetUser is a helper method to define mock.On call
+// DeleteClassBookingSchedule is a helper method to define mock.On call
 //   - ctx context.Context
 //   - chatID int64
-func (_e *MockStorage_Expecter) GetUser(ctx interface{}, chatID interface{}) *MockStorage_GetUser_Call {
-	return &MockStorage_GetUser_Call{Call: _e.mock.On("GetUser", ctx, chatID)}
+//   - scheduleID string
+func (_e *MockStorage_Expecter) DeleteClassBookingSchedule(ctx interface{}, chatID interface{}, scheduleID interface{}) *MockStorage_DeleteClassBookingSchedule_Call {
+	return &MockStorage_DeleteClassBookingSchedule_Call{Call: _e.mock.On("DeleteClassBookingSchedule", ctx, chatID, scheduleID)}
 }
 
-func (_c *MockStorage_GetUser_Call) Run(run func(ctx context.Context, chatID int64)) *MockStorage_GetUser_Call {
+func (_c *MockStorage_DeleteClassBookingSchedule_Call) Run(run func(ctx context.Context, chatID int64, scheduleID string)) *MockStorage_DeleteClassBookingSchedule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -217,233 +266,1734 @@ func (_c *MockStorage_GetUser_Call) Run(run func(ctx context.Context, chatID int
 		if args[1] != nil {
 			arg1 = args[1].(int64)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_GetUser_Call) Return(user models.User, b bool) *MockStorage_GetUser_Call {
-	_c.Call.Return(user, b)
+func (_c *MockStorage_DeleteClassBookingSchedule_Call) Return(err error) *MockStorage_DeleteClassBookingSchedule_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_GetUser_Call) RunAndReturn(run func(ctx context.Context, chatID int64) (models.User, bool)) *MockStorage_GetUser_Call {
+func (_c *MockStorage_DeleteClassBookingSchedule_Call) RunAndReturn(run func(ctx context.Context, chatID int64, scheduleID string) error) *MockStorage_DeleteClassBookingSchedule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveBookingAttempt provides a mock function for the type MockStorage
-func (_mock *MockStorage) SaveBookingAttempt(ctx context.Context, attempt models.BookingAttempt) error {
-	ret := _mock.Called(ctx, attempt)
+// GetAllPendingBookings provides a mock function for the type MockStorage
+func (_mock *MockStorage) GetAllPendingBookings(ctx context.Context) ([]models.BookingAttempt, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveBookingAttempt")
+		panic("no return value specified for GetAllPendingBookings")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.BookingAttempt) error); ok {
-		r0 = returnFunc(ctx, attempt)
+	var r0 []models.BookingAttempt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.BookingAttempt, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.BookingAttempt); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BookingAttempt)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_SaveBookingAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBookingAttempt'
-type MockStorage_SaveBookingAttempt_Call struct {
+// MockStorage_GetAllPendingBookings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllPendingBookings'
+type MockStorage_GetAllPendingBookings_Call struct {
 	*mock.Call
 }
 
-// SaveBookingAttempt is a helper method to define mock.On call
+// GetAllPendingBookings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - attempt models.BookingAttempt
-func (_e *MockStorage_Expecter) SaveBookingAttempt(ctx interface{}, attempt interface{}) *MockStorage_SaveBookingAttempt_Call {
-	return &MockStorage_SaveBookingAttempt_Call{Call: _e.mock.On("SaveBookingAttempt", ctx, attempt)}
+func (_e *MockStorage_Expecter) GetAllPendingBookings(ctx interface{}) *MockStorage_GetAllPendingBookings_Call {
+	return &MockStorage_GetAllPendingBookings_Call{Call: _e.mock.On("GetAllPendingBookings", ctx)}
 }
 
-func (_c *MockStorage_SaveBookingAttempt_Call) Run(run func(ctx context.Context, attempt models.BookingAttempt)) *MockStorage_SaveBookingAttempt_Call {
+func (_c *MockStorage_GetAllPendingBookings_Call) Run(run func(ctx context.Context)) *MockStorage_GetAllPendingBookings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.BookingAttempt
-		if args[1] != nil {
-			arg1 = args[1].(models.BookingAttempt)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_SaveBookingAttempt_Call) Return(err error) *MockStorage_SaveBookingAttempt_Call {
-	_c.Call.Return(err)
+func (_c *MockStorage_GetAllPendingBookings_Call) Return(bookingAttempts []models.BookingAttempt, err error) *MockStorage_GetAllPendingBookings_Call {
+	_c.Call.Return(bookingAttempts, err)
 	return _c
 }
 
-func (_c *MockStorage_SaveBookingAttempt_Call) RunAndReturn(run func(ctx context.Context, attempt models.BookingAttempt) error) *MockStorage_SaveBookingAttempt_Call {
+func (_c *MockStorage_GetAllPendingBookings_Call) RunAndReturn(run func(ctx context.Context) ([]models.BookingAttempt, error)) *MockStorage_GetAllPendingBookings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveClassBookingSchedule provides a mock function for the type MockStorage
-func (_mock *MockStorage) SaveClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
-	ret := _mock.Called(ctx, chatID, class)
+// GetAllUsers provides a mock function for the type MockStorage
+func (_mock *MockStorage) GetAllUsers(ctx context.Context) ([]models.User, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveClassBookingSchedule")
+		panic("no return value specified for GetAllUsers")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.ClassBookingSchedule) error); ok {
-		r0 = returnFunc(ctx, chatID, class)
+	var r0 []models.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.User, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.User); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.User)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_SaveClassBookingSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveClassBookingSchedule'
-type MockStorage_SaveClassBookingSchedule_Call struct {
+// MockStorage_GetAllUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUsers'
+type MockStorage_GetAllUsers_Call struct {
 	*mock.Call
 }
 
-// SaveClassBookingSchedule is a helper method to define mock.On call
+// GetAllUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - chatID int64
-//   - class models.ClassBookingSchedule
-func (_e *MockStorage_Expecter) SaveClassBookingSchedule(ctx interface{}, chatID interface{}, class interface{}) *MockStorage_SaveClassBookingSchedule_Call {
-	return &MockStorage_SaveClassBookingSchedule_Call{Call: _e.mock.On("SaveClassBookingSchedule", ctx, chatID, class)}
+func (_e *MockStorage_Expecter) GetAllUsers(ctx interface{}) *MockStorage_GetAllUsers_Call {
+	return &MockStorage_GetAllUsers_Call{Call: _e.mock.On("GetAllUsers", ctx)}
 }
 
-func (_c *MockStorage_SaveClassBookingSchedule_Call) Run(run func(ctx context.Context, chatID int64, class models.ClassBookingSchedule)) *MockStorage_SaveClassBookingSchedule_Call {
+func (_c *MockStorage_GetAllUsers_Call) Run(run func(ctx context.Context)) *MockStorage_GetAllUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int64
-		if args[1] != nil {
-			arg1 = args[1].(int64)
-		}
-		var arg2 models.ClassBookingSchedule
-		if args[2] != nil {
-			arg2 = args[2].(models.ClassBookingSchedule)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_SaveClassBookingSchedule_Call) Return(err error) *MockStorage_SaveClassBookingSchedule_Call {
-	_c.Call.Return(err)
+func (_c *MockStorage_GetAllUsers_Call) Return(users []models.User, err error) *MockStorage_GetAllUsers_Call {
+	_c.Call.Return(users, err)
 	return _c
 }
 
-func (_c *MockStorage_SaveClassBookingSchedule_Call) RunAndReturn(run func(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error) *MockStorage_SaveClassBookingSchedule_Call {
+func (_c *MockStorage_GetAllUsers_Call) RunAndReturn(run func(ctx context.Context) ([]models.User, error)) *MockStorage_GetAllUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveUser provides a mock function for the type MockStorage
-func (_mock *MockStorage) SaveUser(ctx context.Context, user models.User) error {
-	ret := _mock.Called(ctx, user)
+// GetBookingAttempt provides a mock function for the type MockStorage
+func (_mock *MockStorage) GetBookingAttempt(ctx context.Context, attemptID string) (models.BookingAttempt, bool) {
+	ret := _mock.Called(ctx, attemptID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveUser")
+		panic("no return value specified for GetBookingAttempt")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.User) error); ok {
-		r0 = returnFunc(ctx, user)
+	var r0 models.BookingAttempt
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.BookingAttempt, bool)); ok {
+		return returnFunc(ctx, attemptID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.BookingAttempt); ok {
+		r0 = returnFunc(ctx, attemptID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(models.BookingAttempt)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = returnFunc(ctx, attemptID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
 }
 
-// MockStorage_SaveUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveUser'
-type MockStorage_SaveUser_Call struct {
+// MockStorage_GetBookingAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookingAttempt'
+type MockStorage_GetBookingAttempt_Call struct {
 	*mock.Call
 }
 
-// SaveUser is a helper method to define mock.On call
+// GetBookingAttempt is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user models.User
-func (_e *MockStorage_Expecter) SaveUser(ctx interface{}, user interface{}) *MockStorage_SaveUser_Call {
-	return &MockStorage_SaveUser_Call{Call: _e.mock.On("SaveUser", ctx, user)}
+//   - attemptID string
+func (_e *MockStorage_Expecter) GetBookingAttempt(ctx interface{}, attemptID interface{}) *MockStorage_GetBookingAttempt_Call {
+	return &MockStorage_GetBookingAttempt_Call{Call: _e.mock.On("GetBookingAttempt", ctx, attemptID)}
+}
+
+func (_c *MockStorage_GetBookingAttempt_Call) Run(run func(ctx context.Context, attemptID string)) *MockStorage_GetBookingAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_GetBookingAttempt_Call) Return(bookingAttempt models.BookingAttempt, b bool) *MockStorage_GetBookingAttempt_Call {
+	_c.Call.Return(bookingAttempt, b)
+	return _c
+}
+
+func (_c *MockStorage_GetBookingAttempt_Call) RunAndReturn(run func(ctx context.Context, attemptID string) (models.BookingAttempt, bool)) *MockStorage_GetBookingAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookingsByStatus provides a mock function for the type MockStorage
+func (_mock *MockStorage) GetBookingsByStatus(ctx context.Context, status string) ([]models.BookingAttempt, error) {
+	ret := _mock.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookingsByStatus")
+	}
+
+	var r0 []models.BookingAttempt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.BookingAttempt, error)); ok {
+		return returnFunc(ctx, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.BookingAttempt); ok {
+		r0 = returnFunc(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BookingAttempt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_GetBookingsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookingsByStatus'
+type MockStorage_GetBookingsByStatus_Call struct {
+	*mock.Call
+}
+
+// GetBookingsByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status string
+func (_e *MockStorage_Expecter) GetBookingsByStatus(ctx interface{}, status interface{}) *MockStorage_GetBookingsByStatus_Call {
+	return &MockStorage_GetBookingsByStatus_Call{Call: _e.mock.On("GetBookingsByStatus", ctx, status)}
+}
+
+func (_c *MockStorage_GetBookingsByStatus_Call) Run(run func(ctx context.Context, status string)) *MockStorage_GetBookingsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_GetBookingsByStatus_Call) Return(bookingAttempts []models.BookingAttempt, err error) *MockStorage_GetBookingsByStatus_Call {
+	_c.Call.Return(bookingAttempts, err)
+	return _c
+}
+
+func (_c *MockStorage_GetBookingsByStatus_Call) RunAndReturn(run func(ctx context.Context, status string) ([]models.BookingAttempt, error)) *MockStorage_GetBookingsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClassBookingSchedules provides a mock function for the type MockStorage
+func (_mock *MockStorage) GetClassBookingSchedules(ctx context.Context, chatID int64) ([]models.ClassBookingSchedule, bool) {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClassBookingSchedules")
+	}
+
+	var r0 []models.ClassBookingSchedule
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]models.ClassBookingSchedule, bool)); ok {
+		return returnFunc(ctx, chatID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []models.ClassBookingSchedule); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ClassBookingSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
+		r1 = returnFunc(ctx, chatID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
+}
+
+// MockStorage_GetClassBookingSchedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClassBookingSchedules'
+type MockStorage_GetClassBookingSchedules_Call struct {
+	*mock.Call
+}
+
+// GetClassBookingSchedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockStorage_Expecter) GetClassBookingSchedules(ctx interface{}, chatID interface{}) *MockStorage_GetClassBookingSchedules_Call {
+	return &MockStorage_GetClassBookingSchedules_Call{Call: _e.mock.On("GetClassBookingSchedules", ctx, chatID)}
+}
+
+func (_c *MockStorage_GetClassBookingSchedules_Call) Run(run func(ctx context.Context, chatID int64)) *MockStorage_GetClassBookingSchedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_GetClassBookingSchedules_Call) Return(classBookingSchedules []models.ClassBookingSchedule, b bool) *MockStorage_GetClassBookingSchedules_Call {
+	_c.Call.Return(classBookingSchedules, b)
+	return _c
+}
+
+func (_c *MockStorage_GetClassBookingSchedules_Call) RunAndReturn(run func(ctx context.Context, chatID int64) ([]models.ClassBookingSchedule, bool)) *MockStorage_GetClassBookingSchedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockStorage
+func (_mock *MockStorage) GetUser(ctx context.Context, chatID int64) (models.User, bool) {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 models.User
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (models.User, bool)); ok {
+		return returnFunc(ctx, chatID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) models.User); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(models.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
+		r1 = returnFunc(ctx, chatID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
+}
+
+// MockStorage_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockStorage_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockStorage_Expecter) GetUser(ctx interface{}, chatID interface{}) *MockStorage_GetUser_Call {
+	return &MockStorage_GetUser_Call{Call: _e.mock.On("GetUser", ctx, chatID)}
+}
+
+func (_c *MockStorage_GetUser_Call) Run(run func(ctx context.Context, chatID int64)) *MockStorage_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_GetUser_Call) Return(user models.User, b bool) *MockStorage_GetUser_Call {
+	_c.Call.Return(user, b)
+	return _c
+}
+
+func (_c *MockStorage_GetUser_Call) RunAndReturn(run func(ctx context.Context, chatID int64) (models.User, bool)) *MockStorage_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserSession provides a mock function for the type MockStorage
+func (_mock *MockStorage) GetUserSession(ctx context.Context, chatID int64) (models.UserSession, bool) {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserSession")
+	}
+
+	var r0 models.UserSession
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (models.UserSession, bool)); ok {
+		return returnFunc(ctx, chatID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) models.UserSession); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Get(0).(models.UserSession)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) bool); ok {
+		r1 = returnFunc(ctx, chatID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
+}
+
+// MockStorage_GetUserSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSession'
+type MockStorage_GetUserSession_Call struct {
+	*mock.Call
+}
+
+// GetUserSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockStorage_Expecter) GetUserSession(ctx interface{}, chatID interface{}) *MockStorage_GetUserSession_Call {
+	return &MockStorage_GetUserSession_Call{Call: _e.mock.On("GetUserSession", ctx, chatID)}
+}
+
+func (_c *MockStorage_GetUserSession_Call) Run(run func(ctx context.Context, chatID int64)) *MockStorage_GetUserSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_GetUserSession_Call) Return(userSession models.UserSession, b bool) *MockStorage_GetUserSession_Call {
+	_c.Call.Return(userSession, b)
+	return _c
+}
+
+func (_c *MockStorage_GetUserSession_Call) RunAndReturn(run func(ctx context.Context, chatID int64) (models.UserSession, bool)) *MockStorage_GetUserSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateUserSession provides a mock function for the type MockStorage
+func (_mock *MockStorage) InvalidateUserSession(ctx context.Context, chatID int64) error {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateUserSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_InvalidateUserSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateUserSession'
+type MockStorage_InvalidateUserSession_Call struct {
+	*mock.Call
+}
+
+// InvalidateUserSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+func (_e *MockStorage_Expecter) InvalidateUserSession(ctx interface{}, chatID interface{}) *MockStorage_InvalidateUserSession_Call {
+	return &MockStorage_InvalidateUserSession_Call{Call: _e.mock.On("InvalidateUserSession", ctx, chatID)}
+}
+
+func (_c *MockStorage_InvalidateUserSession_Call) Run(run func(ctx context.Context, chatID int64)) *MockStorage_InvalidateUserSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_InvalidateUserSession_Call) Return(err error) *MockStorage_InvalidateUserSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_InvalidateUserSession_Call) RunAndReturn(run func(ctx context.Context, chatID int64) error) *MockStorage_InvalidateUserSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveBookingAttempt provides a mock function for the type MockStorage
+func (_mock *MockStorage) SaveBookingAttempt(ctx context.Context, attempt models.BookingAttempt) error {
+	ret := _mock.Called(ctx, attempt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBookingAttempt")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.BookingAttempt) error); ok {
+		r0 = returnFunc(ctx, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_SaveBookingAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBookingAttempt'
+type MockStorage_SaveBookingAttempt_Call struct {
+	*mock.Call
+}
+
+// SaveBookingAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attempt models.BookingAttempt
+func (_e *MockStorage_Expecter) SaveBookingAttempt(ctx interface{}, attempt interface{}) *MockStorage_SaveBookingAttempt_Call {
+	return &MockStorage_SaveBookingAttempt_Call{Call: _e.mock.On("SaveBookingAttempt", ctx, attempt)}
+}
+
+func (_c *MockStorage_SaveBookingAttempt_Call) Run(run func(ctx context.Context, attempt models.BookingAttempt)) *MockStorage_SaveBookingAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.BookingAttempt
+		if args[1] != nil {
+			arg1 = args[1].(models.BookingAttempt)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_SaveBookingAttempt_Call) Return(err error) *MockStorage_SaveBookingAttempt_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_SaveBookingAttempt_Call) RunAndReturn(run func(ctx context.Context, attempt models.BookingAttempt) error) *MockStorage_SaveBookingAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveClassBookingSchedule provides a mock function for the type MockStorage
+func (_mock *MockStorage) SaveClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
+	ret := _mock.Called(ctx, chatID, class)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveClassBookingSchedule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.ClassBookingSchedule) error); ok {
+		r0 = returnFunc(ctx, chatID, class)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_SaveClassBookingSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveClassBookingSchedule'
+type MockStorage_SaveClassBookingSchedule_Call struct {
+	*mock.Call
+}
+
+// SaveClassBookingSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - class models.ClassBookingSchedule
+func (_e *MockStorage_Expecter) SaveClassBookingSchedule(ctx interface{}, chatID interface{}, class interface{}) *MockStorage_SaveClassBookingSchedule_Call {
+	return &MockStorage_SaveClassBookingSchedule_Call{Call: _e.mock.On("SaveClassBookingSchedule", ctx, chatID, class)}
+}
+
+func (_c *MockStorage_SaveClassBookingSchedule_Call) Run(run func(ctx context.Context, chatID int64, class models.ClassBookingSchedule)) *MockStorage_SaveClassBookingSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 models.ClassBookingSchedule
+		if args[2] != nil {
+			arg2 = args[2].(models.ClassBookingSchedule)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_SaveClassBookingSchedule_Call) Return(err error) *MockStorage_SaveClassBookingSchedule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_SaveClassBookingSchedule_Call) RunAndReturn(run func(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error) *MockStorage_SaveClassBookingSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveUser provides a mock function for the type MockStorage
+func (_mock *MockStorage) SaveUser(ctx context.Context, user models.User) error {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.User) error); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_SaveUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveUser'
+type MockStorage_SaveUser_Call struct {
+	*mock.Call
+}
+
+// SaveUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user models.User
+func (_e *MockStorage_Expecter) SaveUser(ctx interface{}, user interface{}) *MockStorage_SaveUser_Call {
+	return &MockStorage_SaveUser_Call{Call: _e.mock.On("SaveUser", ctx, user)}
+}
+
+func (_c *MockStorage_SaveUser_Call) Run(run func(ctx context.Context, user models.User)) *MockStorage_SaveUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.User
+		if args[1] != nil {
+			arg1 = args[1].(models.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_SaveUser_Call) Return(err error) *MockStorage_SaveUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_SaveUser_Call) RunAndReturn(run func(ctx context.Context, user models.User) error) *MockStorage_SaveUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveUserSession provides a mock function for the type MockStorage
+func (_mock *MockStorage) SaveUserSession(ctx context.Context, session models.UserSession) error {
+	ret := _mock.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUserSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.UserSession) error); ok {
+		r0 = returnFunc(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_SaveUserSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveUserSession'
+type MockStorage_SaveUserSession_Call struct {
+	*mock.Call
+}
+
+// SaveUserSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session models.UserSession
+func (_e *MockStorage_Expecter) SaveUserSession(ctx interface{}, session interface{}) *MockStorage_SaveUserSession_Call {
+	return &MockStorage_SaveUserSession_Call{Call: _e.mock.On("SaveUserSession", ctx, session)}
+}
+
+func (_c *MockStorage_SaveUserSession_Call) Run(run func(ctx context.Context, session models.UserSession)) *MockStorage_SaveUserSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.UserSession
+		if args[1] != nil {
+			arg1 = args[1].(models.UserSession)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_SaveUserSession_Call) Return(err error) *MockStorage_SaveUserSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_SaveUserSession_Call) RunAndReturn(run func(ctx context.Context, session models.UserSession) error) *MockStorage_SaveUserSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBookingRetry provides a mock function for the type MockStorage
+func (_mock *MockStorage) UpdateBookingRetry(ctx context.Context, attemptID string, retryCount int, errorMsg string) error {
+	ret := _mock.Called(ctx, attemptID, retryCount, errorMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBookingRetry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string) error); ok {
+		r0 = returnFunc(ctx, attemptID, retryCount, errorMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_UpdateBookingRetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBookingRetry'
+type MockStorage_UpdateBookingRetry_Call struct {
+	*mock.Call
+}
+
+// UpdateBookingRetry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attemptID string
+//   - retryCount int
+//   - errorMsg string
+func (_e *MockStorage_Expecter) UpdateBookingRetry(ctx interface{}, attemptID interface{}, retryCount interface{}, errorMsg interface{}) *MockStorage_UpdateBookingRetry_Call {
+	return &MockStorage_UpdateBookingRetry_Call{Call: _e.mock.On("UpdateBookingRetry", ctx, attemptID, retryCount, errorMsg)}
+}
+
+func (_c *MockStorage_UpdateBookingRetry_Call) Run(run func(ctx context.Context, attemptID string, retryCount int, errorMsg string)) *MockStorage_UpdateBookingRetry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UpdateBookingRetry_Call) Return(err error) *MockStorage_UpdateBookingRetry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_UpdateBookingRetry_Call) RunAndReturn(run func(ctx context.Context, attemptID string, retryCount int, errorMsg string) error) *MockStorage_UpdateBookingRetry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBookingStatus provides a mock function for the type MockStorage
+func (_mock *MockStorage) UpdateBookingStatus(ctx context.Context, attemptID string, status string, errorMsg string) error {
+	ret := _mock.Called(ctx, attemptID, status, errorMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBookingStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, attemptID, status, errorMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_UpdateBookingStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBookingStatus'
+type MockStorage_UpdateBookingStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateBookingStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attemptID string
+//   - status string
+//   - errorMsg string
+func (_e *MockStorage_Expecter) UpdateBookingStatus(ctx interface{}, attemptID interface{}, status interface{}, errorMsg interface{}) *MockStorage_UpdateBookingStatus_Call {
+	return &MockStorage_UpdateBookingStatus_Call{Call: _e.mock.On("UpdateBookingStatus", ctx, attemptID, status, errorMsg)}
+}
+
+func (_c *MockStorage_UpdateBookingStatus_Call) Run(run func(ctx context.Context, attemptID string, status string, errorMsg string)) *MockStorage_UpdateBookingStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UpdateBookingStatus_Call) Return(err error) *MockStorage_UpdateBookingStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_UpdateBookingStatus_Call) RunAndReturn(run func(ctx context.Context, attemptID string, status string, errorMsg string) error) *MockStorage_UpdateBookingStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateClassBookingSchedule provides a mock function for the type MockStorage
+func (_mock *MockStorage) UpdateClassBookingSchedule(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error {
+	ret := _mock.Called(ctx, chatID, class)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateClassBookingSchedule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, models.ClassBookingSchedule) error); ok {
+		r0 = returnFunc(ctx, chatID, class)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_UpdateClassBookingSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateClassBookingSchedule'
+type MockStorage_UpdateClassBookingSchedule_Call struct {
+	*mock.Call
+}
+
+// UpdateClassBookingSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - class models.ClassBookingSchedule
+func (_e *MockStorage_Expecter) UpdateClassBookingSchedule(ctx interface{}, chatID interface{}, class interface{}) *MockStorage_UpdateClassBookingSchedule_Call {
+	return &MockStorage_UpdateClassBookingSchedule_Call{Call: _e.mock.On("UpdateClassBookingSchedule", ctx, chatID, class)}
+}
+
+func (_c *MockStorage_UpdateClassBookingSchedule_Call) Run(run func(ctx context.Context, chatID int64, class models.ClassBookingSchedule)) *MockStorage_UpdateClassBookingSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 models.ClassBookingSchedule
+		if args[2] != nil {
+			arg2 = args[2].(models.ClassBookingSchedule)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UpdateClassBookingSchedule_Call) Return(err error) *MockStorage_UpdateClassBookingSchedule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_UpdateClassBookingSchedule_Call) RunAndReturn(run func(ctx context.Context, chatID int64, class models.ClassBookingSchedule) error) *MockStorage_UpdateClassBookingSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIClient creates a new instance of MockAPIClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIClient {
+	mock := &MockAPIClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAPIClient is an autogenerated mock type for the APIClient type
+type MockAPIClient struct {
+	mock.Mock
+}
+
+type MockAPIClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIClient) EXPECT() *MockAPIClient_Expecter {
+	return &MockAPIClient_Expecter{mock: &_m.Mock}
+}
+
+// BookClass provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) BookClass(ctx context.Context, email string, password string, day models.Day, classType string, hour string) error {
+	ret := _mock.Called(ctx, email, password, day, classType, hour)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BookClass")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, models.Day, string, string) error); ok {
+		r0 = returnFunc(ctx, email, password, day, classType, hour)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAPIClient_BookClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BookClass'
+type MockAPIClient_BookClass_Call struct {
+	*mock.Call
+}
+
+// BookClass is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+//   - day models.Day
+//   - classType string
+//   - hour string
+func (_e *MockAPIClient_Expecter) BookClass(ctx interface{}, email interface{}, password interface{}, day interface{}, classType interface{}, hour interface{}) *MockAPIClient_BookClass_Call {
+	return &MockAPIClient_BookClass_Call{Call: _e.mock.On("BookClass", ctx, email, password, day, classType, hour)}
+}
+
+func (_c *MockAPIClient_BookClass_Call) Run(run func(ctx context.Context, email string, password string, day models.Day, classType string, hour string)) *MockAPIClient_BookClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 models.Day
+		if args[3] != nil {
+			arg3 = args[3].(models.Day)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_BookClass_Call) Return(err error) *MockAPIClient_BookClass_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAPIClient_BookClass_Call) RunAndReturn(run func(ctx context.Context, email string, password string, day models.Day, classType string, hour string) error) *MockAPIClient_BookClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BookClasses provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) BookClasses(ctx context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+	ret := _mock.Called(ctx, classes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BookClasses")
+	}
+
+	var r0 []models.ClassResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.ClassRequest) ([]models.ClassResult, error)); ok {
+		return returnFunc(ctx, classes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.ClassRequest) []models.ClassResult); ok {
+		r0 = returnFunc(ctx, classes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ClassResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []models.ClassRequest) error); ok {
+		r1 = returnFunc(ctx, classes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_BookClasses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BookClasses'
+type MockAPIClient_BookClasses_Call struct {
+	*mock.Call
+}
+
+// BookClasses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - classes []models.ClassRequest
+func (_e *MockAPIClient_Expecter) BookClasses(ctx interface{}, classes interface{}) *MockAPIClient_BookClasses_Call {
+	return &MockAPIClient_BookClasses_Call{Call: _e.mock.On("BookClasses", ctx, classes)}
+}
+
+func (_c *MockAPIClient_BookClasses_Call) Run(run func(ctx context.Context, classes []models.ClassRequest)) *MockAPIClient_BookClasses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.ClassRequest
+		if args[1] != nil {
+			arg1 = args[1].([]models.ClassRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_BookClasses_Call) Return(classResults []models.ClassResult, err error) *MockAPIClient_BookClasses_Call {
+	_c.Call.Return(classResults, err)
+	return _c
+}
+
+func (_c *MockAPIClient_BookClasses_Call) RunAndReturn(run func(ctx context.Context, classes []models.ClassRequest) ([]models.ClassResult, error)) *MockAPIClient_BookClasses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAvailableClasses provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) GetAvailableClasses(ctx context.Context, email string, password string, classDate time.Time) ([]models.ClassSchedule, error) {
+	ret := _mock.Called(ctx, email, password, classDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailableClasses")
+	}
+
+	var r0 []models.ClassSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) ([]models.ClassSchedule, error)); ok {
+		return returnFunc(ctx, email, password, classDate)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) []models.ClassSchedule); ok {
+		r0 = returnFunc(ctx, email, password, classDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ClassSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, email, password, classDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_GetAvailableClasses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAvailableClasses'
+type MockAPIClient_GetAvailableClasses_Call struct {
+	*mock.Call
+}
+
+// GetAvailableClasses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+//   - classDate time.Time
+func (_e *MockAPIClient_Expecter) GetAvailableClasses(ctx interface{}, email interface{}, password interface{}, classDate interface{}) *MockAPIClient_GetAvailableClasses_Call {
+	return &MockAPIClient_GetAvailableClasses_Call{Call: _e.mock.On("GetAvailableClasses", ctx, email, password, classDate)}
+}
+
+func (_c *MockAPIClient_GetAvailableClasses_Call) Run(run func(ctx context.Context, email string, password string, classDate time.Time)) *MockAPIClient_GetAvailableClasses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_GetAvailableClasses_Call) Return(classSchedules []models.ClassSchedule, err error) *MockAPIClient_GetAvailableClasses_Call {
+	_c.Call.Return(classSchedules, err)
+	return _c
+}
+
+func (_c *MockAPIClient_GetAvailableClasses_Call) RunAndReturn(run func(ctx context.Context, email string, password string, classDate time.Time) ([]models.ClassSchedule, error)) *MockAPIClient_GetAvailableClasses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCookies provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) GetCookies() []*http.Cookie {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCookies")
+	}
+
+	var r0 []*http.Cookie
+	if returnFunc, ok := ret.Get(0).(func() []*http.Cookie); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*http.Cookie)
+		}
+	}
+	return r0
+}
+
+// MockAPIClient_GetCookies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCookies'
+type MockAPIClient_GetCookies_Call struct {
+	*mock.Call
+}
+
+// GetCookies is a helper method to define mock.On call
+func (_e *MockAPIClient_Expecter) GetCookies() *MockAPIClient_GetCookies_Call {
+	return &MockAPIClient_GetCookies_Call{Call: _e.mock.On("GetCookies")}
+}
+
+func (_c *MockAPIClient_GetCookies_Call) Run(run func()) *MockAPIClient_GetCookies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_GetCookies_Call) Return(cookies []*http.Cookie) *MockAPIClient_GetCookies_Call {
+	_c.Call.Return(cookies)
+	return _c
+}
+
+func (_c *MockAPIClient_GetCookies_Call) RunAndReturn(run func() []*http.Cookie) *MockAPIClient_GetCookies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JoinWaitlist provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) JoinWaitlist(ctx context.Context, classType string, hour string) (bool, error) {
+	ret := _mock.Called(ctx, classType, hour)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinWaitlist")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, classType, hour)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, classType, hour)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, classType, hour)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_JoinWaitlist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinWaitlist'
+type MockAPIClient_JoinWaitlist_Call struct {
+	*mock.Call
+}
+
+// JoinWaitlist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - classType string
+//   - hour string
+func (_e *MockAPIClient_Expecter) JoinWaitlist(ctx interface{}, classType interface{}, hour interface{}) *MockAPIClient_JoinWaitlist_Call {
+	return &MockAPIClient_JoinWaitlist_Call{Call: _e.mock.On("JoinWaitlist", ctx, classType, hour)}
+}
+
+func (_c *MockAPIClient_JoinWaitlist_Call) Run(run func(ctx context.Context, classType string, hour string)) *MockAPIClient_JoinWaitlist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_JoinWaitlist_Call) Return(b bool, err error) *MockAPIClient_JoinWaitlist_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockAPIClient_JoinWaitlist_Call) RunAndReturn(run func(ctx context.Context, classType string, hour string) (bool, error)) *MockAPIClient_JoinWaitlist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadStoredSession provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) LoadStoredSession(ctx context.Context, cookies []*http.Cookie) error {
+	ret := _mock.Called(ctx, cookies)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadStoredSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*http.Cookie) error); ok {
+		r0 = returnFunc(ctx, cookies)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAPIClient_LoadStoredSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadStoredSession'
+type MockAPIClient_LoadStoredSession_Call struct {
+	*mock.Call
+}
+
+// LoadStoredSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cookies []*http.Cookie
+func (_e *MockAPIClient_Expecter) LoadStoredSession(ctx interface{}, cookies interface{}) *MockAPIClient_LoadStoredSession_Call {
+	return &MockAPIClient_LoadStoredSession_Call{Call: _e.mock.On("LoadStoredSession", ctx, cookies)}
+}
+
+func (_c *MockAPIClient_LoadStoredSession_Call) Run(run func(ctx context.Context, cookies []*http.Cookie)) *MockAPIClient_LoadStoredSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*http.Cookie
+		if args[1] != nil {
+			arg1 = args[1].([]*http.Cookie)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_LoadStoredSession_Call) Return(err error) *MockAPIClient_LoadStoredSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAPIClient_LoadStoredSession_Call) RunAndReturn(run func(ctx context.Context, cookies []*http.Cookie) error) *MockAPIClient_LoadStoredSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogIn provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) LogIn(ctx context.Context, email string, password string) (*http.Cookie, error) {
+	ret := _mock.Called(ctx, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogIn")
+	}
+
+	var r0 *http.Cookie
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*http.Cookie, error)); ok {
+		return returnFunc(ctx, email, password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *http.Cookie); ok {
+		r0 = returnFunc(ctx, email, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Cookie)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, email, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_LogIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogIn'
+type MockAPIClient_LogIn_Call struct {
+	*mock.Call
+}
+
+// LogIn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+func (_e *MockAPIClient_Expecter) LogIn(ctx interface{}, email interface{}, password interface{}) *MockAPIClient_LogIn_Call {
+	return &MockAPIClient_LogIn_Call{Call: _e.mock.On("LogIn", ctx, email, password)}
+}
+
+func (_c *MockAPIClient_LogIn_Call) Run(run func(ctx context.Context, email string, password string)) *MockAPIClient_LogIn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_LogIn_Call) Return(cookie *http.Cookie, err error) *MockAPIClient_LogIn_Call {
+	_c.Call.Return(cookie, err)
+	return _c
+}
+
+func (_c *MockAPIClient_LogIn_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (*http.Cookie, error)) *MockAPIClient_LogIn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrepareBooking provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) PrepareBooking(ctx context.Context, email string, password string, classDate time.Time) error {
+	ret := _mock.Called(ctx, email, password, classDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrepareBooking")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, email, password, classDate)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAPIClient_PrepareBooking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrepareBooking'
+type MockAPIClient_PrepareBooking_Call struct {
+	*mock.Call
+}
+
+// PrepareBooking is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+//   - classDate time.Time
+func (_e *MockAPIClient_Expecter) PrepareBooking(ctx interface{}, email interface{}, password interface{}, classDate interface{}) *MockAPIClient_PrepareBooking_Call {
+	return &MockAPIClient_PrepareBooking_Call{Call: _e.mock.On("PrepareBooking", ctx, email, password, classDate)}
+}
+
+func (_c *MockAPIClient_PrepareBooking_Call) Run(run func(ctx context.Context, email string, password string, classDate time.Time)) *MockAPIClient_PrepareBooking_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_PrepareBooking_Call) Return(err error) *MockAPIClient_PrepareBooking_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAPIClient_PrepareBooking_Call) RunAndReturn(run func(ctx context.Context, email string, password string, classDate time.Time) error) *MockAPIClient_PrepareBooking_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveBooking provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) RemoveBooking(ctx context.Context, email string, password string, day models.Day, classType string, hour string) error {
+	ret := _mock.Called(ctx, email, password, day, classType, hour)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBooking")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, models.Day, string, string) error); ok {
+		r0 = returnFunc(ctx, email, password, day, classType, hour)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAPIClient_RemoveBooking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveBooking'
+type MockAPIClient_RemoveBooking_Call struct {
+	*mock.Call
+}
+
+// RemoveBooking is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+//   - day models.Day
+//   - classType string
+//   - hour string
+func (_e *MockAPIClient_Expecter) RemoveBooking(ctx interface{}, email interface{}, password interface{}, day interface{}, classType interface{}, hour interface{}) *MockAPIClient_RemoveBooking_Call {
+	return &MockAPIClient_RemoveBooking_Call{Call: _e.mock.On("RemoveBooking", ctx, email, password, day, classType, hour)}
+}
+
+func (_c *MockAPIClient_RemoveBooking_Call) Run(run func(ctx context.Context, email string, password string, day models.Day, classType string, hour string)) *MockAPIClient_RemoveBooking_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 models.Day
+		if args[3] != nil {
+			arg3 = args[3].(models.Day)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
 }
 
-func (_c *MockStorage_SaveUser_Call) Run(run func(ctx context.Context, user models.User)) *MockStorage_SaveUser_Call {
+func (_c *MockAPIClient_RemoveBooking_Call) Return(err error) *MockAPIClient_RemoveBooking_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAPIClient_RemoveBooking_Call) RunAndReturn(run func(ctx context.Context, email string, password string, day models.Day, classType string, hour string) error) *MockAPIClient_RemoveBooking_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ServerTime provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) ServerTime(ctx context.Context) (time.Time, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServerTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (time.Time, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) time.Time); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_ServerTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServerTime'
+type MockAPIClient_ServerTime_Call struct {
+	*mock.Call
+}
+
+// ServerTime is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAPIClient_Expecter) ServerTime(ctx interface{}) *MockAPIClient_ServerTime_Call {
+	return &MockAPIClient_ServerTime_Call{Call: _e.mock.On("ServerTime", ctx)}
+}
+
+func (_c *MockAPIClient_ServerTime_Call) Run(run func(ctx context.Context)) *MockAPIClient_ServerTime_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.User
-		if args[1] != nil {
-			arg1 = args[1].(models.User)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_SaveUser_Call) Return(err error) *MockStorage_SaveUser_Call {
-	_c.Call.Return(err)
+func (_c *MockAPIClient_ServerTime_Call) Return(time1 time.Time, err error) *MockAPIClient_ServerTime_Call {
+	_c.Call.Return(time1, err)
 	return _c
 }
 
-func (_c *MockStorage_SaveUser_Call) RunAndReturn(run func(ctx context.Context, user models.User) error) *MockStorage_SaveUser_Call {
+func (_c *MockAPIClient_ServerTime_Call) RunAndReturn(run func(ctx context.Context) (time.Time, error)) *MockAPIClient_ServerTime_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBookingStatus provides a mock function for the type MockStorage
-func (_mock *MockStorage) UpdateBookingStatus(ctx context.Context, attemptID string, status string, errorMsg string) error {
-	ret := _mock.Called(ctx, attemptID, status, errorMsg)
+// TryBookClass provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) TryBookClass(ctx context.Context, classType string, hour string) (bool, error) {
+	ret := _mock.Called(ctx, classType, hour)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateBookingStatus")
+		panic("no return value specified for TryBookClass")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(ctx, attemptID, status, errorMsg)
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, classType, hour)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, classType, hour)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, classType, hour)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_UpdateBookingStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBookingStatus'
-type MockStorage_UpdateBookingStatus_Call struct {
+// MockAPIClient_TryBookClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryBookClass'
+type MockAPIClient_TryBookClass_Call struct {
 	*mock.Call
 }
 
-// UpdateBookingStatus is a helper method to define mock.On call
+// TryBookClass is a helper method to define mock.On call
 //   - ctx context.Context
-//   - attemptID string
-//   - status string
-//   - errorMsg string
-func (_e *MockStorage_Expecter) UpdateBookingStatus(ctx interface{}, attemptID interface{}, status interface{}, errorMsg interface{}) *MockStorage_UpdateBookingStatus_Call {
-	return &MockStorage_UpdateBookingStatus_Call{Call: _e.mock.On("UpdateBookingStatus", ctx, attemptID, status, errorMsg)}
+//   - classType string
+//   - hour string
+func (_e *MockAPIClient_Expecter) TryBookClass(ctx interface{}, classType interface{}, hour interface{}) *MockAPIClient_TryBookClass_Call {
+	return &MockAPIClient_TryBookClass_Call{Call: _e.mock.On("TryBookClass", ctx, classType, hour)}
 }
 
-func (_c *MockStorage_UpdateBookingStatus_Call) Run(run func(ctx context.Context, attemptID string, status string, errorMsg string)) *MockStorage_UpdateBookingStatus_Call {
+func (_c *MockAPIClient_TryBookClass_Call) Run(run func(ctx context.Context, classType string, hour string)) *MockAPIClient_TryBookClass_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -457,37 +2007,32 @@ func (_c *MockStorage_UpdateBookingStatus_Call) Run(run func(ctx context.Context
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_UpdateBookingStatus_Call) Return(err error) *MockStorage_UpdateBookingStatus_Call {
-	_c.Call.Return(err)
+func (_c *MockAPIClient_TryBookClass_Call) Return(b bool, err error) *MockAPIClient_TryBookClass_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockStorage_UpdateBookingStatus_Call) RunAndReturn(run func(ctx context.Context, attemptID string, status string, errorMsg string) error) *MockStorage_UpdateBookingStatus_Call {
+func (_c *MockAPIClient_TryBookClass_Call) RunAndReturn(run func(ctx context.Context, classType string, hour string) (bool, error)) *MockAPIClient_TryBookClass_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockAPIClient creates a new instance of MockAPIClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockClientPool creates a new instance of MockClientPool. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockAPIClient(t interface {
+func NewMockClientPool(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockAPIClient {
-	mock := &MockAPIClient{}
+}) *MockClientPool {
+	mock := &MockClientPool{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -495,150 +2040,244 @@ func NewMockAPIClient(t interface {
 	return mock
 }
 
-// MockAPIClient is an autogenerated mock type for the APIClient type
-type MockAPIClient struct {
+// MockClientPool is an autogenerated mock type for the ClientPool type
+type MockClientPool struct {
 	mock.Mock
 }
 
-type MockAPIClient_Expecter struct {
+type MockClientPool_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockAPIClient) EXPECT() *MockAPIClient_Expecter {
-	return &MockAPIClient_Expecter{mock: &_m.Mock}
+func (_m *MockClientPool) EXPECT() *MockClientPool_Expecter {
+	return &MockClientPool_Expecter{mock: &_m.Mock}
 }
 
-// BookClass provides a mock function for the type MockAPIClient
-func (_mock *MockAPIClient) BookClass(ctx context.Context, email string, password string, day string, classType string, hour string) error {
-	ret := _mock.Called(ctx, email, password, day, classType, hour)
+// Acquire provides a mock function for the type MockClientPool
+func (_mock *MockClientPool) Acquire(ctx context.Context) (APIClient, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BookClass")
+		panic("no return value specified for Acquire")
+	}
+
+	var r0 APIClient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (APIClient, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) APIClient); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(APIClient)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClientPool_Acquire_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acquire'
+type MockClientPool_Acquire_Call struct {
+	*mock.Call
+}
+
+// Acquire is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClientPool_Expecter) Acquire(ctx interface{}) *MockClientPool_Acquire_Call {
+	return &MockClientPool_Acquire_Call{Call: _e.mock.On("Acquire", ctx)}
+}
+
+func (_c *MockClientPool_Acquire_Call) Run(run func(ctx context.Context)) *MockClientPool_Acquire_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClientPool_Acquire_Call) Return(aPIClient APIClient, err error) *MockClientPool_Acquire_Call {
+	_c.Call.Return(aPIClient, err)
+	return _c
+}
+
+func (_c *MockClientPool_Acquire_Call) RunAndReturn(run func(ctx context.Context) (APIClient, error)) *MockClientPool_Acquire_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function for the type MockClientPool
+func (_mock *MockClientPool) Release(client APIClient) {
+	_mock.Called(client)
+	return
+}
+
+// MockClientPool_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockClientPool_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - client APIClient
+func (_e *MockClientPool_Expecter) Release(client interface{}) *MockClientPool_Release_Call {
+	return &MockClientPool_Release_Call{Call: _e.mock.On("Release", client)}
+}
+
+func (_c *MockClientPool_Release_Call) Run(run func(client APIClient)) *MockClientPool_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 APIClient
+		if args[0] != nil {
+			arg0 = args[0].(APIClient)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClientPool_Release_Call) Return() *MockClientPool_Release_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockClientPool_Release_Call) RunAndReturn(run func(client APIClient)) *MockClientPool_Release_Call {
+	_c.Run(run)
+	return _c
+}
+
+// WarmUp provides a mock function for the type MockClientPool
+func (_mock *MockClientPool) WarmUp(ctx context.Context, count int) error {
+	ret := _mock.Called(ctx, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WarmUp")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) error); ok {
-		r0 = returnFunc(ctx, email, password, day, classType, hour)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, count)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockAPIClient_BookClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BookClass'
-type MockAPIClient_BookClass_Call struct {
+// MockClientPool_WarmUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WarmUp'
+type MockClientPool_WarmUp_Call struct {
 	*mock.Call
 }
 
-// BookClass is a helper method to define mock.On call
+// WarmUp is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-//   - password string
-//   - day string
-//   - classType string
-//   - hour string
-func (_e *MockAPIClient_Expecter) BookClass(ctx interface{}, email interface{}, password interface{}, day interface{}, classType interface{}, hour interface{}) *MockAPIClient_BookClass_Call {
-	return &MockAPIClient_BookClass_Call{Call: _e.mock.On("BookClass", ctx, email, password, day, classType, hour)}
+//   - count int
+func (_e *MockClientPool_Expecter) WarmUp(ctx interface{}, count interface{}) *MockClientPool_WarmUp_Call {
+	return &MockClientPool_WarmUp_Call{Call: _e.mock.On("WarmUp", ctx, count)}
 }
 
-func (_c *MockAPIClient_BookClass_Call) Run(run func(ctx context.Context, email string, password string, day string, classType string, hour string)) *MockAPIClient_BookClass_Call {
+func (_c *MockClientPool_WarmUp_Call) Run(run func(ctx context.Context, count int)) *MockClientPool_WarmUp_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
-		var arg5 string
-		if args[5] != nil {
-			arg5 = args[5].(string)
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4,
-			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAPIClient_BookClass_Call) Return(err error) *MockAPIClient_BookClass_Call {
+func (_c *MockClientPool_WarmUp_Call) Return(err error) *MockClientPool_WarmUp_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAPIClient_BookClass_Call) RunAndReturn(run func(ctx context.Context, email string, password string, day string, classType string, hour string) error) *MockAPIClient_BookClass_Call {
+func (_c *MockClientPool_WarmUp_Call) RunAndReturn(run func(ctx context.Context, count int) error) *MockClientPool_WarmUp_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LogIn provides a mock function for the type MockAPIClient
-func (_mock *MockAPIClient) LogIn(ctx context.Context, email string, password string) (*http.Cookie, error) {
-	ret := _mock.Called(ctx, email, password)
+// NewMockNotifier creates a new instance of MockNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotifier {
+	mock := &MockNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotifier is an autogenerated mock type for the Notifier type
+type MockNotifier struct {
+	mock.Mock
+}
+
+type MockNotifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotifier) EXPECT() *MockNotifier_Expecter {
+	return &MockNotifier_Expecter{mock: &_m.Mock}
+}
+
+// Notify provides a mock function for the type MockNotifier
+func (_mock *MockNotifier) Notify(ctx context.Context, chatID int64, message string) error {
+	ret := _mock.Called(ctx, chatID, message)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LogIn")
+		panic("no return value specified for Notify")
 	}
 
-	var r0 *http.Cookie
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*http.Cookie, error)); ok {
-		return returnFunc(ctx, email, password)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *http.Cookie); ok {
-		r0 = returnFunc(ctx, email, password)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*http.Cookie)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, email, password)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = returnFunc(ctx, chatID, message)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockAPIClient_LogIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogIn'
-type MockAPIClient_LogIn_Call struct {
+// MockNotifier_Notify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Notify'
+type MockNotifier_Notify_Call struct {
 	*mock.Call
 }
 
-// LogIn is a helper method to define mock.On call
+// Notify is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-//   - password string
-func (_e *MockAPIClient_Expecter) LogIn(ctx interface{}, email interface{}, password interface{}) *MockAPIClient_LogIn_Call {
-	return &MockAPIClient_LogIn_Call{Call: _e.mock.On("LogIn", ctx, email, password)}
+//   - chatID int64
+//   - message string
+func (_e *MockNotifier_Expecter) Notify(ctx interface{}, chatID interface{}, message interface{}) *MockNotifier_Notify_Call {
+	return &MockNotifier_Notify_Call{Call: _e.mock.On("Notify", ctx, chatID, message)}
 }
 
-func (_c *MockAPIClient_LogIn_Call) Run(run func(ctx context.Context, email string, password string)) *MockAPIClient_LogIn_Call {
+func (_c *MockNotifier_Notify_Call) Run(run func(ctx context.Context, chatID int64, message string)) *MockNotifier_Notify_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
 		var arg2 string
 		if args[2] != nil {
@@ -653,12 +2292,12 @@ func (_c *MockAPIClient_LogIn_Call) Run(run func(ctx context.Context, email stri
 	return _c
 }
 
-func (_c *MockAPIClient_LogIn_Call) Return(cookie *http.Cookie, err error) *MockAPIClient_LogIn_Call {
-	_c.Call.Return(cookie, err)
+func (_c *MockNotifier_Notify_Call) Return(err error) *MockNotifier_Notify_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockAPIClient_LogIn_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (*http.Cookie, error)) *MockAPIClient_LogIn_Call {
+func (_c *MockNotifier_Notify_Call) RunAndReturn(run func(ctx context.Context, chatID int64, message string) error) *MockNotifier_Notify_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+)
+
+// notify sends a message to the user, if a notifier is set
+func (bs *BookingScheduler) notify(ctx context.Context, chatID int64, message string) {
+	if bs.notifier == nil {
+		return
+	}
+	if err := bs.notifier.Notify(ctx, chatID, message); err != nil {
+		bs.logger.Error("Failed to notify user", "chat_id", chatID, "error", err)
+	}
+}
+
+// bookingResultMessage tells the user how the booking run of an attempt ended,
+// with the reason and what to do about it when it failed
+func (bs *BookingScheduler) bookingResultMessage(attempt models.BookingAttempt, err error) string {
+	class := describeAttempt(attempt)
+
+	switch attempt.Status {
+	case "success":
+		return fmt.Sprintf("✅ Booked %s.", describeBooked(attempt))
+	case "waitlisted":
+		return fmt.Sprintf("⏳ %s is full, you joined its waiting list. I'll let you know if you get a spot.", class)
+	case "monitoring":
+		return fmt.Sprintf("⏳ %s is full. I'll keep checking and book it if a spot frees up.", class)
+	}
+
+	return fmt.Sprintf("❌ Could not book %s.\nReason: %s\n💡 %s", class, attempt.ErrorMsg, bs.retryHint(err))
+}
+
+// limitRejectedMessage tells the user a class of their rules won't be booked
+// because it breaks their limits
+func limitRejectedMessage(attempt models.BookingAttempt) string {
+	return fmt.Sprintf("🚫 %s won't be booked: %s.\n💡 Remove another class with /remove or change your limits with /limits.",
+		describeAttempt(attempt), attempt.ErrorMsg)
+}
+
+// retryHint suggests what the user can do after a failed booking
+func (bs *BookingScheduler) retryHint(err error) string {
+	const loginHint = "Log in again with /login so your next bookings go through."
+
+	switch {
+	case errors.Is(err, ErrCredentialsUnavailable), errors.Is(err, ErrSessionRestoreFailed):
+		return loginHint
+	case errors.Is(err, ErrBurstWindowExpired):
+		return "The class never became bookable. Check on WODBuster that it is still scheduled."
+	}
+
+	switch bs.classify(err) {
+	case BookingErrorSessionExpired:
+		return loginHint
+	case BookingErrorClassFull:
+		return "The class was full. Pick another time with /book."
+	case BookingErrorTransient:
+		return "WODBuster did not respond in time. Book it on WODBuster if spots are left; your next classes are booked as usual."
+	case BookingErrorNotOpenYet:
+		return "Booking had not opened for the class. If your box opens it at another time, the booking window needs adjusting."
+	}
+	return "Check the class still exists on WODBuster with the same time and type, or replace the rule with /remove and /book."
+}
+
+// sendWeeklySummaries sends each user the outcome of their attempts in the
+// weekly run
+func (bs *BookingScheduler) sendWeeklySummaries(ctx context.Context, results []models.BookingAttempt) {
+	byUser := make(map[int64][]models.BookingAttempt)
+	for _, attempt := range results {
+		byUser[attempt.ChatID] = append(byUser[attempt.ChatID], attempt)
+	}
+
+	for chatID, attempts := range byUser {
+		bs.notify(ctx, chatID, weeklySummaryMessage(attempts))
+	}
+}
+
+// weeklySummaryMessage lists the user's attempts of a weekly run by class date
+func weeklySummaryMessage(attempts []models.BookingAttempt) string {
+	attempts = slices.Clone(attempts)
+	slices.SortFunc(attempts, func(a, b models.BookingAttempt) int {
+		return cmp.Or(cmp.Compare(a.ClassDate, b.ClassDate), cmp.Compare(a.Hour, b.Hour))
+	})
+
+	var booked int
+	var lines strings.Builder
+	for _, attempt := range attempts {
+		switch attempt.Status {
+		case "success":
+			booked++
+			fmt.Fprintf(&lines, "✅ %s\n", describeBooked(attempt))
+		case "waitlisted", "monitoring":
+			fmt.Fprintf(&lines, "⏳ %s: waiting for a spot\n", describeAttempt(attempt))
+		default:
+			fmt.Fprintf(&lines, "❌ %s: %s\n", describeAttempt(attempt), attempt.ErrorMsg)
+		}
+	}
+
+	return fmt.Sprintf("📋 Weekly booking summary\n\n%s\nBooked %d of %d classes.", lines.String(), booked, len(attempts))
+}
+
+// describeAttempt names the attempt's class for users, e.g. "Wod on Monday
+// 2025-08-18 at 07:00"
+func describeAttempt(attempt models.BookingAttempt) string {
+	return fmt.Sprintf("%s on %s %s at %s", attempt.ClassType, attempt.Day, attempt.ClassDate, attempt.Hour)
+}
+
+// describeBooked names the class a successful attempt booked, saying which
+// class it replaced when an alternative was booked
+func describeBooked(attempt models.BookingAttempt) string {
+	alternative := attempt.BookedAlternative
+	if alternative == nil {
+		return describeAttempt(attempt)
+	}
+	return fmt.Sprintf("%s on %s %s at %s instead of %s at %s",
+		alternative.ClassType, attempt.Day, attempt.ClassDate, alternative.Hour, attempt.ClassType, attempt.Hour)
+}
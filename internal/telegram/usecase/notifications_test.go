@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingScheduler_BookingResultMessage(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	errFull := errors.New("class is full")
+	scheduler := NewBookingScheduler(nil, nil, nil, newTestWindow(t), logger,
+		WithErrorClassifier(func(err error) BookingErrorKind {
+			if errors.Is(err, errFull) {
+				return BookingErrorClassFull
+			}
+			return ClassifyBookingError(err)
+		}))
+
+	attempt := models.BookingAttempt{ClassDate: "2025-08-18", Day: "Monday", Hour: "07:00", ClassType: "Wod"}
+
+	tests := []struct {
+		name        string
+		status      string
+		err         error
+		alternative *models.ClassAlternative
+		contains    []string
+	}{
+		{
+			name:     "success",
+			status:   "success",
+			contains: []string{"✅ Booked Wod on Monday 2025-08-18 at 07:00."},
+		},
+		{
+			name:        "alternative booked",
+			status:      "success",
+			alternative: &models.ClassAlternative{Hour: "08:00", ClassType: "Open box"},
+			contains:    []string{"✅ Booked Open box on Monday 2025-08-18 at 08:00 instead of Wod at 07:00."},
+		},
+		{
+			name:     "waiting list",
+			status:   "waitlisted",
+			contains: []string{"waiting list"},
+		},
+		{
+			name:     "full class",
+			status:   "failed",
+			err:      errFull,
+			contains: []string{"❌ Could not book Wod on Monday 2025-08-18 at 07:00.", "Reason: class is full", "/book"},
+		},
+		{
+			name:     "credentials unavailable",
+			status:   "failed",
+			err:      fmt.Errorf("%w: key rotated", ErrCredentialsUnavailable),
+			contains: []string{"/login"},
+		},
+		{
+			name:     "class never opened",
+			status:   "failed",
+			err:      fmt.Errorf("%w: 120 tries", ErrBurstWindowExpired),
+			contains: []string{"never became bookable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt.Status = tt.status
+			attempt.BookedAlternative = tt.alternative
+			attempt.ErrorMsg = ""
+			if tt.err != nil {
+				attempt.ErrorMsg = tt.err.Error()
+			}
+
+			message := scheduler.bookingResultMessage(attempt, tt.err)
+			for _, want := range tt.contains {
+				assert.Contains(t, message, want)
+			}
+		})
+	}
+}
+
+func TestWeeklySummaryMessage(t *testing.T) {
+	message := weeklySummaryMessage([]models.BookingAttempt{
+		{ClassDate: "2025-08-20", Day: "Wednesday", Hour: "19:00", ClassType: "Wod", Status: "failed", ErrorMsg: "class is full"},
+		{ClassDate: "2025-08-18", Day: "Monday", Hour: "07:00", ClassType: "Wod", Status: "success"},
+		{ClassDate: "2025-08-19", Day: "Tuesday", Hour: "08:00", ClassType: "Open box", Status: "monitoring"},
+	})
+
+	assert.Equal(t, "📋 Weekly booking summary\n\n"+
+		"✅ Wod on Monday 2025-08-18 at 07:00\n"+
+		"⏳ Open box on Tuesday 2025-08-19 at 08:00: waiting for a spot\n"+
+		"❌ Wod on Wednesday 2025-08-20 at 19:00: class is full\n"+
+		"\nBooked 1 of 3 classes.", message)
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+)
+
+// ReencryptResult counts the stored passwords ReencryptPasswords went through
+type ReencryptResult struct {
+	Reencrypted int // Passwords moved to the primary key
+	Current     int // Passwords already under the primary key
+	Failed      int // Passwords none of the keys could decrypt
+}
+
+// ReencryptPasswords moves the stored password of every user to the primary
+// key of the keyring, so the other keys can be retired. Users whose password
+// can't be decrypted are left untouched and reported in the error; they have
+// to log in again.
+func ReencryptPasswords(ctx context.Context, storage Storage, keyring *utils.Keyring, logger *slog.Logger) (ReencryptResult, error) {
+	users, err := storage.GetAllUsers(ctx)
+	if err != nil {
+		return ReencryptResult{}, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	var result ReencryptResult
+	var errs []error
+	for _, user := range users {
+		if user.Password == "" || !keyring.NeedsRotation(user.Password) {
+			result.Current++
+			continue
+		}
+
+		rotated, err := keyring.Rotate(user.Password)
+		if err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("user %d: %w", user.ChatID, err))
+			logger.Error("Failed to re-encrypt password", "error", err, "chat_id", user.ChatID)
+			continue
+		}
+
+		user.Password = rotated
+		user.UpdatedAt = time.Now()
+		if err := storage.SaveUser(ctx, user); err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("user %d: %w", user.ChatID, err))
+			logger.Error("Failed to save re-encrypted password", "error", err, "chat_id", user.ChatID)
+			continue
+		}
+		result.Reencrypted++
+	}
+
+	logger.Info("Re-encrypted stored passwords",
+		"primary_key", keyring.Primary(),
+		"reencrypted", result.Reencrypted,
+		"current", result.Current,
+		"failed", result.Failed)
+	return result, errors.Join(errs...)
+}
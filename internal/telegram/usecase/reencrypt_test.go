@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReencryptPasswords(t *testing.T) {
+	ctx := context.Background()
+	const (
+		oldKey = "0123456789abcdef0123456789abcdef"
+		newKey = "fedcba9876543210fedcba9876543210"
+	)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	before, err := utils.NewKeyring("old", map[string]string{"old": oldKey})
+	require.NoError(t, err)
+	keyring, err := utils.NewKeyring("new", map[string]string{"old": oldKey, "new": newKey})
+	require.NoError(t, err)
+
+	envelope, err := before.Encrypt("secret1")
+	require.NoError(t, err)
+	legacy, err := utils.EncryptPassword("secret2", oldKey)
+	require.NoError(t, err)
+	current, err := keyring.Encrypt("secret3")
+	require.NoError(t, err)
+	lost, err := utils.EncryptPassword("secret4", "00000000000000000000000000000000")
+	require.NoError(t, err)
+
+	store := storage.NewMemoryStorage()
+	passwords := map[int64]string{1: envelope, 2: legacy, 3: current, 4: lost}
+	for chatID, password := range passwords {
+		require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com", Password: password}))
+	}
+
+	result, err := ReencryptPasswords(ctx, store, keyring, logger)
+	assert.Error(t, err)
+	assert.Equal(t, ReencryptResult{Reencrypted: 2, Current: 1, Failed: 1}, result)
+
+	// Everything that could be decrypted no longer needs the old key
+	retired, err := utils.NewKeyring("new", map[string]string{"new": newKey})
+	require.NoError(t, err)
+	for chatID, want := range map[int64]string{1: "secret1", 2: "secret2", 3: "secret3"} {
+		user, ok := store.GetUser(ctx, chatID)
+		require.True(t, ok)
+		password, err := retired.Decrypt(user.Password)
+		require.NoError(t, err)
+		assert.Equal(t, want, password)
+	}
+
+	user, ok := store.GetUser(ctx, 4)
+	require.True(t, ok)
+	assert.Equal(t, lost, user.Password)
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"time"
+)
+
+// BookingErrorKind tells the scheduler how to react to a failed booking step
+type BookingErrorKind int
+
+const (
+	// BookingErrorPermanent gives up on the attempt
+	BookingErrorPermanent BookingErrorKind = iota
+	// BookingErrorTransient covers navigation timeouts and network errors
+	BookingErrorTransient
+	// BookingErrorNotOpenYet is a class or button that is not shown yet
+	BookingErrorNotOpenYet
+	// BookingErrorSessionExpired requires logging in again before retrying
+	BookingErrorSessionExpired
+	// BookingErrorClassFull means there are no spots left
+	BookingErrorClassFull
+)
+
+func (k BookingErrorKind) String() string {
+	switch k {
+	case BookingErrorTransient:
+		return "transient"
+	case BookingErrorNotOpenYet:
+		return "not_open_yet"
+	case BookingErrorSessionExpired:
+		return "session_expired"
+	case BookingErrorClassFull:
+		return "class_full"
+	}
+	return "permanent"
+}
+
+// Retryable reports whether the booking may succeed if tried again
+func (k BookingErrorKind) Retryable() bool {
+	return k == BookingErrorTransient || k == BookingErrorNotOpenYet || k == BookingErrorSessionExpired
+}
+
+// ErrorClassifier maps the errors of an APIClient implementation to kinds
+type ErrorClassifier func(err error) BookingErrorKind
+
+// ClassifyBookingError classifies the errors that don't depend on the client
+// implementation: timeouts and network errors are transient, anything else is
+// permanent. Client specific classifiers fall back to it.
+func ClassifyBookingError(err error) BookingErrorKind {
+	if errors.Is(err, ErrBurstWindowExpired) {
+		return BookingErrorPermanent
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return BookingErrorTransient
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return BookingErrorTransient
+	}
+
+	return BookingErrorPermanent
+}
+
+// Backoff between retries: exponential from retryBaseDelay, capped at
+// retryMaxDelay, with jitter so concurrent bookings don't retry in lockstep
+const (
+	retryBaseDelay = 250 * time.Millisecond
+	retryMaxDelay  = 5 * time.Second
+)
+
+// retryBackoff returns how long to wait before the given retry (1 for the
+// first): a random delay between half and all of the exponential backoff
+func retryBackoff(retry int) time.Duration {
+	delay := retryMaxDelay
+	if retry < 16 {
+		delay = min(retryBaseDelay<<(retry-1), retryMaxDelay)
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyBookingError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want BookingErrorKind
+	}{
+		{"deadline exceeded", fmt.Errorf("failed to book class: %w", context.DeadlineExceeded), BookingErrorTransient},
+		{"network error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, BookingErrorTransient},
+		{"burst window expired", fmt.Errorf("%w: 10 tries", ErrBurstWindowExpired), BookingErrorPermanent},
+		{"unknown error", errors.New("something broke"), BookingErrorPermanent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyBookingError(tt.err))
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{1, 125 * time.Millisecond, 250 * time.Millisecond},
+		{3, 500 * time.Millisecond, time.Second},
+		{10, retryMaxDelay / 2, retryMaxDelay},
+		{100, retryMaxDelay / 2, retryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		for range 20 {
+			delay := retryBackoff(tt.retry)
+			assert.GreaterOrEqual(t, delay, tt.min, "retry %d", tt.retry)
+			assert.LessOrEqual(t, delay, tt.max, "retry %d", tt.retry)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 
@@ -11,52 +14,200 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
+// Defaults of the burst of booking attempts fired when a window opens, and of
+// the retries of the failed ones
+const (
+	defaultBurstWindow   = 30 * time.Second
+	defaultBurstInterval = 250 * time.Millisecond
+	defaultMaxRetries    = 3
+	defaultRetryWindow   = 2 * time.Minute
+	// defaultClassDuration is how long classes last, to find overlapping ones
+	defaultClassDuration = time.Hour
+	// waitlistCheckTimeout bounds a single check of a full class
+	waitlistCheckTimeout = 2 * time.Minute
+)
+
 // BookingContext represents an active booking attempt
 type BookingContext struct {
+	AttemptID   string
 	ChatID      int64
 	BookingData models.BookingWindow
 	Cancel      context.CancelFunc
 	Status      string
 }
 
-// BookingScheduler handles Saturday cronjob and parallel booking
+// BookingScheduler runs the bookings when their window opens, in parallel
 type BookingScheduler struct {
 	storage           Storage
-	clientAPI         APIClient
+	clientPool        ClientPool
+	credentials       CredentialProvider
+	window            *BookingWindowPolicy
+	burstWindow       time.Duration
+	burstInterval     time.Duration
+	maxRetries        int
+	retryWindow       time.Duration
+	classify          ErrorClassifier
+	waitlistInterval  time.Duration // Zero disables waitlist mode
+	waitlistCutoff    time.Duration
+	keepAliveLead     time.Duration // Zero disables the session checks
+	keepAliveWarned   map[int64]bool
+	keepAliveMux      sync.Mutex
+	limits            models.BookingLimits // Of the users who haven't set their own
+	classDuration     time.Duration        // Zero allows overlapping classes
+	notifier          Notifier
 	logger            *slog.Logger
 	cron              *cron.Cron
-	activeBookings    map[int64]*BookingContext
+	bookingEntry      cron.EntryID
+	activeBookings    map[int64]map[string]*BookingContext // By chat ID, then attempt ID
 	activeBookingsMux sync.RWMutex
+	claimMux          sync.Mutex // Held while a run claims its attempts
 	isRunning         bool
 }
 
-func NewBookingScheduler(storage Storage, clientAPI APIClient, logger *slog.Logger) *BookingScheduler {
-	return &BookingScheduler{
-		storage:        storage,
-		clientAPI:      clientAPI,
-		logger:         logger,
-		cron:           cron.New(),
-		activeBookings: make(map[int64]*BookingContext),
+// SchedulerOption defines the method to customize the BookingScheduler.
+type SchedulerOption func(*BookingScheduler)
+
+// WithBurst sets how long booking attempts keep being fired once a window
+// opens, and how often
+func WithBurst(window, interval time.Duration) SchedulerOption {
+	return func(bs *BookingScheduler) {
+		if window > 0 {
+			bs.burstWindow = window
+		}
+		if interval > 0 {
+			bs.burstInterval = interval
+		}
+	}
+}
+
+// WithRetries sets how many times a failed booking is retried, and until how
+// long after its window opened
+func WithRetries(maxRetries int, window time.Duration) SchedulerOption {
+	return func(bs *BookingScheduler) {
+		if maxRetries >= 0 {
+			bs.maxRetries = maxRetries
+		}
+		if window > 0 {
+			bs.retryWindow = window
+		}
+	}
+}
+
+// WithErrorClassifier sets how the errors of the API clients are classified
+func WithErrorClassifier(classify ErrorClassifier) SchedulerOption {
+	return func(bs *BookingScheduler) {
+		if classify != nil {
+			bs.classify = classify
+		}
+	}
+}
+
+// WithWaitlist enables waitlist mode: a booking that finds its class full joins
+// the site's waiting list if it offers one, and is otherwise retried every
+// interval until cutoff before the class starts
+func WithWaitlist(interval, cutoff time.Duration) SchedulerOption {
+	return func(bs *BookingScheduler) {
+		if interval > 0 && cutoff >= 0 {
+			bs.waitlistInterval = interval
+			bs.waitlistCutoff = cutoff
+		}
+	}
+}
+
+// WithKeepAlive enables the session checks: lead before each booking window,
+// every user's stored session is checked and logged in again if it died, so
+// broken logins are found before the booking run
+func WithKeepAlive(lead time.Duration) SchedulerOption {
+	return func(bs *BookingScheduler) {
+		if lead > 0 {
+			bs.keepAliveLead = lead
+		}
+	}
+}
+
+// WithLimits sets the booking limits of the users who haven't set their own,
+// and how long classes last, so overlapping ones aren't both booked. A zero
+// duration allows overlapping classes.
+func WithLimits(defaults models.BookingLimits, classDuration time.Duration) SchedulerOption {
+	return func(bs *BookingScheduler) {
+		bs.limits = defaults
+		if classDuration >= 0 {
+			bs.classDuration = classDuration
+		}
 	}
 }
 
-// Start begins the Saturday 11:55 cronjob
+func NewBookingScheduler(
+	storage Storage,
+	clientPool ClientPool,
+	credentials CredentialProvider,
+	window *BookingWindowPolicy,
+	logger *slog.Logger,
+	opts ...SchedulerOption,
+) *BookingScheduler {
+	bs := &BookingScheduler{
+		storage:         storage,
+		clientPool:      clientPool,
+		credentials:     credentials,
+		window:          window,
+		burstWindow:     defaultBurstWindow,
+		burstInterval:   defaultBurstInterval,
+		maxRetries:      defaultMaxRetries,
+		retryWindow:     defaultRetryWindow,
+		classify:        ClassifyBookingError,
+		classDuration:   defaultClassDuration,
+		keepAliveWarned: make(map[int64]bool),
+		logger:          logger,
+		cron:            cron.New(),
+		activeBookings:  make(map[int64]map[string]*BookingContext),
+	}
+
+	for _, opt := range opts {
+		opt(bs)
+	}
+
+	return bs
+}
+
+// SetNotifier sets how users are told about bookings that change state in the
+// background. It is set after creation because the bot sending the messages
+// depends on the manager, which depends on the scheduler.
+func (bs *BookingScheduler) SetNotifier(notifier Notifier) {
+	bs.notifier = notifier
+}
+
+// Start begins the cronjob that runs ahead of each booking window, the one
+// checking full classes in waitlist mode, and the one keeping sessions alive
 func (bs *BookingScheduler) Start() error {
 	if bs.isRunning {
 		return fmt.Errorf("booking scheduler is already running")
 	}
 
-	// Schedule for every Saturday at 11:55 AM
-	// Cron format: "MIN HOUR DAY_OF_MONTH MONTH DAY_OF_WEEK"
-	// 55 11 * * 6 = 11:55 AM every Saturday (6 = Saturday)
-	_, err := bs.cron.AddFunc("55 11 * * 6", bs.processAllBookings)
+	spec := bs.window.CronSpec()
+	entry, err := bs.cron.AddFunc(spec, bs.processAllBookings)
 	if err != nil {
 		return fmt.Errorf("failed to schedule cronjob: %w", err)
 	}
+	bs.bookingEntry = entry
+
+	if bs.waitlistInterval > 0 {
+		// A slow check must not overlap the next one
+		job := cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(cron.FuncJob(bs.processWaitlist))
+		if _, err := bs.cron.AddJob("@every "+bs.waitlistInterval.String(), job); err != nil {
+			return fmt.Errorf("failed to schedule waitlist cronjob: %w", err)
+		}
+	}
+
+	if bs.keepAliveLead > 0 {
+		job := cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(cron.FuncJob(bs.processKeepAlive))
+		if _, err := bs.cron.AddJob(bs.window.KeepAliveCronSpec(bs.keepAliveLead), job); err != nil {
+			return fmt.Errorf("failed to schedule session keep-alive cronjob: %w", err)
+		}
+	}
 
 	bs.cron.Start()
 	bs.isRunning = true
-	bs.logger.Info("Booking scheduler started - will run every Saturday at 11:55 AM")
+	bs.logger.Info("Booking scheduler started", "booking_window", bs.window.String(), "cron", spec)
 
 	return nil
 }
@@ -72,44 +223,101 @@ func (bs *BookingScheduler) Stop() {
 
 	// Cancel all active bookings
 	bs.activeBookingsMux.Lock()
-	for chatID, booking := range bs.activeBookings {
-		booking.Cancel()
-		bs.logger.Info("Cancelled active booking", "chat_id", chatID)
+	for chatID, bookings := range bs.activeBookings {
+		for attemptID, booking := range bookings {
+			booking.Cancel()
+			bs.logger.Info("Cancelled active booking", "chat_id", chatID, "booking_id", attemptID)
+		}
 	}
-	bs.activeBookings = make(map[int64]*BookingContext)
+	bs.activeBookings = make(map[int64]map[string]*BookingContext)
 	bs.activeBookingsMux.Unlock()
 
 	bs.logger.Info("Booking scheduler stopped")
 }
 
-// processAllBookings processes all pending bookings (called by cronjob)
+// processAllBookings starts the bookings due in this run (called by cronjob).
+// It returns without waiting for them: in rolling mode the bookings of a run
+// wait for their windows longer than the minute until the next run, which must
+// start the attempts due by then on time. Every attempt is claimed before its
+// booking starts, so the next runs don't start it again.
 func (bs *BookingScheduler) processAllBookings() {
-	bs.logger.Info("🚀 Saturday 11:55 - Starting booking process for all users")
-
 	ctx := context.Background()
 
+	bookingAttempts := bs.claimDueAttempts(ctx, time.Now())
+	if len(bookingAttempts) == 0 {
+		bs.logger.Debug("No bookings due")
+		return
+	}
+
+	bs.logger.Info("🚀 Starting booking process", "count", len(bookingAttempts))
+	go bs.runBookings(ctx, bookingAttempts)
+}
+
+// claimDueAttempts marks the pending attempts due in the run starting at now
+// as active and returns them. Runs claim their attempts one at a time, so an
+// attempt is never claimed twice.
+func (bs *BookingScheduler) claimDueAttempts(ctx context.Context, now time.Time) []models.BookingAttempt {
+	bs.claimMux.Lock()
+	defer bs.claimMux.Unlock()
+
+	// Turn the weekly booking rules into the pending attempts of their next class
+	if err := bs.ExpandSchedules(ctx, now); err != nil {
+		bs.logger.Error("Failed to expand booking schedules", "error", err)
+	}
+
 	// Get all pending booking attempts
-	bookingAttempts, err := bs.storage.GetAllPendingBookings(ctx)
+	pendingAttempts, err := bs.storage.GetAllPendingBookings(ctx)
 	if err != nil {
 		bs.logger.Error("Failed to get pending bookings", "error", err)
-		return
+		return nil
 	}
 
-	if len(bookingAttempts) == 0 {
-		bs.logger.Info("No pending bookings found")
-		return
+	var claimed []models.BookingAttempt
+	for _, attempt := range bs.dueAttempts(ctx, pendingAttempts, now) {
+		if err := bs.storage.UpdateBookingStatus(ctx, attempt.ID, "active", ""); err != nil {
+			// Left pending, the next run tries to claim it again
+			bs.logger.Error("Failed to claim booking attempt", "booking_id", attempt.ID, "error", err)
+			continue
+		}
+		attempt.Status = "active"
+		claimed = append(claimed, attempt)
 	}
+	return claimed
+}
+
+// runBookings books the claimed attempts and, in weekly mode, sends each user
+// the summary of their bookings
+func (bs *BookingScheduler) runBookings(ctx context.Context, bookingAttempts []models.BookingAttempt) {
+	// A user's classes are booked one after the other in a single session
+	groups := groupByUser(bookingAttempts)
 
-	bs.logger.Info("Processing bookings", "count", len(bookingAttempts))
+	// Open the browser contexts before the booking window opens
+	if err := bs.clientPool.WarmUp(ctx, len(groups)); err != nil {
+		bs.logger.Warn("Failed to warm up browser contexts", "error", err)
+	}
 
-	// Process each booking concurrently
-	var wg sync.WaitGroup
-	for _, attempt := range bookingAttempts {
+	// Process each user's bookings concurrently
+	var (
+		wg         sync.WaitGroup
+		resultsMux sync.Mutex
+		results    []models.BookingAttempt
+	)
+	for _, group := range groups {
 		wg.Add(1)
-		go func(booking models.BookingAttempt) {
+		go func(attempts []models.BookingAttempt) {
 			defer wg.Done()
-			bs.processUserBooking(ctx, booking)
-		}(attempt)
+
+			var finished []models.BookingAttempt
+			if len(attempts) == 1 {
+				finished = []models.BookingAttempt{bs.processUserBooking(ctx, attempts[0])}
+			} else {
+				finished = bs.processUserBatch(ctx, attempts)
+			}
+
+			resultsMux.Lock()
+			results = append(results, finished...)
+			resultsMux.Unlock()
+		}(group)
 	}
 
 	// Wait for all bookings to complete (or timeout)
@@ -126,10 +334,137 @@ func (bs *BookingScheduler) processAllBookings() {
 	case <-time.After(10 * time.Minute):
 		bs.logger.Warn("Booking timeout reached - some bookings may still be in progress")
 	}
+
+	// Rolling windows run every minute, each attempt is notified on its own
+	if bs.window.Mode() == BookingWindowWeekly {
+		resultsMux.Lock()
+		finished := slices.Clone(results)
+		resultsMux.Unlock()
+		bs.sendWeeklySummaries(ctx, finished)
+	}
 }
 
-// processUserBooking processes booking for a single user
-func (bs *BookingScheduler) processUserBooking(ctx context.Context, booking models.BookingAttempt) {
+// dueAttempts filters the pending attempts whose window opens within the
+// current run and expires the ones whose class has already taken place
+func (bs *BookingScheduler) dueAttempts(ctx context.Context, attempts []models.BookingAttempt, now time.Time) []models.BookingAttempt {
+	today := bs.window.Today(now)
+
+	var due []models.BookingAttempt
+	for _, attempt := range attempts {
+		if attempt.ClassDate != "" && attempt.ClassDate < today {
+			if err := bs.storage.UpdateBookingStatus(ctx, attempt.ID, "expired", "class date has already passed"); err != nil {
+				bs.logger.Error("Failed to expire booking attempt", "booking_id", attempt.ID, "error", err)
+			}
+			continue
+		}
+		if !bs.window.IsDue(attempt.AttemptTime, now) {
+			continue
+		}
+		due = append(due, attempt)
+	}
+	return due
+}
+
+// ExpandSchedules creates the pending attempt of the next class of every user's
+// weekly booking rules
+func (bs *BookingScheduler) ExpandSchedules(ctx context.Context, now time.Time) error {
+	users, err := bs.storage.GetAllUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	for _, user := range users {
+		for _, schedule := range user.ClassBookingSchedules {
+			if err := bs.expandSchedule(ctx, user.ChatID, schedule, now); err != nil {
+				bs.logger.Error("Failed to expand booking schedule",
+					"chat_id", user.ChatID,
+					"schedule_id", schedule.ID,
+					"error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ScheduleNextAttempt creates the pending attempt of the next class of a single
+// booking rule
+func (bs *BookingScheduler) ScheduleNextAttempt(ctx context.Context, chatID int64, schedule models.ClassBookingSchedule) error {
+	return bs.expandSchedule(ctx, chatID, schedule, time.Now())
+}
+
+// expandSchedule creates the attempt for the next class of the rule whose
+// booking window has not been processed yet. It is idempotent: an attempt that
+// already exists for that class date is left untouched whatever its status.
+func (bs *BookingScheduler) expandSchedule(ctx context.Context, chatID int64, schedule models.ClassBookingSchedule, now time.Time) error {
+	classStart, opensAt, err := bs.window.NextClass(schedule.Day, schedule.Hour, now)
+	if err != nil {
+		return err
+	}
+
+	// Dates are kept in the box's timezone, as shown on WODBuster
+	classDate := classStart.In(bs.window.Location())
+
+	if !schedule.AppliesOn(classDate) {
+		bs.logger.Debug("Booking schedule does not apply this week",
+			"chat_id", chatID,
+			"schedule_id", schedule.ID,
+			"class_date", classDate.Format(models.DateLayout))
+		return nil
+	}
+
+	attemptID := bookingAttemptID(chatID, schedule.ID, classDate.Format(models.DateLayout))
+	if _, exists := bs.storage.GetBookingAttempt(ctx, attemptID); exists {
+		return nil
+	}
+
+	// The limits may have changed, or the date ranges of the rules made them
+	// look compatible when they were added
+	status, errorMsg := "pending", ""
+	if user, exists := bs.storage.GetUser(ctx, chatID); exists {
+		if err := bs.checkWeekLimits(user, schedule, classDate); err != nil {
+			status, errorMsg = "rejected", err.Error()
+		}
+	}
+
+	attempt := models.BookingAttempt{
+		ID:           attemptID,
+		ChatID:       chatID,
+		ScheduleID:   schedule.ID,
+		ClassDate:    classDate.Format(models.DateLayout),
+		Day:          schedule.Day,
+		Hour:         schedule.Hour,
+		ClassType:    schedule.ClassType,
+		Alternatives: schedule.Alternatives,
+		Status:       status,
+		AttemptTime:  opensAt, // When the booking window opens
+		ErrorMsg:     errorMsg,
+		RetryCount:   0,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := bs.storage.SaveBookingAttempt(ctx, attempt); err != nil {
+		return err
+	}
+
+	if status == "rejected" {
+		bs.logger.Info("Booking attempt rejected by the user's limits",
+			"chat_id", chatID,
+			"booking_id", attemptID,
+			"reason", errorMsg)
+		bs.notify(ctx, chatID, limitRejectedMessage(attempt))
+	}
+	return nil
+}
+
+// bookingAttemptID builds the deterministic ID of a rule's attempt for a class date
+func bookingAttemptID(chatID int64, scheduleID, classDate string) string {
+	return fmt.Sprintf("%d-%s-%s", chatID, scheduleID, classDate)
+}
+
+// processUserBooking processes booking for a single user, notifies them of the
+// outcome and returns the attempt in its final state
+func (bs *BookingScheduler) processUserBooking(ctx context.Context, booking models.BookingAttempt) models.BookingAttempt {
 	// Create cancellable context for this booking
 	bookingCtx, cancel := context.WithTimeout(ctx, 15*time.Minute)
 	defer cancel()
@@ -143,79 +478,103 @@ func (bs *BookingScheduler) processUserBooking(ctx context.Context, booking mode
 
 	time.Sleep(delay)
 
+	classStart, err := bs.classStart(booking)
+	if err != nil {
+		bs.logger.Error("Invalid booking attempt", "booking_id", booking.ID, "error", err)
+		booking.Status = "failed"
+		booking.ErrorMsg = err.Error()
+		if updateErr := bs.storage.UpdateBookingStatus(ctx, booking.ID, booking.Status, booking.ErrorMsg); updateErr != nil {
+			bs.logger.Error("Failed to update booking status", "booking_id", booking.ID, "error", updateErr)
+		}
+		bs.notify(ctx, booking.ChatID, bs.bookingResultMessage(booking, err))
+		return booking
+	}
+
 	// Track active booking
 	bookingContext := &BookingContext{
-		ChatID: booking.ChatID,
+		AttemptID: booking.ID,
+		ChatID:    booking.ChatID,
 		BookingData: models.BookingWindow{
-			Day:       booking.Day,
-			Hour:      booking.Hour,
-			ClassType: booking.ClassType,
-			OpensAt:   time.Now().Add(5 * time.Minute), // Opens at 12:00
+			Day:          booking.Day,
+			Hour:         booking.Hour,
+			ClassType:    booking.ClassType,
+			Alternatives: booking.Alternatives,
+			ClassStart:   classStart,
+			OpensAt:      booking.AttemptTime,
 		},
 		Cancel: cancel,
 		Status: "active",
 	}
 
-	bs.activeBookingsMux.Lock()
-	bs.activeBookings[booking.ChatID] = bookingContext
-	bs.activeBookingsMux.Unlock()
-
-	// Update booking status to active
-	if err := bs.storage.UpdateBookingStatus(bookingCtx, booking.ID, "active", ""); err != nil {
-		bs.logger.Error("Failed to update booking status", "booking_id", booking.ID, "error", err)
-	}
+	bs.trackActiveBooking(bookingContext)
+	defer bs.untrackActiveBooking(bookingContext)
 
 	// Perform the booking using APIClient
-	err := bs.performBookingForUser(bookingCtx, booking.ChatID, bookingContext.BookingData)
+	run, err := bs.performBookingForUser(bookingCtx, booking.ID, booking.ChatID, bookingContext.BookingData)
 
-	// Update final status
-	status := "success"
-	errorMsg := ""
-	if err != nil {
-		status = "failed"
-		errorMsg = err.Error()
-		bs.logger.Error("Booking failed", "chat_id", booking.ChatID, "error", err)
-	} else {
-		bs.logger.Info("Booking successful", "chat_id", booking.ChatID)
+	if err != nil && errors.Is(bookingCtx.Err(), context.Canceled) {
+		err = context.Canceled
 	}
+	return bs.finishAttempt(ctx, booking, run, err)
+}
 
-	// Update booking attempt in storage
-	if updateErr := bs.storage.UpdateBookingStatus(bookingCtx, booking.ID, status, errorMsg); updateErr != nil {
-		bs.logger.Error("Failed to update final booking status", "booking_id", booking.ID, "error", updateErr)
+// trackActiveBooking adds the booking to the user's active bookings
+func (bs *BookingScheduler) trackActiveBooking(booking *BookingContext) {
+	bs.activeBookingsMux.Lock()
+	defer bs.activeBookingsMux.Unlock()
+
+	if bs.activeBookings[booking.ChatID] == nil {
+		bs.activeBookings[booking.ChatID] = make(map[string]*BookingContext)
 	}
+	bs.activeBookings[booking.ChatID][booking.AttemptID] = booking
+}
 
-	// Remove from active bookings
+// untrackActiveBooking removes the booking from the user's active bookings
+func (bs *BookingScheduler) untrackActiveBooking(booking *BookingContext) {
 	bs.activeBookingsMux.Lock()
-	delete(bs.activeBookings, booking.ChatID)
-	bs.activeBookingsMux.Unlock()
+	defer bs.activeBookingsMux.Unlock()
+
+	bookings := bs.activeBookings[booking.ChatID]
+	delete(bookings, booking.AttemptID)
+	if len(bookings) == 0 {
+		delete(bs.activeBookings, booking.ChatID)
+	}
 }
 
-// GetActiveBookings returns currently active booking attempts
-func (bs *BookingScheduler) GetActiveBookings() map[int64]*BookingContext {
+// GetActiveBookings returns the user's active booking attempts, by class start
+func (bs *BookingScheduler) GetActiveBookings(chatID int64) []BookingContext {
 	bs.activeBookingsMux.RLock()
 	defer bs.activeBookingsMux.RUnlock()
 
-	// Return copy to avoid race conditions
-	result := make(map[int64]*BookingContext)
-	for k, v := range bs.activeBookings {
-		result[k] = v
+	// Return copies to avoid race conditions
+	result := make([]BookingContext, 0, len(bs.activeBookings[chatID]))
+	for _, booking := range bs.activeBookings[chatID] {
+		result = append(result, *booking)
 	}
+	slices.SortFunc(result, func(a, b BookingContext) int {
+		return cmp.Or(a.BookingData.ClassStart.Compare(b.BookingData.ClassStart), cmp.Compare(a.AttemptID, b.AttemptID))
+	})
 	return result
 }
 
-// CancelBooking cancels an active booking attempt
-func (bs *BookingScheduler) CancelBooking(chatID int64) bool {
+// CancelBooking cancels one of the user's active booking attempts
+func (bs *BookingScheduler) CancelBooking(chatID int64, attemptID string) bool {
 	bs.activeBookingsMux.Lock()
 	defer bs.activeBookingsMux.Unlock()
 
-	if booking, exists := bs.activeBookings[chatID]; exists {
-		booking.Cancel()
-		booking.Status = "cancelled"
+	booking, exists := bs.activeBookings[chatID][attemptID]
+	if !exists {
+		return false
+	}
+
+	booking.Cancel()
+	booking.Status = "cancelled"
+	delete(bs.activeBookings[chatID], attemptID)
+	if len(bs.activeBookings[chatID]) == 0 {
 		delete(bs.activeBookings, chatID)
-		bs.logger.Info("Cancelled booking", "chat_id", chatID)
-		return true
 	}
-	return false
+	bs.logger.Info("Cancelled booking", "chat_id", chatID, "booking_id", attemptID)
+	return true
 }
 
 // IsRunning returns whether the scheduler is currently running
@@ -229,12 +588,7 @@ func (bs *BookingScheduler) GetNextRunTime() time.Time {
 		return time.Time{}
 	}
 
-	entries := bs.cron.Entries()
-	if len(entries) == 0 {
-		return time.Time{}
-	}
-
-	return entries[0].Next
+	return bs.cron.Entry(bs.bookingEntry).Next
 }
 
 // GetScheduleInfo returns human-readable schedule information
@@ -249,17 +603,36 @@ func (bs *BookingScheduler) GetScheduleInfo() string {
 	}
 
 	timeUntilNext := time.Until(nextRun)
-	return fmt.Sprintf("Next booking run: %s (in %s)",
-		nextRun.Format("Monday, January 2, 2006 at 15:04 MST"),
-		timeUntilNext.Round(time.Minute))
+	return fmt.Sprintf("Next booking run: %s (in %s)\nBooking window: %s",
+		nextRun.In(bs.window.Location()).Format("Monday, January 2, 2006 at 15:04 MST"),
+		timeUntilNext.Round(time.Minute),
+		bs.window)
 }
 
-// performBookingForUser uses APIClient to perform booking for specific user
-func (bs *BookingScheduler) performBookingForUser(ctx context.Context, chatID int64, booking models.BookingWindow) error {
+// bookingRun is what a booking run measured against the site's clock and went
+// through before it succeeded or gave up
+type bookingRun struct {
+	clockSkew time.Duration // Server clock minus local clock
+	latency   time.Duration // From the window opening to the booking confirmation
+	tries     int           // Booking attempts fired
+	retries   int           // Times the booking was started over after an error
+	waitlist  string        // "waitlisted" or "monitoring" once the class was full in waitlist mode
+	// alternative is the class booked instead of the attempt's, if any
+	alternative *models.ClassAlternative
+}
+
+// performBookingForUser uses APIClient to perform booking for specific user. The
+// client logs in and opens the class day before the window opens, then fires
+// booking attempts from the moment it opens by the site's clock. Retryable
+// errors start the booking over after a backoff, until maxRetries or the retry
+// window runs out; each retry is recorded on the attempt.
+func (bs *BookingScheduler) performBookingForUser(ctx context.Context, attemptID string, chatID int64, booking models.BookingWindow) (bookingRun, error) {
+	var run bookingRun
+
 	// Get user from storage
 	user, exists := bs.storage.GetUser(ctx, chatID)
 	if !exists {
-		return fmt.Errorf("user %d not found", chatID)
+		return run, fmt.Errorf("user %d not found", chatID)
 	}
 
 	bs.logger.Info("Starting booking for user",
@@ -269,19 +642,454 @@ func (bs *BookingScheduler) performBookingForUser(ctx context.Context, chatID in
 		"hour", booking.Hour,
 		"class_type", booking.ClassType)
 
-	// Wait for booking window to open (12:00 PM)
-	if err := bs.waitForBookingWindow(ctx, booking); err != nil {
+	// Each booking runs in its own isolated browser context
+	client, err := bs.clientPool.Acquire(ctx)
+	if err != nil {
+		return run, fmt.Errorf("failed to acquire browser context: %w", err)
+	}
+	defer bs.clientPool.Release(client)
+
+	// Resolve credentials before the window opens so failures are reported early
+	password, err := bs.authenticate(ctx, client, user)
+	if err != nil {
+		return run, err
+	}
+
+	// A late run still gets the full retry window
+	retryDeadline := booking.OpensAt
+	if now := time.Now(); now.After(retryDeadline) {
+		retryDeadline = now
+	}
+	retryDeadline = retryDeadline.Add(bs.retryWindow)
+
+	for {
+		err := bs.bookOnce(ctx, client, user.Email, password, booking, &run)
+		if err == nil {
+			return run, nil
+		}
+
+		kind := bs.classify(err)
+		if kind == BookingErrorClassFull && bs.waitlistInterval > 0 {
+			run.waitlist = bs.joinWaitlist(ctx, client, chatID, booking)
+			return run, err
+		}
+		if !kind.Retryable() || run.retries >= bs.maxRetries || time.Now().After(retryDeadline) {
+			return run, err
+		}
+
+		run.retries++
+		bs.logger.Warn("Booking failed, retrying",
+			"chat_id", chatID,
+			"retry", run.retries,
+			"error_kind", kind.String(),
+			"error", err)
+		if updateErr := bs.storage.UpdateBookingRetry(ctx, attemptID, run.retries, err.Error()); updateErr != nil {
+			bs.logger.Error("Failed to record booking retry", "booking_id", attemptID, "error", updateErr)
+		}
+
+		if kind == BookingErrorSessionExpired {
+			if password, err = bs.passwordLogin(ctx, user, err); err != nil {
+				return run, err
+			}
+		}
+
+		select {
+		case <-time.After(retryBackoff(run.retries)):
+		case <-ctx.Done():
+			return run, ctx.Err()
+		}
+	}
+}
+
+// bookOnce prepares the booking, waits for the window to open and fires the
+// burst of booking attempts
+func (bs *BookingScheduler) bookOnce(ctx context.Context, client APIClient, email, password string, booking models.BookingWindow, run *bookingRun) error {
+	// An empty password prepares within the restored session
+	if err := client.PrepareBooking(ctx, email, password, booking.ClassStart); err != nil {
+		return err
+	}
+
+	run.clockSkew = bs.measureClockSkew(ctx, client)
+
+	// The window opens by the site's clock
+	opensAt := booking.OpensAt.Add(-run.clockSkew)
+	if err := bs.waitForBookingWindow(ctx, opensAt); err != nil {
 		return fmt.Errorf("failed while waiting for booking window: %w", err)
 	}
 
-	// Use APIClient to perform booking - it will handle session management, login, etc.
-	return bs.clientAPI.BookClass(ctx, user.Email, "", booking.Day, booking.ClassType, booking.Hour)
+	// A late run still gets a full burst
+	deadline := time.Now().Add(bs.burstWindow)
+	for {
+		run.tries++
+		booked, err := client.TryBookClass(ctx, booking.ClassType, booking.Hour)
+		if !booked && len(booking.Alternatives) > 0 && bs.canFallBack(err) {
+			alternative, altErr := bs.bookAlternative(ctx, client, booking.Alternatives)
+			if altErr != nil {
+				return altErr
+			}
+			if alternative != nil {
+				run.alternative = alternative
+				booked, err = true, nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if booked {
+			run.latency = time.Since(opensAt)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %d tries", ErrBurstWindowExpired, run.tries)
+		}
+
+		select {
+		case <-time.After(bs.burstInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// canFallBack reports whether the alternatives of a class are worth trying
+// after an attempt to book it: its "Reservar" button was missing, it is full,
+// or it can't be booked at all
+func (bs *BookingScheduler) canFallBack(err error) bool {
+	if err == nil {
+		return true
+	}
+	kind := bs.classify(err)
+	return kind == BookingErrorClassFull || kind == BookingErrorPermanent
+}
+
+// bookAlternative makes a single attempt to book each alternative in order,
+// on the day the client prepared, and returns the one it booked, if any
+func (bs *BookingScheduler) bookAlternative(ctx context.Context, client APIClient, alternatives []models.ClassAlternative) (*models.ClassAlternative, error) {
+	for _, alternative := range alternatives {
+		booked, err := client.TryBookClass(ctx, alternative.ClassType, alternative.Hour)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			bs.logger.Debug("Failed to book alternative class", "alternative", alternative.String(), "error", err)
+			continue
+		}
+		if booked {
+			bs.logger.Info("Booked alternative class", "alternative", alternative.String())
+			return &alternative, nil
+		}
+	}
+	return nil, nil
+}
+
+// joinWaitlist joins the waiting list of the full class on the day the client
+// prepared, returning the status the attempt is left in: "waitlisted" when it
+// joined, "monitoring" when the waitlist job has to watch for a free spot
+func (bs *BookingScheduler) joinWaitlist(ctx context.Context, client APIClient, chatID int64, booking models.BookingWindow) string {
+	joined, err := client.JoinWaitlist(ctx, booking.ClassType, booking.Hour)
+	if err != nil {
+		bs.logger.Warn("Failed to join waiting list, monitoring the class instead", "chat_id", chatID, "error", err)
+		return "monitoring"
+	}
+	if !joined {
+		return "monitoring"
+	}
+
+	bs.logger.Info("Joined waiting list", "chat_id", chatID)
+	return "waitlisted"
+}
+
+// processWaitlist checks the full classes of the waitlisted and monitored
+// attempts (called by the waitlist cronjob)
+func (bs *BookingScheduler) processWaitlist() {
+	ctx := context.Background()
+
+	for _, status := range []string{"waitlisted", "monitoring"} {
+		attempts, err := bs.storage.GetBookingsByStatus(ctx, status)
+		if err != nil {
+			bs.logger.Error("Failed to get bookings waiting for a spot", "status", status, "error", err)
+			continue
+		}
+
+		for _, attempt := range attempts {
+			bs.checkFullClass(ctx, attempt, time.Now())
+		}
+	}
+}
+
+// checkFullClass books the attempt's class if a spot freed up, or a waiting
+// list moved the user in, and gives up once the class starts within the cutoff
+func (bs *BookingScheduler) checkFullClass(ctx context.Context, attempt models.BookingAttempt, now time.Time) {
+	classStart, err := bs.classStart(attempt)
+	if err != nil {
+		bs.logger.Error("Invalid booking attempt", "booking_id", attempt.ID, "error", err)
+		return
+	}
+
+	if !now.Before(classStart.Add(-bs.waitlistCutoff)) {
+		if err := bs.storage.UpdateBookingStatus(ctx, attempt.ID, "failed", "class stayed full"); err != nil {
+			bs.logger.Error("Failed to update booking status", "booking_id", attempt.ID, "error", err)
+			return
+		}
+		bs.notify(ctx, attempt.ChatID, fmt.Sprintf("❌ No spot freed up in %s, I stopped waiting.", describeAttempt(attempt)))
+		return
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, waitlistCheckTimeout)
+	defer cancel()
+
+	booked, err := bs.tryFullClass(checkCtx, attempt, classStart)
+	if err != nil {
+		if bs.classify(err) != BookingErrorClassFull {
+			bs.logger.Warn("Failed to check full class", "booking_id", attempt.ID, "error", err)
+		}
+		return
+	}
+	if !booked {
+		return
+	}
+
+	if err := bs.storage.UpdateBookingStatus(ctx, attempt.ID, "success", ""); err != nil {
+		bs.logger.Error("Failed to update booking status", "booking_id", attempt.ID, "error", err)
+	}
+	bs.logger.Info("Booked class after it was full", "booking_id", attempt.ID, "chat_id", attempt.ChatID)
+	bs.notify(ctx, attempt.ChatID, fmt.Sprintf("✅ A spot freed up: you are booked in %s!", describeAttempt(attempt)))
+}
+
+// tryFullClass reloads the class and books it if it has a spot
+func (bs *BookingScheduler) tryFullClass(ctx context.Context, attempt models.BookingAttempt, classStart time.Time) (bool, error) {
+	user, exists := bs.storage.GetUser(ctx, attempt.ChatID)
+	if !exists {
+		return false, fmt.Errorf("user %d not found", attempt.ChatID)
+	}
+
+	client, err := bs.clientPool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire browser context: %w", err)
+	}
+	defer bs.clientPool.Release(client)
+
+	password, err := bs.authenticate(ctx, client, user)
+	if err != nil {
+		return false, err
+	}
+	if err := client.PrepareBooking(ctx, user.Email, password, classStart); err != nil {
+		return false, err
+	}
+
+	return client.TryBookClass(ctx, attempt.ClassType, attempt.Hour)
+}
+
+// classStart returns when the attempt's class starts
+func (bs *BookingScheduler) classStart(attempt models.BookingAttempt) (time.Time, error) {
+	if attempt.ClassDate == "" {
+		return time.Time{}, fmt.Errorf("booking attempt %s has no class date", attempt.ID)
+	}
+	return bs.window.ClassStart(attempt.ClassDate, attempt.Hour)
+}
+
+// passwordLogin returns the password to log in again with once the session
+// expired, failing with the session error when none is stored
+func (bs *BookingScheduler) passwordLogin(ctx context.Context, user models.User, sessionErr error) (string, error) {
+	creds, err := bs.credentials.Credentials(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	if creds.Password == "" {
+		return "", fmt.Errorf("%w: %w", ErrSessionRestoreFailed, sessionErr)
+	}
+
+	bs.logger.Info("Session expired, logging in again", "chat_id", user.ChatID)
+	return creds.Password, nil
+}
+
+// measureClockSkew returns how far the site's clock is ahead of the local one,
+// or zero if it cannot be read
+func (bs *BookingScheduler) measureClockSkew(ctx context.Context, client APIClient) time.Duration {
+	serverTime, err := client.ServerTime(ctx)
+	if err != nil {
+		bs.logger.Warn("Failed to read the site's clock, assuming no skew", "error", err)
+		return 0
+	}
+
+	skew := serverTime.Sub(time.Now())
+	bs.logger.Info("Measured clock skew", "skew_ms", skew.Milliseconds())
+	return skew
+}
+
+// RemoveBookedClass cancels the user's reservation of a class on WODBuster
+func (bs *BookingScheduler) RemoveBookedClass(ctx context.Context, chatID int64, day models.Day, classType, hour string) error {
+	user, exists := bs.storage.GetUser(ctx, chatID)
+	if !exists {
+		return fmt.Errorf("user %d not found", chatID)
+	}
+
+	client, err := bs.clientPool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire browser context: %w", err)
+	}
+	defer bs.clientPool.Release(client)
+
+	password, err := bs.authenticate(ctx, client, user)
+	if err != nil {
+		return err
+	}
+
+	return client.RemoveBooking(ctx, user.Email, password, day, classType, hour)
+}
+
+// GetTimetable reads the timetable of each date, keyed by date (YYYY-MM-DD),
+// through the user's session
+func (bs *BookingScheduler) GetTimetable(ctx context.Context, chatID int64, dates []time.Time) (map[string][]models.ClassSchedule, error) {
+	user, exists := bs.storage.GetUser(ctx, chatID)
+	if !exists {
+		return nil, fmt.Errorf("user %d not found", chatID)
+	}
+
+	client, err := bs.clientPool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire browser context: %w", err)
+	}
+	defer bs.clientPool.Release(client)
+
+	password, err := bs.authenticate(ctx, client, user)
+	if err != nil {
+		return nil, err
+	}
+
+	timetable := make(map[string][]models.ClassSchedule, len(dates))
+	for _, date := range dates {
+		classes, err := client.GetAvailableClasses(ctx, user.Email, password, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get classes of %s: %w", date.Format("2006-01-02"), err)
+		}
+		timetable[date.Format("2006-01-02")] = classes
+	}
+
+	return timetable, nil
+}
+
+// CancelScheduleAttempts cancels the pending attempts of a booking rule, and
+// the ones waiting for a spot in a full class, so a removed rule is not booked
+// by the next run or the waitlist job
+func (bs *BookingScheduler) CancelScheduleAttempts(ctx context.Context, chatID int64, scheduleID string) error {
+	var attempts []models.BookingAttempt
+	for _, status := range []string{"pending", "waitlisted", "monitoring"} {
+		found, err := bs.storage.GetBookingsByStatus(ctx, status)
+		if err != nil {
+			return fmt.Errorf("failed to get %s bookings: %w", status, err)
+		}
+		attempts = append(attempts, found...)
+	}
+
+	for _, attempt := range attempts {
+		if attempt.ChatID != chatID || attempt.ScheduleID != scheduleID {
+			continue
+		}
+		if err := bs.storage.UpdateBookingStatus(ctx, attempt.ID, "cancelled", "booking rule removed"); err != nil {
+			return fmt.Errorf("failed to cancel booking attempt %s: %w", attempt.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// SyncScheduleAttempts brings the attempts of an edited booking rule in line
+// with it: the pending attempts of dates it no longer books are paused or
+// skipped, the ones of dates it books again are pending again, and the attempt
+// of its next class is created if missing
+func (bs *BookingScheduler) SyncScheduleAttempts(ctx context.Context, chatID int64, schedule models.ClassBookingSchedule) error {
+	var attempts []models.BookingAttempt
+	for _, status := range []string{"pending", "paused", "skipped"} {
+		found, err := bs.storage.GetBookingsByStatus(ctx, status)
+		if err != nil {
+			return fmt.Errorf("failed to get %s bookings: %w", status, err)
+		}
+		attempts = append(attempts, found...)
+	}
+
+	for _, attempt := range attempts {
+		if attempt.ChatID != chatID || attempt.ScheduleID != schedule.ID {
+			continue
+		}
+		classDate, err := time.ParseInLocation(models.DateLayout, attempt.ClassDate, bs.window.Location())
+		if err != nil {
+			continue
+		}
+
+		status, errorMsg := attempt.Status, attempt.ErrorMsg
+		switch {
+		case schedule.AppliesOn(classDate):
+			status, errorMsg = "pending", ""
+		case attempt.Status != "pending":
+		case schedule.PausedOn(classDate):
+			status, errorMsg = "paused", "booking rule paused"
+		default:
+			status, errorMsg = "skipped", ""
+		}
+		if status == attempt.Status {
+			continue
+		}
+		if err := bs.storage.UpdateBookingStatus(ctx, attempt.ID, status, errorMsg); err != nil {
+			return fmt.Errorf("failed to update booking attempt %s: %w", attempt.ID, err)
+		}
+	}
+
+	return bs.ScheduleNextAttempt(ctx, chatID, schedule)
+}
+
+// authenticate restores the user's stored session when possible and returns the
+// password BookClass should log in with, or an empty one if the session is
+// reused. Restoring is retried after transient errors. Only a session the site
+// reports as expired is invalidated, so it isn't tried again; any other
+// failure keeps it for the next booking.
+func (bs *BookingScheduler) authenticate(ctx context.Context, client APIClient, user models.User) (string, error) {
+	creds, err := bs.credentials.Credentials(ctx, user)
+	if err != nil {
+		return "", err
+	}
+
+	if len(creds.SessionCookies) == 0 {
+		return creds.Password, nil
+	}
+
+	err = client.LoadStoredSession(ctx, creds.SessionCookies)
+	for retries := 1; err != nil && bs.classify(err) == BookingErrorTransient && retries <= bs.maxRetries; retries++ {
+		bs.logger.Warn("Failed to restore stored session, retrying", "chat_id", user.ChatID, "retry", retries, "error", err)
+		select {
+		case <-time.After(retryBackoff(retries)):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		err = client.LoadStoredSession(ctx, creds.SessionCookies)
+	}
+
+	switch {
+	case err == nil:
+		bs.logger.Info("Reusing stored WODBuster session", "chat_id", user.ChatID)
+		return "", nil
+	case bs.classify(err) == BookingErrorSessionExpired:
+		// The site sent the cookies to the login page, so it ended the session
+		if invalidateErr := bs.storage.InvalidateUserSession(ctx, user.ChatID); invalidateErr != nil {
+			bs.logger.Error("Failed to invalidate stored session", "chat_id", user.ChatID, "error", invalidateErr)
+		}
+		if creds.Password == "" {
+			return "", fmt.Errorf("%w: %w", ErrSessionRestoreFailed, err)
+		}
+	case creds.Password == "":
+		return "", fmt.Errorf("failed to restore stored session: %w", err)
+	}
+
+	bs.logger.Warn("Failed to restore stored session, falling back to password login",
+		"chat_id", user.ChatID,
+		"error", err)
+	return creds.Password, nil
 }
 
-// waitForBookingWindow waits until booking window opens at 12:00 PM
-func (bs *BookingScheduler) waitForBookingWindow(ctx context.Context, booking models.BookingWindow) error {
+// waitForBookingWindow waits until the booking window opens at openTime, on
+// the local clock
+func (bs *BookingScheduler) waitForBookingWindow(ctx context.Context, openTime time.Time) error {
 	now := time.Now()
-	openTime := booking.OpensAt
 
 	if now.Before(openTime) {
 		waitDuration := openTime.Sub(now)
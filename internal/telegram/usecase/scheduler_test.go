@@ -0,0 +1,882 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScheduler(t *testing.T) (*BookingScheduler, *storage.MemoryStorage) {
+	t.Helper()
+
+	store := storage.NewMemoryStorage()
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	return NewBookingScheduler(store, nil, nil, newTestWindow(t), logger), store
+}
+
+// newTestWindow returns the weekly window of Saturdays at 12:00 UTC
+func newTestWindow(t *testing.T) *BookingWindowPolicy {
+	t.Helper()
+
+	window, err := NewBookingWindowPolicy(BookingWindowConfig{
+		Mode:        BookingWindowWeekly,
+		Timezone:    "UTC",
+		OpenWeekday: "Saturday",
+		OpenTime:    "12:00",
+		RunLead:     5 * time.Minute,
+	})
+	require.NoError(t, err)
+	return window
+}
+
+func TestBookingScheduler_ExpandSchedules(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+
+	// Friday before the booking run of Saturday 2025-08-16
+	now := time.Date(2025, 8, 15, 18, 0, 0, 0, time.UTC)
+
+	t.Run("creates one pending attempt per rule and week", func(t *testing.T) {
+		scheduler, store := newTestScheduler(t)
+		require.NoError(t, store.SaveUser(ctx, models.User{
+			ChatID: chatID,
+			ClassBookingSchedules: []models.ClassBookingSchedule{
+				{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod"},
+				{ID: "Friday-19:00-Wod", Day: "Friday", Hour: "19:00", ClassType: "Wod"},
+			},
+		}))
+
+		require.NoError(t, scheduler.ExpandSchedules(ctx, now))
+		// Expanding twice must not duplicate or reset attempts
+		require.NoError(t, store.UpdateBookingStatus(ctx, "42-Monday-07:00-Wod-2025-08-18", "success", ""))
+		require.NoError(t, scheduler.ExpandSchedules(ctx, now))
+
+		monday, exists := store.GetBookingAttempt(ctx, "42-Monday-07:00-Wod-2025-08-18")
+		require.True(t, exists)
+		assert.Equal(t, "success", monday.Status)
+		assert.Equal(t, "Monday-07:00-Wod", monday.ScheduleID)
+
+		pending, err := store.GetAllPendingBookings(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "2025-08-22", pending[0].ClassDate)
+		assert.Equal(t, time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC), pending[0].AttemptTime)
+	})
+
+	t.Run("expands a fresh attempt the following week", func(t *testing.T) {
+		scheduler, store := newTestScheduler(t)
+		require.NoError(t, store.SaveUser(ctx, models.User{
+			ChatID: chatID,
+			ClassBookingSchedules: []models.ClassBookingSchedule{
+				{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod"},
+			},
+		}))
+
+		require.NoError(t, scheduler.ExpandSchedules(ctx, now))
+		require.NoError(t, scheduler.ExpandSchedules(ctx, now.AddDate(0, 0, 7)))
+
+		_, exists := store.GetBookingAttempt(ctx, "42-Monday-07:00-Wod-2025-08-18")
+		assert.True(t, exists)
+		_, exists = store.GetBookingAttempt(ctx, "42-Monday-07:00-Wod-2025-08-25")
+		assert.True(t, exists)
+	})
+
+	t.Run("honours date range and skipped weeks", func(t *testing.T) {
+		scheduler, store := newTestScheduler(t)
+		require.NoError(t, store.SaveUser(ctx, models.User{
+			ChatID: chatID,
+			ClassBookingSchedules: []models.ClassBookingSchedule{
+				{ID: "Monday-07:00-Wod", Day: "Monday", Hour: "07:00", ClassType: "Wod", StartDate: "2025-08-25"},
+				{ID: "Tuesday-07:00-Wod", Day: "Tuesday", Hour: "07:00", ClassType: "Wod", EndDate: "2025-08-18"},
+				{ID: "Friday-07:00-Wod", Day: "Friday", Hour: "07:00", ClassType: "Wod", SkipDates: []string{"2025-08-22"}},
+			},
+		}))
+
+		require.NoError(t, scheduler.ExpandSchedules(ctx, now))
+
+		pending, err := store.GetAllPendingBookings(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+}
+
+func TestBookingScheduler_DueAttempts(t *testing.T) {
+	ctx := context.Background()
+	scheduler, store := newTestScheduler(t)
+	now := time.Date(2025, 8, 16, 11, 55, 0, 0, time.UTC)
+
+	attempts := []models.BookingAttempt{
+		{ID: "due", Status: "pending", ClassDate: "2025-08-18", AttemptTime: time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC)},
+		{ID: "next-week", Status: "pending", ClassDate: "2025-08-25", AttemptTime: time.Date(2025, 8, 23, 12, 0, 0, 0, time.UTC)},
+		{ID: "past", Status: "pending", ClassDate: "2025-08-11", AttemptTime: time.Date(2025, 8, 9, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, attempt := range attempts {
+		require.NoError(t, store.SaveBookingAttempt(ctx, attempt))
+	}
+
+	due := scheduler.dueAttempts(ctx, attempts, now)
+	require.Len(t, due, 1)
+	assert.Equal(t, "due", due[0].ID)
+
+	past, _ := store.GetBookingAttempt(ctx, "past")
+	assert.Equal(t, "expired", past.Status)
+}
+
+// errTestSessionExpired is the error of the mock clients when the site ended
+// the session, as classifyTestError reports it
+var errTestSessionExpired = errors.New("session expired")
+
+// errTestTimeout is the mock clients' transient error
+var errTestTimeout = errors.New("navigation timed out")
+
+// classifyTestError classifies the errors of the mock clients
+func classifyTestError(err error) BookingErrorKind {
+	switch {
+	case errors.Is(err, errTestSessionExpired):
+		return BookingErrorSessionExpired
+	case errors.Is(err, errTestTimeout):
+		return BookingErrorTransient
+	}
+	return ClassifyBookingError(err)
+}
+
+func TestBookingScheduler_PerformBookingForUser(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+
+	session := &http.Cookie{Name: ".WBAuth", Value: "session"}
+	classStart := time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)
+	window := models.BookingWindow{Day: "Monday", Hour: "07:00", ClassType: "Wod", ClassStart: classStart, OpensAt: time.Now()}
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	// expectBooked makes the booking go through on the first try
+	expectBooked := func(client *MockAPIClient) {
+		client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+		client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(true, nil)
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(*MockAPIClient, *MockCredentialProvider)
+		wantTries  int
+		wantErr    error
+	}{
+		{
+			name: "logs in with decrypted password",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+				expectBooked(client)
+			},
+			wantTries: 1,
+		},
+		{
+			name: "books within restored session",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret", SessionCookies: []*http.Cookie{session}}, nil)
+				client.EXPECT().LoadStoredSession(mock.Anything, []*http.Cookie{session}).Return(nil)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "", classStart).Return(nil)
+				expectBooked(client)
+			},
+			wantTries: 1,
+		},
+		{
+			name: "falls back to password when session restore fails",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret", SessionCookies: []*http.Cookie{session}}, nil)
+				client.EXPECT().LoadStoredSession(mock.Anything, []*http.Cookie{session}).Return(errTestSessionExpired)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+				expectBooked(client)
+			},
+			wantTries: 1,
+		},
+		{
+			name: "session restore fails without password",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", SessionCookies: []*http.Cookie{session}}, nil)
+				client.EXPECT().LoadStoredSession(mock.Anything, []*http.Cookie{session}).Return(errTestSessionExpired)
+			},
+			wantErr: ErrSessionRestoreFailed,
+		},
+		{
+			name: "credentials cannot be decrypted",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{}, ErrCredentialsUnavailable)
+			},
+			wantErr: ErrCredentialsUnavailable,
+		},
+		{
+			name: "keeps firing until the class opens",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+				client.EXPECT().ServerTime(mock.Anything).Return(time.Time{}, errors.New("no Date header"))
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, nil).Twice()
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(true, nil).Once()
+			},
+			wantTries: 3,
+		},
+		{
+			name: "gives up when the burst window expires",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+				client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, nil)
+			},
+			wantErr: ErrBurstWindowExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+
+			client := NewMockAPIClient(t)
+			creds := NewMockCredentialProvider(t)
+			tt.setupMocks(client, creds)
+
+			pool := NewMockClientPool(t)
+			pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+			pool.EXPECT().Release(client).Return()
+
+			scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger,
+				WithBurst(50*time.Millisecond, 10*time.Millisecond),
+				WithErrorClassifier(classifyTestError))
+			run, err := scheduler.performBookingForUser(ctx, "attempt", chatID, window)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTries, run.tries)
+		})
+	}
+}
+
+func TestBookingScheduler_PerformBookingForUserAlternatives(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	errFull := errors.New("class is full")
+
+	eight := models.ClassAlternative{Hour: "08:00", ClassType: "Wod"}
+	openBox := models.ClassAlternative{Hour: "07:00", ClassType: "Open box"}
+	classStart := time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)
+	window := models.BookingWindow{Day: "Monday", Hour: "07:00", ClassType: "Wod", ClassStart: classStart, OpensAt: time.Now(),
+		Alternatives: []models.ClassAlternative{eight, openBox}}
+
+	tests := []struct {
+		name            string
+		setupClient     func(*MockAPIClient)
+		wantAlternative *models.ClassAlternative
+		wantErr         error
+	}{
+		{
+			name: "primary booked",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(true, nil)
+			},
+		},
+		{
+			name: "full primary falls back in order",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, errFull)
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "08:00").Return(false, errFull)
+				client.EXPECT().TryBookClass(mock.Anything, "Open box", "07:00").Return(true, nil)
+			},
+			wantAlternative: &openBox,
+		},
+		{
+			name: "primary without Reservar button",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, nil)
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "08:00").Return(true, nil)
+			},
+			wantAlternative: &eight,
+		},
+		{
+			name: "no alternative left",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, errFull)
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "08:00").Return(false, errFull)
+				client.EXPECT().TryBookClass(mock.Anything, "Open box", "07:00").Return(false, nil)
+			},
+			wantErr: errFull,
+		},
+		{
+			name: "transient errors don't fall back",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, context.DeadlineExceeded)
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+
+			client := NewMockAPIClient(t)
+			client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+			client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+			tt.setupClient(client)
+			creds := NewMockCredentialProvider(t)
+			creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+
+			pool := NewMockClientPool(t)
+			pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+			pool.EXPECT().Release(client).Return()
+
+			scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger,
+				WithRetries(0, time.Minute),
+				WithErrorClassifier(func(err error) BookingErrorKind {
+					if errors.Is(err, errFull) {
+						return BookingErrorClassFull
+					}
+					return ClassifyBookingError(err)
+				}))
+			run, err := scheduler.performBookingForUser(ctx, "attempt", chatID, window)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlternative, run.alternative)
+		})
+	}
+}
+
+func TestBookingScheduler_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	cookies := []*http.Cookie{{Name: ".WBAuth", Value: "session"}, {Name: "ASP.NET_SessionId", Value: "aspnet"}}
+
+	tests := []struct {
+		name         string
+		password     string
+		setupClient  func(*MockAPIClient)
+		wantPassword string
+		wantErr      error
+		wantValid    bool // Whether the stored session is kept
+	}{
+		{
+			name:     "expired session is invalidated",
+			password: "secret",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, cookies).Return(errTestSessionExpired).Once()
+			},
+			wantPassword: "secret",
+		},
+		{
+			name: "expired session without password",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, cookies).Return(errTestSessionExpired).Once()
+			},
+			wantErr: ErrSessionRestoreFailed,
+		},
+		{
+			name: "transient error is retried",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, cookies).Return(errTestTimeout).Once()
+				client.EXPECT().LoadStoredSession(mock.Anything, cookies).Return(nil).Once()
+			},
+			wantValid: true,
+		},
+		{
+			name:     "session is kept when WODBuster doesn't respond",
+			password: "secret",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, cookies).Return(errTestTimeout).Times(2)
+			},
+			wantPassword: "secret",
+			wantValid:    true,
+		},
+		{
+			name: "session is kept without password",
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, cookies).Return(errTestTimeout).Times(2)
+			},
+			wantErr:   errTestTimeout,
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUserSession(ctx, models.NewUserSession(chatID, cookies, time.Now())))
+
+			creds := NewMockCredentialProvider(t)
+			creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: tt.password, SessionCookies: cookies}, nil)
+			client := NewMockAPIClient(t)
+			tt.setupClient(client)
+
+			scheduler := NewBookingScheduler(store, NewMockClientPool(t), creds, newTestWindow(t), logger,
+				WithRetries(1, time.Minute),
+				WithErrorClassifier(classifyTestError))
+			password, err := scheduler.authenticate(ctx, client, models.User{ChatID: chatID, Email: "a@b.com"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantPassword, password)
+			}
+
+			// An invalidated session is not tried again by the next booking
+			session, ok := store.GetUserSession(ctx, chatID)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantValid, session.Valid(time.Now()))
+		})
+	}
+}
+
+func TestBookingScheduler_PerformBookingForUserRetries(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	const attemptID = "42-Monday-07:00-Wod-2025-08-18"
+
+	session := &http.Cookie{Name: ".WBAuth", Value: "session"}
+	classStart := time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)
+	window := models.BookingWindow{Day: "Monday", Hour: "07:00", ClassType: "Wod", ClassStart: classStart, OpensAt: time.Now()}
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	errTimeout := errors.New("navigation timed out")
+	errSessionExpired := errors.New("session expired")
+	errFull := errors.New("class is full")
+	classify := func(err error) BookingErrorKind {
+		switch {
+		case errors.Is(err, errTimeout):
+			return BookingErrorTransient
+		case errors.Is(err, errSessionExpired):
+			return BookingErrorSessionExpired
+		case errors.Is(err, errFull):
+			return BookingErrorClassFull
+		}
+		return ClassifyBookingError(err)
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*MockAPIClient, *MockCredentialProvider)
+		wantRetries int
+		wantErr     error
+	}{
+		{
+			name: "retries a transient error",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(errTimeout).Once()
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil).Once()
+				client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(true, nil)
+			},
+			wantRetries: 1,
+		},
+		{
+			name: "logs in again when the session expires",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret", SessionCookies: []*http.Cookie{session}}, nil)
+				client.EXPECT().LoadStoredSession(mock.Anything, []*http.Cookie{session}).Return(nil)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "", classStart).Return(nil)
+				client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, errSessionExpired).Once()
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(true, nil).Once()
+			},
+			wantRetries: 1,
+		},
+		{
+			name: "does not retry a full class",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+				client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+				client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, errFull).Once()
+			},
+			wantErr: errFull,
+		},
+		{
+			name: "gives up after the maximum retries",
+			setupMocks: func(client *MockAPIClient, creds *MockCredentialProvider) {
+				creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+				client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(errTimeout).Times(3)
+			},
+			wantRetries: 2,
+			wantErr:     errTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+			require.NoError(t, store.SaveBookingAttempt(ctx, models.BookingAttempt{ID: attemptID, ChatID: chatID, Status: "active"}))
+
+			client := NewMockAPIClient(t)
+			creds := NewMockCredentialProvider(t)
+			tt.setupMocks(client, creds)
+
+			pool := NewMockClientPool(t)
+			pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+			pool.EXPECT().Release(client).Return()
+
+			scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger,
+				WithRetries(2, time.Minute),
+				WithErrorClassifier(classify))
+			run, err := scheduler.performBookingForUser(ctx, attemptID, chatID, window)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRetries, run.retries)
+
+			attempt, _ := store.GetBookingAttempt(ctx, attemptID)
+			assert.Equal(t, tt.wantRetries, attempt.RetryCount)
+		})
+	}
+}
+
+func TestBookingScheduler_ProcessUserBookingRecordsTiming(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+	classStart := time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)
+	attempt := models.BookingAttempt{
+		ID:          "42-Monday-07:00-Wod-2025-08-18",
+		ChatID:      chatID,
+		ClassDate:   "2025-08-18",
+		Day:         "Monday",
+		Hour:        "07:00",
+		ClassType:   "Wod",
+		Status:      "pending",
+		AttemptTime: time.Now(),
+	}
+	require.NoError(t, store.SaveBookingAttempt(ctx, attempt))
+
+	client := NewMockAPIClient(t)
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+	client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+	// The site's clock runs two seconds ahead, so the window is already open
+	client.EXPECT().ServerTime(mock.Anything).RunAndReturn(func(context.Context) (time.Time, error) {
+		return time.Now().Add(2 * time.Second), nil
+	})
+	client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(true, nil)
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+	pool.EXPECT().Release(client).Return()
+
+	notifier := NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, chatID, "✅ Booked Wod on Monday 2025-08-18 at 07:00.").Return(nil)
+
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger)
+	scheduler.SetNotifier(notifier)
+	result := scheduler.processUserBooking(ctx, attempt)
+	assert.Equal(t, "success", result.Status)
+
+	saved, exists := store.GetBookingAttempt(ctx, attempt.ID)
+	require.True(t, exists)
+	assert.Equal(t, "success", saved.Status)
+	assert.Equal(t, 1, saved.BookingTries)
+	assert.InDelta(t, 2000, saved.ClockSkewMs, 500)
+	assert.GreaterOrEqual(t, saved.LatencyMs, int64(2000))
+}
+
+func TestBookingScheduler_ActiveBookingsByAttempt(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+	monday := models.BookingAttempt{ID: "42-Monday-07:00-Wod-2025-08-18", ChatID: chatID, ClassDate: "2025-08-18",
+		Day: "Monday", Hour: "07:00", ClassType: "Wod", Status: "pending", AttemptTime: time.Now()}
+	wednesday := models.BookingAttempt{ID: "42-Wednesday-07:00-Wod-2025-08-20", ChatID: chatID, ClassDate: "2025-08-20",
+		Day: "Wednesday", Hour: "07:00", ClassType: "Wod", Status: "pending", AttemptTime: time.Now()}
+
+	// Both bookings wait for their class to open until they are cancelled
+	started := make(chan struct{}, 2)
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).RunAndReturn(func(context.Context) (APIClient, error) {
+		client := NewMockAPIClient(t)
+		client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", mock.Anything).
+			RunAndReturn(func(ctx context.Context, _, _ string, _ time.Time) error {
+				started <- struct{}{}
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		return client, nil
+	})
+	pool.EXPECT().Release(mock.Anything).Return()
+
+	// Cancelled bookings are not reported back to the user
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger)
+	scheduler.SetNotifier(NewMockNotifier(t))
+
+	results := make(chan models.BookingAttempt, 2)
+	for _, attempt := range []models.BookingAttempt{wednesday, monday} {
+		go func() { results <- scheduler.processUserBooking(ctx, attempt) }()
+	}
+	<-started
+	<-started
+
+	active := scheduler.GetActiveBookings(chatID)
+	require.Len(t, active, 2)
+	assert.Equal(t, monday.ID, active[0].AttemptID)
+	assert.Equal(t, wednesday.ID, active[1].AttemptID)
+
+	assert.False(t, scheduler.CancelBooking(7, monday.ID), "another user's booking")
+	assert.True(t, scheduler.CancelBooking(chatID, wednesday.ID))
+	assert.False(t, scheduler.CancelBooking(chatID, wednesday.ID), "already cancelled")
+
+	result := <-results
+	assert.Equal(t, wednesday.ID, result.ID)
+	assert.Equal(t, "cancelled", result.Status)
+	require.Len(t, scheduler.GetActiveBookings(chatID), 1)
+
+	assert.True(t, scheduler.CancelBooking(chatID, monday.ID))
+	<-results
+	assert.Empty(t, scheduler.GetActiveBookings(chatID))
+
+	saved, exists := store.GetBookingAttempt(ctx, monday.ID)
+	require.True(t, exists)
+	assert.Equal(t, "cancelled", saved.Status)
+}
+
+func TestBookingScheduler_ProcessAllBookingsClaimsAttempts(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+	attempt := models.BookingAttempt{ID: "42-Monday-07:00-Wod", ChatID: chatID, ClassDate: time.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+		Day: "Monday", Hour: "07:00", ClassType: "Wod", Status: "pending", AttemptTime: time.Now()}
+	require.NoError(t, store.SaveBookingAttempt(ctx, attempt))
+
+	// The booking waits for its class to open until it is cancelled
+	started := make(chan struct{})
+	client := NewMockAPIClient(t)
+	client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", mock.Anything).
+		RunAndReturn(func(ctx context.Context, _, _ string, _ time.Time) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+
+	// Only the first run starts the booking
+	pool := NewMockClientPool(t)
+	pool.EXPECT().WarmUp(mock.Anything, 1).Return(nil).Once()
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return()
+
+	summarized := make(chan struct{})
+	notifier := NewMockNotifier(t)
+	notifier.EXPECT().Notify(mock.Anything, chatID, mock.Anything).RunAndReturn(func(context.Context, int64, string) error {
+		close(summarized)
+		return nil
+	})
+
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger)
+	scheduler.SetNotifier(notifier)
+
+	// The run returns while its booking waits, and the next run leaves it alone
+	scheduler.processAllBookings()
+	<-started
+	saved, exists := store.GetBookingAttempt(ctx, attempt.ID)
+	require.True(t, exists)
+	assert.Equal(t, "active", saved.Status)
+	scheduler.processAllBookings()
+
+	require.True(t, scheduler.CancelBooking(chatID, attempt.ID))
+	<-summarized
+	saved, _ = store.GetBookingAttempt(ctx, attempt.ID)
+	assert.Equal(t, "cancelled", saved.Status)
+}
+
+func TestBookingScheduler_PerformBookingForUserWaitlist(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+
+	classStart := time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)
+	window := models.BookingWindow{Day: "Monday", Hour: "07:00", ClassType: "Wod", ClassStart: classStart, OpensAt: time.Now()}
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	errFull := errors.New("class is full")
+	classify := func(err error) BookingErrorKind {
+		if errors.Is(err, errFull) {
+			return BookingErrorClassFull
+		}
+		return ClassifyBookingError(err)
+	}
+
+	tests := []struct {
+		name         string
+		setupMocks   func(*MockAPIClient)
+		wantWaitlist string
+	}{
+		{
+			name: "joins the site's waiting list",
+			setupMocks: func(client *MockAPIClient) {
+				client.EXPECT().JoinWaitlist(mock.Anything, "Wod", "07:00").Return(true, nil)
+			},
+			wantWaitlist: "waitlisted",
+		},
+		{
+			name: "monitors a class without waiting list",
+			setupMocks: func(client *MockAPIClient) {
+				client.EXPECT().JoinWaitlist(mock.Anything, "Wod", "07:00").Return(false, nil)
+			},
+			wantWaitlist: "monitoring",
+		},
+		{
+			name: "monitors the class when joining fails",
+			setupMocks: func(client *MockAPIClient) {
+				client.EXPECT().JoinWaitlist(mock.Anything, "Wod", "07:00").Return(false, errors.New("navigation timed out"))
+			},
+			wantWaitlist: "monitoring",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+
+			client := NewMockAPIClient(t)
+			creds := NewMockCredentialProvider(t)
+			creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+			client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+			client.EXPECT().ServerTime(mock.Anything).Return(time.Now(), nil)
+			client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(false, errFull)
+			tt.setupMocks(client)
+
+			pool := NewMockClientPool(t)
+			pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+			pool.EXPECT().Release(client).Return()
+
+			scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger,
+				WithErrorClassifier(classify),
+				WithWaitlist(time.Minute, time.Hour))
+			run, err := scheduler.performBookingForUser(ctx, "attempt", chatID, window)
+			assert.ErrorIs(t, err, errFull)
+			assert.Equal(t, tt.wantWaitlist, run.waitlist)
+		})
+	}
+}
+
+func TestBookingScheduler_CheckFullClass(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+
+	classStart := time.Date(2025, 8, 18, 7, 0, 0, 0, time.UTC)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	errFull := errors.New("class is full")
+	classify := func(err error) BookingErrorKind {
+		if errors.Is(err, errFull) {
+			return BookingErrorClassFull
+		}
+		return ClassifyBookingError(err)
+	}
+
+	// expectCheck reloads the class, which TryBookClass reports with the given result
+	expectCheck := func(booked bool, err error) func(*MockAPIClient, *MockCredentialProvider, *MockClientPool) {
+		return func(client *MockAPIClient, creds *MockCredentialProvider, pool *MockClientPool) {
+			pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+			pool.EXPECT().Release(client).Return()
+			creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil)
+			client.EXPECT().PrepareBooking(mock.Anything, "a@b.com", "secret", classStart).Return(nil)
+			client.EXPECT().TryBookClass(mock.Anything, "Wod", "07:00").Return(booked, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		setupMocks func(*MockAPIClient, *MockCredentialProvider, *MockClientPool)
+		wantStatus string
+		wantNotify bool
+	}{
+		{
+			name:       "books the class when a spot frees up",
+			now:        classStart.Add(-24 * time.Hour),
+			setupMocks: expectCheck(true, nil),
+			wantStatus: "success",
+			wantNotify: true,
+		},
+		{
+			name:       "keeps monitoring a full class",
+			now:        classStart.Add(-24 * time.Hour),
+			setupMocks: expectCheck(false, errFull),
+			wantStatus: "monitoring",
+		},
+		{
+			name:       "stops waiting at the cutoff",
+			now:        classStart.Add(-time.Hour),
+			setupMocks: func(*MockAPIClient, *MockCredentialProvider, *MockClientPool) {},
+			wantStatus: "failed",
+			wantNotify: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+			attempt := models.BookingAttempt{
+				ID:        "42-Monday-07:00-Wod-2025-08-18",
+				ChatID:    chatID,
+				ClassDate: "2025-08-18",
+				Day:       "Monday",
+				Hour:      "07:00",
+				ClassType: "Wod",
+				Status:    "monitoring",
+			}
+			require.NoError(t, store.SaveBookingAttempt(ctx, attempt))
+
+			client := NewMockAPIClient(t)
+			creds := NewMockCredentialProvider(t)
+			pool := NewMockClientPool(t)
+			tt.setupMocks(client, creds, pool)
+
+			notifier := NewMockNotifier(t)
+			if tt.wantNotify {
+				notifier.EXPECT().Notify(mock.Anything, chatID, mock.Anything).Return(nil).Once()
+			}
+
+			scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger,
+				WithErrorClassifier(classify),
+				WithWaitlist(time.Minute, 2*time.Hour))
+			scheduler.SetNotifier(notifier)
+			scheduler.checkFullClass(ctx, attempt, tt.now)
+
+			saved, _ := store.GetBookingAttempt(ctx, attempt.ID)
+			assert.Equal(t, tt.wantStatus, saved.Status)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+)
+
+// SessionStatus is what probing a user's WODBuster session found
+type SessionStatus struct {
+	Alive     bool      // The stored or refreshed session opens the calendar
+	Refreshed bool      // The stored session was dead and a new one was logged in
+	ExpiresAt time.Time // When the session's cookies expire, zero if they don't
+}
+
+// ProbeSession restores the user's stored session in a pooled client and
+// checks the calendar opens with it. A session the site reports as expired is
+// invalidated and, when the password can be decrypted, replaced by a fresh
+// login. Other failures leave the stored session alone and are returned.
+func (bs *BookingScheduler) ProbeSession(ctx context.Context, chatID int64) (SessionStatus, error) {
+	user, exists := bs.storage.GetUser(ctx, chatID)
+	if !exists {
+		return SessionStatus{}, ErrUserNotFound
+	}
+
+	creds, err := bs.credentials.Credentials(ctx, user)
+	if err != nil {
+		return SessionStatus{}, err
+	}
+
+	client, err := bs.clientPool.Acquire(ctx)
+	if err != nil {
+		return SessionStatus{}, fmt.Errorf("failed to acquire browser context: %w", err)
+	}
+	defer bs.clientPool.Release(client)
+
+	if len(creds.SessionCookies) > 0 {
+		err := client.LoadStoredSession(ctx, creds.SessionCookies)
+		if err == nil {
+			return SessionStatus{Alive: true, ExpiresAt: models.SessionExpiry(creds.SessionCookies)}, nil
+		}
+		if bs.classify(err) != BookingErrorSessionExpired {
+			return SessionStatus{}, fmt.Errorf("failed to check stored session: %w", err)
+		}
+
+		bs.logger.Info("Stored session is dead", "chat_id", chatID, "error", err)
+		if err := bs.storage.InvalidateUserSession(ctx, chatID); err != nil {
+			bs.logger.Error("Failed to invalidate stored session", "chat_id", chatID, "error", err)
+		}
+	}
+
+	if creds.Password == "" {
+		return SessionStatus{}, nil
+	}
+
+	sessionCookie, err := client.LogIn(ctx, creds.Email, creds.Password)
+	if err != nil {
+		return SessionStatus{}, fmt.Errorf("%w: %w", ErrInvalidWODBusterLogin, err)
+	}
+	// Without a session cookie the login didn't open a new session, and the
+	// client's cookies may still be the dead ones
+	cookies := client.GetCookies()
+	if sessionCookie == nil || len(cookies) == 0 {
+		return SessionStatus{}, fmt.Errorf("%w: no session cookie after login", ErrInvalidWODBusterLogin)
+	}
+
+	session := models.NewUserSession(chatID, cookies, time.Now())
+	if err := bs.storage.SaveUserSession(ctx, session); err != nil {
+		return SessionStatus{}, fmt.Errorf("failed to save refreshed session: %w", err)
+	}
+
+	bs.logger.Info("Refreshed WODBuster session", "chat_id", chatID)
+	return SessionStatus{Alive: true, Refreshed: true, ExpiresAt: session.ExpiresAt}, nil
+}
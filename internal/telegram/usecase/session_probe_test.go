@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingScheduler_ProbeSession(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	expires := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+	stored := []*http.Cookie{{Name: ".WBAuth", Value: "old", Expires: expires}}
+	fresh := []*http.Cookie{{Name: ".WBAuth", Value: "new", Expires: expires.Add(time.Hour)}}
+
+	tests := []struct {
+		name             string
+		credentials      Credentials
+		setupClient      func(*MockAPIClient)
+		wantStatus       SessionStatus
+		wantErr          error
+		wantStoredCookie string // Value of the stored session cookie afterwards, empty if invalid
+	}{
+		{
+			name:        "stored session is alive",
+			credentials: Credentials{Email: "a@b.com", Password: "secret", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(nil)
+			},
+			wantStatus:       SessionStatus{Alive: true, ExpiresAt: expires},
+			wantStoredCookie: "old",
+		},
+		{
+			name:        "dead session is refreshed",
+			credentials: Credentials{Email: "a@b.com", Password: "secret", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(errTestSessionExpired)
+				client.EXPECT().LogIn(mock.Anything, "a@b.com", "secret").Return(fresh[0], nil)
+				client.EXPECT().GetCookies().Return(fresh)
+			},
+			wantStatus:       SessionStatus{Alive: true, Refreshed: true, ExpiresAt: expires.Add(time.Hour)},
+			wantStoredCookie: "new",
+		},
+		{
+			name:        "dead session without password",
+			credentials: Credentials{Email: "a@b.com", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(errTestSessionExpired)
+			},
+			wantStatus: SessionStatus{},
+		},
+		{
+			name:        "stored session can't be checked",
+			credentials: Credentials{Email: "a@b.com", Password: "secret", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(errTestTimeout)
+			},
+			wantErr:          errTestTimeout,
+			wantStoredCookie: "old",
+		},
+		{
+			name:        "login opens no new session",
+			credentials: Credentials{Email: "a@b.com", Password: "secret", SessionCookies: stored},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LoadStoredSession(mock.Anything, stored).Return(errTestSessionExpired)
+				client.EXPECT().LogIn(mock.Anything, "a@b.com", "secret").Return(nil, nil)
+				client.EXPECT().GetCookies().Return(stored).Maybe()
+			},
+			wantErr: ErrInvalidWODBusterLogin,
+		},
+		{
+			name:        "refresh login fails",
+			credentials: Credentials{Email: "a@b.com", Password: "changed"},
+			setupClient: func(client *MockAPIClient) {
+				client.EXPECT().LogIn(mock.Anything, "a@b.com", "changed").Return(nil, errors.New("invalid credentials"))
+			},
+			wantErr:          ErrInvalidWODBusterLogin,
+			wantStoredCookie: "old",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewMemoryStorage()
+			require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+			require.NoError(t, store.SaveUserSession(ctx, models.NewUserSession(chatID, stored, time.Now())))
+
+			client := NewMockAPIClient(t)
+			tt.setupClient(client)
+			creds := NewMockCredentialProvider(t)
+			creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(tt.credentials, nil)
+
+			pool := NewMockClientPool(t)
+			pool.EXPECT().Acquire(mock.Anything).Return(client, nil)
+			pool.EXPECT().Release(client).Return()
+
+			scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger, WithErrorClassifier(classifyTestError))
+			status, err := scheduler.ProbeSession(ctx, chatID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, status)
+			}
+
+			// The dead cookies are never saved as a new session
+			session, _ := store.GetUserSession(ctx, chatID)
+			if tt.wantStoredCookie == "" {
+				assert.False(t, session.Valid(time.Now()))
+				return
+			}
+			require.True(t, session.Valid(time.Now()))
+			assert.Equal(t, tt.wantStoredCookie, session.Cookies[0].Value)
+		})
+	}
+}
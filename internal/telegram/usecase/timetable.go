@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+)
+
+// timetableCacheTTL is how long a timetable read from WODBuster is shown
+// again before it is read anew, so browsing days doesn't hit the site each time
+const timetableCacheTTL = 5 * time.Minute
+
+// timetableCache holds the timetables read for each user by date (YYYY-MM-DD).
+// They are kept per user because availability shows the user's own bookings.
+type timetableCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[timetableKey]timetableEntry
+}
+
+type timetableKey struct {
+	chatID int64
+	date   string
+}
+
+type timetableEntry struct {
+	classes   []models.ClassSchedule
+	fetchedAt time.Time
+}
+
+func newTimetableCache(ttl time.Duration) *timetableCache {
+	return &timetableCache{
+		ttl:     ttl,
+		entries: make(map[timetableKey]timetableEntry),
+	}
+}
+
+func (c *timetableCache) get(chatID int64, date string, now time.Time) ([]models.ClassSchedule, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := timetableKey{chatID: chatID, date: date}
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if now.Sub(entry.fetchedAt) >= c.ttl {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.classes, true
+}
+
+// put caches the timetable and drops the expired ones, so the timetables of
+// past weeks and of users who stopped browsing don't pile up
+func (c *timetableCache) put(chatID int64, date string, classes []models.ClassSchedule, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) >= c.ttl {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[timetableKey{chatID: chatID, date: date}] = timetableEntry{classes: classes, fetchedAt: now}
+}
+
+// GetTimetable returns the classes of the current or the next week, in
+// timetable order, or only those of the day when it is not empty. Timetables
+// are read through the user's session and cached briefly; their class types
+// are added to the gym's catalogue.
+func (m *Manager) GetTimetable(ctx context.Context, chatID int64, day models.Day, nextWeek bool) ([]models.ClassSchedule, error) {
+	now := time.Now()
+
+	var dates []time.Time
+	for _, date := range m.bookingScheduler.window.WeekDates(now, nextWeek) {
+		if day == "" || models.DayOf(date.Weekday()) == day {
+			dates = append(dates, date)
+		}
+	}
+
+	var missing []time.Time
+	for _, date := range dates {
+		if _, ok := m.timetables.get(chatID, date.Format("2006-01-02"), now); !ok {
+			missing = append(missing, date)
+		}
+	}
+
+	fetched := map[string][]models.ClassSchedule{}
+	if len(missing) > 0 {
+		var err error
+		fetched, err = m.bookingScheduler.GetTimetable(ctx, chatID, missing)
+		if err != nil {
+			return nil, err
+		}
+		for date, classes := range fetched {
+			m.timetables.put(chatID, date, classes, now)
+			m.classCatalogue.Learn(classes, now)
+		}
+	}
+
+	var timetable []models.ClassSchedule
+	for _, date := range dates {
+		key := date.Format("2006-01-02")
+		classes, ok := fetched[key]
+		if !ok {
+			classes, _ = m.timetables.get(chatID, key, now)
+		}
+		timetable = append(timetable, classes...)
+	}
+	return timetable, nil
+}
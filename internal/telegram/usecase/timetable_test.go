@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_GetTimetable(t *testing.T) {
+	ctx := context.Background()
+	const chatID int64 = 42
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	store := storage.NewMemoryStorage()
+	require.NoError(t, store.SaveUser(ctx, models.User{ChatID: chatID, Email: "a@b.com"}))
+
+	// Each date is read once, the second request is served from the cache
+	client := NewMockAPIClient(t)
+	client.EXPECT().GetAvailableClasses(mock.Anything, "a@b.com", "secret", mock.Anything).
+		RunAndReturn(func(_ context.Context, _, _ string, classDate time.Time) ([]models.ClassSchedule, error) {
+			day := models.DayOf(classDate.Weekday())
+			if day != models.DayMonday && day != models.DayWednesday {
+				return nil, nil
+			}
+			return []models.ClassSchedule{{Day: day, Hour: "07:00", ClassType: "Yoga Flow", Available: true}}, nil
+		}).Times(7)
+
+	creds := NewMockCredentialProvider(t)
+	creds.EXPECT().Credentials(mock.Anything, mock.Anything).Return(Credentials{Email: "a@b.com", Password: "secret"}, nil).Once()
+
+	pool := NewMockClientPool(t)
+	pool.EXPECT().Acquire(mock.Anything).Return(client, nil).Once()
+	pool.EXPECT().Release(client).Return().Once()
+
+	catalogue := NewClassCatalogue("https://box.wodbuster.com", []string{"Wod"}, nil, time.Hour)
+	scheduler := NewBookingScheduler(store, pool, creds, newTestWindow(t), logger)
+	manager := NewManager(store, pool, nil, scheduler, catalogue, logger)
+
+	timetable, err := manager.GetTimetable(ctx, chatID, "", true)
+	require.NoError(t, err)
+	require.Len(t, timetable, 2)
+	assert.Equal(t, models.DayMonday, timetable[0].Day)
+	assert.Equal(t, models.DayWednesday, timetable[1].Day)
+
+	timetable, err = manager.GetTimetable(ctx, chatID, models.DayWednesday, true)
+	require.NoError(t, err)
+	require.Len(t, timetable, 1)
+	assert.Equal(t, models.DayWednesday, timetable[0].Day)
+
+	classType, err := catalogue.Resolve("yoga flow")
+	require.NoError(t, err)
+	assert.Equal(t, "Yoga Flow", classType, "timetables feed the class catalogue")
+}
+
+func TestTimetableCache_PutDropsExpired(t *testing.T) {
+	cache := newTimetableCache(5 * time.Minute)
+	now := time.Date(2025, 8, 18, 10, 0, 0, 0, time.UTC)
+	classes := []models.ClassSchedule{{Day: models.DayMonday, Hour: "07:00", ClassType: "Wod"}}
+
+	cache.put(41, "2025-08-18", classes, now)
+	cache.put(42, "2025-08-18", classes, now.Add(3*time.Minute))
+
+	// Entries never read again are dropped once expired
+	cache.put(42, "2025-08-25", classes, now.Add(6*time.Minute))
+	assert.Len(t, cache.entries, 2)
+	_, ok := cache.get(42, "2025-08-18", now.Add(6*time.Minute))
+	assert.True(t, ok)
+	_, ok = cache.get(41, "2025-08-18", now.Add(6*time.Minute))
+	assert.False(t, ok)
+}
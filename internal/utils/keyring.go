@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrUnknownKey   = errors.New("unknown encryption key")
+	ErrInvalidKeyID = errors.New("invalid encryption key ID")
+)
+
+// envelopeVersion prefixes the ciphertexts made by a Keyring. Ciphertexts
+// without it were encrypted by EncryptPassword with one of the keys directly.
+const envelopeVersion = "v1"
+
+// dataKeySize is the size of the random key each password is encrypted with
+const dataKeySize = 32
+
+// Keyring encrypts passwords with envelope encryption: each password is
+// encrypted with its own random data key, which is in turn encrypted with the
+// primary key of the keyring. Ciphertexts name the key that encrypted their
+// data key, as "v1.<key ID>.<encrypted data key>.<encrypted password>", so
+// any key of the keyring can decrypt them and the primary key can be rotated
+// by only re-encrypting the data keys.
+type Keyring struct {
+	primary string
+	keys    map[string]string
+}
+
+// NewKeyring creates a keyring from keys by ID, encrypting with the primary
+func NewKeyring(primary string, keys map[string]string) (*Keyring, error) {
+	if _, ok := keys[primary]; !ok {
+		return nil, fmt.Errorf("%w: primary key %q", ErrUnknownKey, primary)
+	}
+
+	k := &Keyring{primary: primary, keys: make(map[string]string, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ".") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidKeyID, id)
+		}
+		if n := len(key); n != 16 && n != 24 && n != 32 {
+			return nil, fmt.Errorf("key %q: %w", id, ErrInvalidKeySize)
+		}
+		k.keys[id] = key
+	}
+	return k, nil
+}
+
+// Primary returns the ID of the key new ciphertexts are encrypted with
+func (k *Keyring) Primary() string {
+	return k.primary
+}
+
+// Encrypt encrypts the password under a new data key wrapped by the primary key
+func (k *Keyring) Encrypt(password string) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	encryptedPassword, err := EncryptPassword(password, string(dataKey))
+	if err != nil {
+		return "", err
+	}
+	return k.wrap(string(dataKey), encryptedPassword)
+}
+
+// Decrypt decrypts a ciphertext made by Encrypt with any key of the keyring,
+// or one made by EncryptPassword with one of the keys
+func (k *Keyring) Decrypt(ciphertext string) (string, error) {
+	if !isEnvelope(ciphertext) {
+		return k.decryptLegacy(ciphertext)
+	}
+
+	dataKey, encryptedPassword, err := k.unwrap(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return DecryptPassword(encryptedPassword, dataKey)
+}
+
+// NeedsRotation reports whether the ciphertext isn't under the primary key
+func (k *Keyring) NeedsRotation(ciphertext string) bool {
+	id, _, ok := strings.Cut(strings.TrimPrefix(ciphertext, envelopeVersion+"."), ".")
+	return !isEnvelope(ciphertext) || !ok || id != k.primary
+}
+
+// Rotate returns the ciphertext with its data key wrapped by the primary key.
+// Ciphertexts made by EncryptPassword are encrypted anew.
+func (k *Keyring) Rotate(ciphertext string) (string, error) {
+	if !k.NeedsRotation(ciphertext) {
+		return ciphertext, nil
+	}
+	if !isEnvelope(ciphertext) {
+		password, err := k.decryptLegacy(ciphertext)
+		if err != nil {
+			return "", err
+		}
+		return k.Encrypt(password)
+	}
+
+	dataKey, encryptedPassword, err := k.unwrap(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return k.wrap(dataKey, encryptedPassword)
+}
+
+// wrap encrypts the data key with the primary key and joins it with the
+// password it encrypted
+func (k *Keyring) wrap(dataKey, encryptedPassword string) (string, error) {
+	encryptedDataKey, err := EncryptPassword(dataKey, k.keys[k.primary])
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt data key: %w", err)
+	}
+	return strings.Join([]string{envelopeVersion, k.primary, encryptedDataKey, encryptedPassword}, "."), nil
+}
+
+// unwrap splits the ciphertext and decrypts its data key
+func (k *Keyring) unwrap(ciphertext string) (dataKey, encryptedPassword string, err error) {
+	parts := strings.Split(ciphertext, ".")
+	if len(parts) != 4 {
+		return "", "", ErrInvalidCiphertext
+	}
+
+	id, encryptedDataKey := parts[1], parts[2]
+	key, ok := k.keys[id]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	dataKey, err = DecryptPassword(encryptedDataKey, key)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	return dataKey, parts[3], nil
+}
+
+// decryptLegacy decrypts a ciphertext made by EncryptPassword, trying the
+// primary key first. AES-GCM authenticates, so a wrong key fails instead of
+// decrypting to garbage.
+func (k *Keyring) decryptLegacy(ciphertext string) (string, error) {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		if id != k.primary {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var err error
+	for _, id := range append([]string{k.primary}, ids...) {
+		var password string
+		if password, err = DecryptPassword(ciphertext, k.keys[id]); err == nil {
+			return password, nil
+		}
+	}
+	return "", err
+}
+
+func isEnvelope(ciphertext string) bool {
+	return strings.HasPrefix(ciphertext, envelopeVersion+".")
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	oldKey = "0123456789abcdef0123456789abcdef"
+	newKey = "fedcba9876543210fedcba9876543210"
+)
+
+func TestNewKeyring(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary string
+		keys    map[string]string
+		wantErr error
+	}{
+		{"valid", "new", map[string]string{"old": oldKey, "new": newKey}, nil},
+		{"unknown primary", "other", map[string]string{"old": oldKey}, ErrUnknownKey},
+		{"invalid key size", "old", map[string]string{"old": "short"}, ErrInvalidKeySize},
+		{"invalid key ID", "old", map[string]string{"old": oldKey, "v.2": newKey}, ErrInvalidKeyID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyring(tt.primary, tt.keys)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewKeyring() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	keyring, err := NewKeyring("old", map[string]string{"old": oldKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := keyring.Encrypt("пароль123")
+	if err != nil {
+		t.Fatal("Encrypt() failed:", err)
+	}
+	if !strings.HasPrefix(encrypted, "v1.old.") {
+		t.Errorf("Encrypt() = %q, want it under key old", encrypted)
+	}
+
+	decrypted, err := keyring.Decrypt(encrypted)
+	if err != nil {
+		t.Fatal("Decrypt() failed:", err)
+	}
+	if decrypted != "пароль123" {
+		t.Errorf("Decrypt() = %q, want %q", decrypted, "пароль123")
+	}
+
+	other, err := NewKeyring("new", map[string]string{"new": newKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Decrypt(encrypted); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt() with another keyring error = %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	before, err := NewKeyring("old", map[string]string{"old": oldKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := NewKeyring("new", map[string]string{"old": oldKey, "new": newKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envelope, err := before.Encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := EncryptPassword("secret", oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, ciphertext := range map[string]string{"envelope": envelope, "legacy": legacy} {
+		t.Run(name, func(t *testing.T) {
+			if !after.NeedsRotation(ciphertext) {
+				t.Fatal("NeedsRotation() = false, want true")
+			}
+
+			// Ciphertexts under any key of the keyring stay readable
+			if decrypted, err := after.Decrypt(ciphertext); err != nil || decrypted != "secret" {
+				t.Fatalf("Decrypt() = %q, %v, want secret", decrypted, err)
+			}
+
+			rotated, err := after.Rotate(ciphertext)
+			if err != nil {
+				t.Fatal("Rotate() failed:", err)
+			}
+			if after.NeedsRotation(rotated) {
+				t.Errorf("NeedsRotation() after Rotate() = true, want false for %q", rotated)
+			}
+
+			// The old key can be retired once everything is rotated
+			retired, err := NewKeyring("new", map[string]string{"new": newKey})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decrypted, err := retired.Decrypt(rotated); err != nil || decrypted != "secret" {
+				t.Errorf("Decrypt() without the old key = %q, %v, want secret", decrypted, err)
+			}
+		})
+	}
+}
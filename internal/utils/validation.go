@@ -9,11 +9,10 @@ import (
 )
 
 var (
-	ErrInvalidEmail     = errors.New("invalid email format")
-	ErrInvalidDay       = errors.New("invalid day name")
-	ErrInvalidTime      = errors.New("invalid time format")
-	ErrEmptyInput       = errors.New("input cannot be empty")
-	ErrInvalidClassType = errors.New("invalid class type")
+	ErrInvalidEmail = errors.New("invalid email format")
+	ErrInvalidTime  = errors.New("invalid time format")
+	ErrEmptyInput   = errors.New("input cannot be empty")
+	ErrInvalidDate  = errors.New("invalid date format")
 )
 
 // ValidateEmail validates email format using regex
@@ -44,29 +43,6 @@ func ValidatePassword(password string) error {
 	return nil
 }
 
-// ValidateDay validates day name (Monday, Tuesday, etc.)
-func ValidateDay(day string) error {
-	if strings.TrimSpace(day) == "" {
-		return ErrEmptyInput
-	}
-
-	validDays := map[string]bool{
-		"monday":    true,
-		"tuesday":   true,
-		"wednesday": true,
-		"thursday":  true,
-		"friday":    true,
-		"saturday":  true,
-		"sunday":    true,
-	}
-
-	if !validDays[strings.ToLower(day)] {
-		return ErrInvalidDay
-	}
-
-	return nil
-}
-
 // ValidateTime validates time format (HH:MM)
 func ValidateTime(timeStr string) error {
 	if strings.TrimSpace(timeStr) == "" {
@@ -88,22 +64,14 @@ func ValidateTime(timeStr string) error {
 	return nil
 }
 
-// ValidateClassType validates class type
-func ValidateClassType(classType string) error {
-	if strings.TrimSpace(classType) == "" {
+// ValidateDate validates calendar date format (YYYY-MM-DD)
+func ValidateDate(date string) error {
+	if strings.TrimSpace(date) == "" {
 		return ErrEmptyInput
 	}
 
-	validTypes := map[string]bool{
-		"wod":      true,
-		"open":     true,
-		"strength": true,
-		"cardio":   true,
-		"yoga":     true,
-	}
-
-	if !validTypes[strings.ToLower(classType)] {
-		return ErrInvalidClassType
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return ErrInvalidDate
 	}
 
 	return nil
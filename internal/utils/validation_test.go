@@ -56,37 +56,6 @@ func TestValidatePassword(t *testing.T) {
 	}
 }
 
-func TestValidateDay(t *testing.T) {
-	tests := []struct {
-		name    string
-		day     string
-		wantErr bool
-	}{
-		{"valid day - monday", "monday", false},
-		{"valid day - Monday", "Monday", false},
-		{"valid day - MONDAY", "MONDAY", false},
-		{"valid day - tuesday", "tuesday", false},
-		{"valid day - wednesday", "wednesday", false},
-		{"valid day - thursday", "thursday", false},
-		{"valid day - friday", "friday", false},
-		{"valid day - saturday", "saturday", false},
-		{"valid day - sunday", "sunday", false},
-		{"empty day", "", true},
-		{"whitespace only", "   ", true},
-		{"invalid day", "funday", true},
-		{"partial day", "mon", true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateDay(tt.day)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidateDay() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
 func TestValidateTime(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -117,29 +86,27 @@ func TestValidateTime(t *testing.T) {
 	}
 }
 
-func TestValidateClassType(t *testing.T) {
+func TestValidateDate(t *testing.T) {
 	tests := []struct {
-		name      string
-		classType string
-		wantErr   bool
+		name    string
+		date    string
+		wantErr bool
 	}{
-		{"valid type - wod", "wod", false},
-		{"valid type - WOD", "WOD", false},
-		{"valid type - open", "open", false},
-		{"valid type - strength", "strength", false},
-		{"valid type - cardio", "cardio", false},
-		{"valid type - yoga", "yoga", false},
-		{"empty type", "", true},
+		{"valid date", "2025-08-18", false},
+		{"valid leap day", "2024-02-29", false},
+		{"empty date", "", true},
 		{"whitespace only", "   ", true},
-		{"invalid type", "invalid", true},
-		{"partial type", "wo", true},
+		{"invalid format - slashes", "2025/08/18", true},
+		{"invalid format - day first", "18-08-2025", true},
+		{"invalid month", "2025-13-01", true},
+		{"invalid day", "2025-02-30", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateClassType(tt.classType)
+			err := ValidateDate(tt.date)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidateClassType() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ValidateDate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -0,0 +1,102 @@
+package wodbuster
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	inputTagRegex  = regexp.MustCompile(`(?is)<input\b[^>]*>`)
+	attributeRegex = regexp.MustCompile(`(?is)\s([a-z][a-z0-9_:-]*)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	postBackRegex  = regexp.MustCompile(`__doPostBack\(\s*'([^']*)'`)
+)
+
+// aspNetForm holds the state of an ASP.NET WebForms page that must be posted
+// back for the server to accept the next request (view state, validation, ...)
+type aspNetForm struct {
+	action string
+	fields url.Values
+}
+
+// parseAspNetForm collects the hidden inputs of the page's form
+func parseAspNetForm(pageURL, body string) aspNetForm {
+	form := aspNetForm{action: pageURL, fields: url.Values{}}
+
+	for _, tag := range inputTagRegex.FindAllString(body, -1) {
+		if !strings.EqualFold(tagAttribute(tag, "type"), "hidden") {
+			continue
+		}
+		if name := tagAttribute(tag, "name"); name != "" {
+			form.fields.Set(name, tagAttribute(tag, "value"))
+		}
+	}
+
+	return form
+}
+
+// setInput sets the value of the input identified by its client id, e.g. the
+// email input "body_body_CtlLogin_IoEmail"
+func (f aspNetForm) setInput(body, id, value string) error {
+	tag, ok := findTagByID(body, id)
+	if !ok {
+		return fmt.Errorf("input %s not found", id)
+	}
+
+	name := tagAttribute(tag, "name")
+	if name == "" {
+		return fmt.Errorf("input %s has no name", id)
+	}
+
+	f.fields.Set(name, value)
+	return nil
+}
+
+// click simulates clicking the control identified by its client id: submit
+// inputs and buttons post their own name, link buttons raise a __doPostBack
+func (f aspNetForm) click(body, id string) error {
+	tag, ok := findTagByID(body, id)
+	if !ok {
+		return fmt.Errorf("control %s not found", id)
+	}
+
+	if name := tagAttribute(tag, "name"); name != "" {
+		f.fields.Set(name, tagAttribute(tag, "value"))
+		return nil
+	}
+
+	for _, attribute := range []string{"href", "onclick"} {
+		if match := postBackRegex.FindStringSubmatch(tagAttribute(tag, attribute)); match != nil {
+			f.fields.Set("__EVENTTARGET", match[1])
+			f.fields.Set("__EVENTARGUMENT", "")
+			return nil
+		}
+	}
+
+	return fmt.Errorf("control %s cannot be submitted", id)
+}
+
+// hasElement reports whether the page contains an element with the given id
+func hasElement(body, id string) bool {
+	_, ok := findTagByID(body, id)
+	return ok
+}
+
+// findTagByID returns the opening tag of the element with the given id
+func findTagByID(body, id string) (string, bool) {
+	idRegex := regexp.MustCompile(`(?is)<[a-z]+\b[^>]*\sid\s*=\s*["']` + regexp.QuoteMeta(id) + `["'][^>]*>`)
+	tag := idRegex.FindString(body)
+	return tag, tag != ""
+}
+
+// tagAttribute returns the unescaped value of an attribute of an opening tag
+func tagAttribute(tag, name string) string {
+	for _, match := range attributeRegex.FindAllStringSubmatch(tag, -1) {
+		if strings.EqualFold(match[1], name) {
+			return html.UnescapeString(match[2] + match[3])
+		}
+	}
+	return ""
+}
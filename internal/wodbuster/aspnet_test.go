@@ -0,0 +1,63 @@
+package wodbuster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLoginPage = `<form method="post" action="./user" id="form1">
+<input type="hidden" name="__VIEWSTATE" id="__VIEWSTATE" value="abc&#43;def" />
+<input type="hidden" name="__EVENTVALIDATION" id="__EVENTVALIDATION" value="xyz" />
+<input name="ctl00$ctl00$body$body$CtlLogin$IoEmail" type="text" id="body_body_CtlLogin_IoEmail" />
+<input name="ctl00$ctl00$body$body$CtlLogin$IoPassword" type="password" id="body_body_CtlLogin_IoPassword" />
+<input type="submit" name="ctl00$ctl00$body$body$CtlLogin$CtlAceptar" value="Aceptar" id="body_body_CtlLogin_CtlAceptar" />
+<a data-id="body_body_CtlConfiar_CtlSeguro" href="#">decoy</a>
+<a id="body_body_CtlConfiar_CtlSeguro" href="javascript:__doPostBack('ctl00$ctl00$body$body$CtlConfiar$CtlSeguro','')">Sí</a>
+</form>`
+
+func TestParseAspNetForm(t *testing.T) {
+	form := parseAspNetForm("https://example.com/user", testLoginPage)
+
+	assert.Equal(t, "https://example.com/user", form.action)
+	assert.Equal(t, "abc+def", form.fields.Get("__VIEWSTATE"))
+	assert.Equal(t, "xyz", form.fields.Get("__EVENTVALIDATION"))
+	assert.Len(t, form.fields, 2, "only hidden inputs are collected")
+}
+
+func TestAspNetForm_SetInput(t *testing.T) {
+	form := parseAspNetForm("https://example.com/user", testLoginPage)
+
+	require.NoError(t, form.setInput(testLoginPage, loginEmailID, "test@example.com"))
+	assert.Equal(t, "test@example.com", form.fields.Get("ctl00$ctl00$body$body$CtlLogin$IoEmail"))
+
+	assert.Error(t, form.setInput(testLoginPage, "missing", "value"))
+}
+
+func TestAspNetForm_Click(t *testing.T) {
+	t.Run("submit input", func(t *testing.T) {
+		form := parseAspNetForm("https://example.com/user", testLoginPage)
+
+		require.NoError(t, form.click(testLoginPage, loginSubmitID))
+		assert.Equal(t, "Aceptar", form.fields.Get("ctl00$ctl00$body$body$CtlLogin$CtlAceptar"))
+		assert.Empty(t, form.fields.Get("__EVENTTARGET"))
+	})
+
+	t.Run("link button", func(t *testing.T) {
+		form := parseAspNetForm("https://example.com/user", testLoginPage)
+
+		require.NoError(t, form.click(testLoginPage, trustBrowserID))
+		assert.Equal(t, "ctl00$ctl00$body$body$CtlConfiar$CtlSeguro", form.fields.Get("__EVENTTARGET"))
+	})
+
+	t.Run("missing control", func(t *testing.T) {
+		form := parseAspNetForm("https://example.com/user", testLoginPage)
+		assert.Error(t, form.click(testLoginPage, dontTrustBrowserID))
+	})
+}
+
+func TestHasElement(t *testing.T) {
+	assert.True(t, hasElement(testLoginPage, loginEmailID))
+	assert.False(t, hasElement(testLoginPage, "CtlSeguro"))
+}
@@ -3,42 +3,63 @@ package wodbuster
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/chromedp"
 )
 
-// BookClass books a class for a specific day, class type, and hour
-// day: Day abbreviation string (e.g., "L", "M", "X", "J", "V", "S", "D")
-//
-//	You can use the Day constants (DayMonday, DayTuesday, etc.) or plain strings
+// bookingConfirmationTimeout bounds the wait for the site to confirm a booking
+const bookingConfirmationTimeout = 15 * time.Second
+
+// BookClass books a class next week for a specific day, class type, and hour
+// day: models.Day (e.g., models.DayMonday), opened on its calendar tab
 //
 // classType: Class type string (e.g., "Wod", "Open box", "HYROX")
 //
 //	You can use the ClassType constants (ClassTypeWod, ClassTypeOpenBox, etc.) or plain strings
 //
 // hour: Time in format "HH:MM" (e.g., "07:00", "19:30")
-func (c *Client) BookClass(_ context.Context, email, password string, day, classType, hour string) error {
+//
+// An empty password books within the session restored by LoadStoredSession
+// instead of logging in again.
+func (c *Client) BookClass(ctx context.Context, email, password string, day models.Day, classType, hour string) error {
 	if day == "" || classType == "" || hour == "" {
 		return fmt.Errorf("day, classType, and hour are required")
 	}
+	if password == "" && !c.sessionRestored {
+		return ErrNoSession
+	}
+
+	tab, err := calendarTab(day)
+	if err != nil {
+		return err
+	}
 
 	c.logger.Info("Starting class booking",
 		"day", day,
 		"classType", classType,
 		"hour", hour)
 
-	actions := login(c.baseURL, email, password)
-	actions = append(actions, notRememberBrowser()...)
-	actions = append(actions, getAvailableClasses(true)...) // false for current week
-	actions = append(actions, selectDay(day)...)
+	var actions []chromedp.Action
+	if password == "" {
+		actions = openSchedule(c.baseURL, true)
+	} else {
+		actions = login(c.baseURL, email, password)
+		actions = append(actions, notRememberBrowser()...)
+		actions = append(actions, getAvailableClasses(true)...) // false for current week
+	}
+	actions = append(actions, selectDay(tab)...)
 	actions = append(actions, bookClass(classType, hour)...)
 	actions = append(actions, acceptConfirmation()...)
 	actions = append(actions,
 		// Wait for the confirmation to process
 		chromedp.Sleep(3*time.Second))
 
-	if err := chromedp.Run(c.ctx, actions...); err != nil {
+	if err := c.run(ctx, actions...); err != nil {
 		c.logger.Error("Failed to book class",
 			"error", err,
 			"day", day,
@@ -55,27 +76,253 @@ func (c *Client) BookClass(_ context.Context, email, password string, day, class
 	return nil
 }
 
-// RemoveBooking removes a booking for a specific day and hour (not implemented yet)
-func (c *Client) RemoveBooking(day, hour string) error {
-	if err := chromedp.Run(c.ctx,
-		chromedp.Navigate(c.baseURL+"/schedule"),
-	); err != nil {
-		return fmt.Errorf("failed to navigate: %w", err)
+// PrepareBooking logs in, or reuses the session restored by LoadStoredSession
+// when the password is empty, and leaves the browser on the calendar day of
+// the class date, so TryBookClass only has to click. The date must lie in the
+// current or the next week, the ones the calendar shows.
+func (c *Client) PrepareBooking(ctx context.Context, email, password string, classDate time.Time) error {
+	if password == "" && !c.sessionRestored {
+		return ErrNoSession
+	}
+
+	day, nextWeek, err := calendarDay(time.Now(), classDate)
+	if err != nil {
+		return err
+	}
+
+	var actions []chromedp.Action
+	if password == "" {
+		actions = openSchedule(c.baseURL, nextWeek)
+	} else {
+		actions = login(c.baseURL, email, password)
+		actions = append(actions, notRememberBrowser()...)
+		actions = append(actions, getAvailableClasses(nextWeek)...)
+	}
+	actions = append(actions, selectDay(day)...)
+
+	c.calendarWeek = time.Time{}
+	if err := c.runBounded(ctx, navigationTimeout, actions...); err != nil {
+		return fmt.Errorf("failed to prepare booking: %w", err)
+	}
+
+	// The client stays logged in for the calls with an empty password
+	c.sessionRestored = true
+	c.preparedDay = day
+	c.calendarWeek = weekStart(classDate)
+	c.logger.Info("Booking prepared", "day", day, "date", classDate.Format("2006-01-02"))
+	return nil
+}
+
+// TryBookClass makes a single attempt to book the class on the day opened by
+// PrepareBooking: it loads the day's classes anew and clicks "Reservar" if the
+// button is shown. It reports false without an error while the class cannot be
+// booked yet, so it can be called in a loop as the booking window opens,
+// ErrClassFull once the class has no spots left and ErrClassNotFound when the
+// day has no such class.
+func (c *Client) TryBookClass(ctx context.Context, classType, hour string) (bool, error) {
+	if c.preparedDay == "" {
+		return false, ErrNotPrepared
+	}
+
+	xpathReserve := classButtonXPath(classType, hour, "Reservar")
+	xpathCancel := classButtonXPath(classType, hour, "Borrar")
+
+	var card, reserve, booked, full []*cdp.Node
+	actions := refreshDay(c.preparedDay)
+	actions = append(actions,
+		chromedp.Nodes(classCardXPath(classType, hour), &card, chromedp.BySearch, chromedp.AtLeast(0)),
+		chromedp.Nodes(xpathReserve, &reserve, chromedp.BySearch, chromedp.AtLeast(0)),
+		chromedp.Nodes(xpathCancel, &booked, chromedp.BySearch, chromedp.AtLeast(0)),
+		chromedp.Nodes(classFullXPath(classType, hour), &full, chromedp.BySearch, chromedp.AtLeast(0)),
+	)
+	if err := c.runBounded(ctx, navigationTimeout, actions...); err != nil {
+		return false, fmt.Errorf("failed to reload schedule: %w", err)
+	}
+	if len(card) == 0 {
+		return false, fmt.Errorf("%w: %s at %s", ErrClassNotFound, classType, hour)
+	}
+	if len(booked) > 0 {
+		return true, nil
+	}
+	if len(full) > 0 {
+		return false, fmt.Errorf("%w: %s at %s", ErrClassFull, classType, hour)
+	}
+	if len(reserve) == 0 {
+		return false, nil
+	}
+
+	actions = []chromedp.Action{
+		chromedp.Click(xpathReserve, chromedp.BySearch),
+	}
+	actions = append(actions, acceptConfirmation()...)
+	// The class turns into "Borrar" once the booking is confirmed
+	actions = append(actions, chromedp.WaitVisible(xpathCancel, chromedp.BySearch))
+
+	if err := c.runBounded(ctx, bookingConfirmationTimeout, actions...); err != nil {
+		return false, fmt.Errorf("failed to book class: %w", err)
+	}
+
+	c.logger.Info("Successfully booked class", "day", c.preparedDay, "classType", classType, "hour", hour)
+	return true, nil
+}
+
+// BookClasses books the classes in the given order within the session opened
+// by PrepareBooking: it makes a single attempt to book each class, as
+// TryBookClass does, or one of its alternatives when it can't be booked. The
+// day of each class is opened from the calendar PrepareBooking left the
+// browser on, so the schedule is only loaded again for a class in the other
+// week. The error is only set when the booking was not prepared; each class
+// has its own result.
+func (c *Client) BookClasses(ctx context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+	if c.preparedDay == "" {
+		return nil, ErrNotPrepared
+	}
+
+	results := make([]models.ClassResult, len(classes))
+	for i, class := range classes {
+		results[i] = models.ClassResult{ClassRequest: class}
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		day, nextWeek, err := calendarDay(time.Now(), class.Date)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		// The calendar shows a single week, TryBookClass opens the day
+		if week := weekStart(class.Date); !week.Equal(c.calendarWeek) {
+			c.calendarWeek = time.Time{}
+			actions := append(openSchedule(c.baseURL, nextWeek), selectDay(day)...)
+			if err := c.runBounded(ctx, navigationTimeout, actions...); err != nil {
+				results[i].Err = fmt.Errorf("failed to open class week: %w", err)
+				continue
+			}
+			c.calendarWeek = week
+		}
+
+		c.preparedDay = day
+		results[i] = bookFirstAvailable(ctx, class, c.TryBookClass)
 	}
-	return ErrNotImplemented
+
+	return results, nil
+}
+
+// JoinWaitlist joins the waiting list of the full class on the day opened by
+// PrepareBooking. It reports false without an error when the class offers no
+// waiting list, and ErrClassNotFound when the day has no such class.
+func (c *Client) JoinWaitlist(ctx context.Context, classType, hour string) (bool, error) {
+	if c.preparedDay == "" {
+		return false, ErrNotPrepared
+	}
+
+	xpathJoin := classButtonXPath(classType, hour, "Lista de espera")
+	xpathWaiting := classStatusXPath(classType, hour, "lista de espera")
+
+	var card, join, waiting []*cdp.Node
+	actions := refreshDay(c.preparedDay)
+	actions = append(actions,
+		chromedp.Nodes(classCardXPath(classType, hour), &card, chromedp.BySearch, chromedp.AtLeast(0)),
+		chromedp.Nodes(xpathJoin, &join, chromedp.BySearch, chromedp.AtLeast(0)),
+		chromedp.Nodes(xpathWaiting, &waiting, chromedp.BySearch, chromedp.AtLeast(0)),
+	)
+	if err := c.runBounded(ctx, navigationTimeout, actions...); err != nil {
+		return false, fmt.Errorf("failed to reload schedule: %w", err)
+	}
+	if len(card) == 0 {
+		return false, fmt.Errorf("%w: %s at %s", ErrClassNotFound, classType, hour)
+	}
+	if len(waiting) > 0 {
+		return true, nil
+	}
+	if len(join) == 0 {
+		return false, nil
+	}
+
+	actions = []chromedp.Action{
+		chromedp.Click(xpathJoin, chromedp.BySearch),
+	}
+	actions = append(actions, acceptConfirmation()...)
+	actions = append(actions, chromedp.WaitVisible(xpathWaiting, chromedp.BySearch))
+
+	if err := c.runBounded(ctx, bookingConfirmationTimeout, actions...); err != nil {
+		return false, fmt.Errorf("failed to join waiting list: %w", err)
+	}
+
+	c.logger.Info("Joined waiting list", "day", c.preparedDay, "classType", classType, "hour", hour)
+	return true, nil
+}
+
+// ServerTime returns the site's current time, to measure the local clock's skew
+func (c *Client) ServerTime(ctx context.Context) (time.Time, error) {
+	return serverTime(ctx, http.DefaultClient, c.baseURL)
+}
+
+// RemoveBooking cancels the user's reservation of a class on the next date
+// falling on the given day. It takes the same arguments as BookClass; an empty
+// password cancels within the session restored by LoadStoredSession.
+func (c *Client) RemoveBooking(ctx context.Context, email, password string, day models.Day, classType, hour string) error {
+	if day == "" || classType == "" || hour == "" {
+		return fmt.Errorf("day, classType, and hour are required")
+	}
+	if password == "" && !c.sessionRestored {
+		return ErrNoSession
+	}
+
+	tab, err := calendarTab(day)
+	if err != nil {
+		return err
+	}
+	weekday, _ := day.Weekday()
+	_, nextWeek := upcomingClassDate(time.Now(), weekday)
+
+	c.logger.Info("Starting booking removal",
+		"day", day,
+		"classType", classType,
+		"hour", hour)
+
+	var actions []chromedp.Action
+	if password == "" {
+		actions = openSchedule(c.baseURL, nextWeek)
+	} else {
+		actions = login(c.baseURL, email, password)
+		actions = append(actions, notRememberBrowser()...)
+		actions = append(actions, getAvailableClasses(nextWeek)...)
+	}
+	actions = append(actions, selectDay(tab)...)
+	actions = append(actions, cancelClass(classType, hour)...)
+	actions = append(actions, acceptConfirmation()...)
+
+	err = c.run(ctx, actions...)
+	if err == nil {
+		// The class loses its "Borrar" button once the site has cancelled the
+		// booking, a rejected cancellation leaves it in place
+		cancelled := classCardXPath(classType, hour) + `[not(.//button[contains(., 'Borrar')])]`
+		err = c.runBounded(ctx, bookingConfirmationTimeout, chromedp.WaitVisible(cancelled, chromedp.BySearch))
+	}
+	if err != nil {
+		c.logger.Error("Failed to remove booking",
+			"error", err,
+			"day", day,
+			"classType", classType,
+			"hour", hour)
+		return fmt.Errorf("failed to remove booking: %w", err)
+	}
+
+	c.logger.Info("Successfully removed booking",
+		"day", day,
+		"classType", classType,
+		"hour", hour)
+
+	return nil
 }
 
 // bookClass finds and clicks the "Reservar" button for a specific class type and hour
 // classType examples: "Wod", "Open box", "HYROX", etc.
 // hour examples: "07:00", "08:00", "19:30", etc.
 func bookClass(classType string, hour string) []chromedp.Action {
-	// Construct XPath to find the "Reservar" button based on both class type and hour
-	// Structure: div.clase > div.entrenamientoHead > div.namehour > (h3.entrenamiento + div.hora)
-	// Then find the button in div.actionsjs > button.entrenar
-	xpathReserve := fmt.Sprintf(
-		`//div[contains(@class, 'clase')]//div[@class='namehour'][.//h3[contains(@class, 'entrenamiento') and contains(normalize-space(text()), '%s')] and .//div[@class='hora' and text()='%s']]/ancestor::div[contains(@class, 'clase')]//button[contains(@class, 'entrenar') and contains(., 'Reservar')]`,
-		classType, hour,
-	)
+	xpathReserve := classButtonXPath(classType, hour, "Reservar")
 
 	return []chromedp.Action{
 		// Wait for the "Reservar" button to appear
@@ -87,6 +334,70 @@ func bookClass(classType string, hour string) []chromedp.Action {
 	}
 }
 
+// cancelClass finds and clicks the "Borrar" button of a booked class, located
+// the same way as bookClass locates the "Reservar" button
+func cancelClass(classType string, hour string) []chromedp.Action {
+	xpathCancel := classButtonXPath(classType, hour, "Borrar")
+
+	return []chromedp.Action{
+		chromedp.WaitVisible(xpathCancel, chromedp.BySearch),
+		chromedp.Click(xpathCancel, chromedp.BySearch),
+
+		chromedp.Sleep(100 * time.Millisecond),
+	}
+}
+
+// classButtonXPath locates the button with the given label of the class
+// matching both class type and hour.
+// Structure: div.clase > div.entrenamientoHead > div.namehour > (h3.entrenamiento + div.hora)
+// Then find the button in div.actionsjs > button.entrenar
+func classButtonXPath(classType, hour, label string) string {
+	return classCardXPath(classType, hour) + fmt.Sprintf(`//button[contains(@class, 'entrenar') and contains(., %s)]`, xpathLiteral(label))
+}
+
+// classStatusXPath locates the status text of the class shown instead of a
+// button, e.g. "Completa", matched case-insensitively
+func classStatusXPath(classType, hour, status string) string {
+	return classCardXPath(classType, hour) + fmt.Sprintf(
+		`//span[contains(@class, 'estado') and contains(translate(., 'ABCDEFGHIJKLMNOPQRSTUVWXYZ', 'abcdefghijklmnopqrstuvwxyz'), %s)]`,
+		xpathLiteral(strings.ToLower(status)),
+	)
+}
+
+// classFullXPath matches a class without spots left: shown as "Completa" or
+// offering its waiting list
+func classFullXPath(classType, hour string) string {
+	return classStatusXPath(classType, hour, "completa") + " | " +
+		classButtonXPath(classType, hour, "Lista de espera") + " | " +
+		classStatusXPath(classType, hour, "lista de espera")
+}
+
+// classCardXPath locates the div.clase card of the class matching both class
+// type and hour
+func classCardXPath(classType, hour string) string {
+	return fmt.Sprintf(
+		`//div[contains(@class, 'clase')]//div[@class='namehour'][.//h3[contains(@class, 'entrenamiento') and contains(normalize-space(text()), %s)] and .//div[@class='hora' and text()=%s]]/ancestor::div[contains(@class, 'clase')]`,
+		xpathLiteral(classType), xpathLiteral(hour),
+	)
+}
+
+// xpathLiteral quotes s as an XPath string literal. XPath has no escapes, so a
+// string holding both kinds of quotes is joined with concat().
+func xpathLiteral(s string) string {
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`
+	}
+
+	parts := strings.Split(s, "'")
+	for i, part := range parts {
+		parts[i] = "'" + part + "'"
+	}
+	return "concat(" + strings.Join(parts, `, "'", `) + ")"
+}
+
 // acceptConfirmation clicks the "Aceptar" button in the confirmation dialog
 func acceptConfirmation() []chromedp.Action {
 	// XPath for the "Aceptar" button inside the confirmation dialog
@@ -102,9 +413,9 @@ func acceptConfirmation() []chromedp.Action {
 }
 
 // selectDay clicks on a specific day in the calendar
-func selectDay(day string) []chromedp.Action {
-	// Construct XPath based on the day abbreviation (L, M, X, J, V, S, D)
-	xpath := fmt.Sprintf(`//a[@class="dia" or contains(@class, "current")]/span[text()='%s']/parent::a`, day)
+func selectDay(tab string) []chromedp.Action {
+	// Construct XPath based on the tab abbreviation (L, M, X, J, V, S, D)
+	xpath := fmt.Sprintf(`//a[@class="dia" or contains(@class, "current")]/span[text()='%s']/parent::a`, tab)
 
 	return []chromedp.Action{
 		chromedp.Sleep(1 * time.Second),
@@ -116,7 +427,23 @@ func selectDay(day string) []chromedp.Action {
 	}
 }
 
-func (c *Client) BookClassOnly(day, classType, hour string) error {
+// refreshDay loads the classes of the selected day anew by clicking its tab
+// again. Reloading the page instead would lose the day and week selected on
+// the calendar wherever the site selects them in the page's JavaScript. It
+// waits until none of the classes shown before the click is left.
+func refreshDay(tab string) []chromedp.Action {
+	xpath := fmt.Sprintf(`//a[@class="dia" or contains(@class, "current")]/span[text()='%s']/parent::a`, tab)
+
+	return []chromedp.Action{
+		chromedp.WaitVisible(xpath),
+		// Mark the classes shown to tell them from the ones loaded by the click
+		chromedp.Evaluate(`document.querySelectorAll('div.clase').forEach(function (c) { c.setAttribute('data-stale', '') })`, nil),
+		chromedp.Click(xpath),
+		chromedp.WaitVisible(`//div[@id="calendar"][not(.//div[contains(@class, 'clase')][@data-stale])]`),
+	}
+}
+
+func (c *Client) BookClassOnly(day models.Day, classType, hour string) error {
 	if day == "" || classType == "" || hour == "" {
 		return fmt.Errorf("day, classType, and hour are required")
 	}
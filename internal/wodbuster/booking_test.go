@@ -3,6 +3,9 @@ package wodbuster
 import (
 	"context"
 	"testing"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestBookClass(t *testing.T) {
@@ -14,7 +17,7 @@ func TestBookClass(t *testing.T) {
 
 	// Book a Wod class on Wednesday at 19:30
 	// Can use constants (converted to strings) or plain strings
-	err := client.BookClass(context.Background(), user, pass, string(DayWednesday), string(ClassTypeWod), "19:30")
+	err := client.BookClass(context.Background(), user, pass, models.DayWednesday, string(ClassTypeWod), "19:30")
 	if err != nil {
 		t.Logf("Error booking class: %v", err)
 	} else {
@@ -25,6 +28,25 @@ func TestBookClass(t *testing.T) {
 	// err := client.BookClass(context.Background(), user, pass, "X", "Wod", "19:30")
 }
 
+func TestXPathLiteral(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "Open box", want: `'Open box'`},
+		{name: "apostrophe", in: "Women's WOD", want: `"Women's WOD"`},
+		{name: "both quotes", in: `Women's "WOD"`, want: `concat('Women', "'", 's "WOD"')`},
+		{name: "trailing apostrophe", in: `"Kids'`, want: `concat('"Kids', "'", '')`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, xpathLiteral(tt.in))
+		})
+	}
+}
+
 // func TestRemoveBooking(t *testing.T) {
 // 	client := setupTestClient(t)
 // 	defer client.Close()
@@ -3,36 +3,53 @@ package wodbuster
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/chromedp"
 )
 
-// GetAvailableClasses retrieves all available classes for booking
-// day: Day abbreviation string (e.g., "L" for Monday, "X" for Wednesday)
-func (c *Client) GetAvailableClasses(email, password string, day string) ([]ClassSchedule, error) {
-	c.logger.Info("Getting available classes", "day", day)
-
-	actions := login(c.baseURL, email, password)
-	actions = append(actions, rememberBrowser()...)
-	actions = append(actions, getAvailableClasses(false)...)
-
-	if day != "" {
-		actions = append(actions, selectDay(day)...)
+// GetAvailableClasses returns the timetable of the class date, which must lie
+// in the current or the next week, the ones the calendar shows. It logs in, or
+// reuses the session restored by LoadStoredSession when the password is empty.
+func (c *Client) GetAvailableClasses(ctx context.Context, email, password string, classDate time.Time) ([]models.ClassSchedule, error) {
+	if password == "" && !c.sessionRestored {
+		return nil, ErrNoSession
 	}
 
+	tab, nextWeek, err := calendarDay(time.Now(), classDate)
+	if err != nil {
+		return nil, err
+	}
+	day := models.DayOf(classDate.Weekday())
+	c.logger.Info("Getting available classes", "day", day, "date", classDate.Format("2006-01-02"))
+
+	var actions []chromedp.Action
+	if password == "" {
+		actions = openSchedule(c.baseURL, nextWeek)
+	} else {
+		actions = login(c.baseURL, email, password)
+		actions = append(actions, rememberBrowser()...)
+		actions = append(actions, getAvailableClasses(nextWeek)...)
+	}
+	actions = append(actions, selectDay(tab)...)
 	actions = append(actions,
 		// Wait for the classes to load
 		chromedp.Sleep(2*time.Second))
 
-	if err := chromedp.Run(c.ctx, actions...); err != nil {
+	if err := c.runBounded(ctx, navigationTimeout, actions...); err != nil {
 		return nil, fmt.Errorf("failed to navigate: %w", err)
 	}
 
-	// Parse available classes from the page
-	var classes []ClassSchedule
-	err := chromedp.Run(c.ctx,
+	return c.readClasses(ctx, day)
+}
+
+// readClasses parses the classes of the calendar day shown by the browser
+func (c *Client) readClasses(ctx context.Context, day models.Day) ([]models.ClassSchedule, error) {
+	var classes []models.ClassSchedule
+	err := c.run(ctx,
 		chromedp.ActionFunc(func(ctx context.Context) error {
 			var nodes []*cdp.Node
 			if err := chromedp.Nodes(`//div[contains(@class, 'clase')]`, &nodes, chromedp.BySearch).Do(ctx); err != nil {
@@ -40,7 +57,7 @@ func (c *Client) GetAvailableClasses(email, password string, day string) ([]Clas
 			}
 
 			for _, node := range nodes {
-				class, err := parseClassNode(ctx, node)
+				class, err := parseClassNode(ctx, node, day)
 				if err != nil {
 					c.logger.Warn("Failed to parse class node", "error", err)
 					continue
@@ -57,7 +74,7 @@ func (c *Client) GetAvailableClasses(email, password string, day string) ([]Clas
 		return nil, fmt.Errorf("failed to parse classes: %w", err)
 	}
 
-	c.logger.Info("Found available classes", "count", len(classes))
+	c.logger.Info("Found available classes", "day", day, "count", len(classes))
 	return classes, nil
 }
 
@@ -77,8 +94,39 @@ func getAvailableClasses(nextWeek bool) []chromedp.Action {
 	return actions
 }
 
-// parseClassNode extracts class information from a DOM node
-func parseClassNode(ctx context.Context, node *cdp.Node) (*ClassSchedule, error) {
+// openSchedule navigates straight to the class booking page, which only works
+// with an authenticated session
+func openSchedule(baseURL string, nextWeek bool) []chromedp.Action {
+	actions := []chromedp.Action{
+		chromedp.Navigate(baseURL + "/schedule"),
+		requireSession(),
+		chromedp.WaitVisible(`//div[@id="calendar"]`),
+	}
+	if nextWeek {
+		actions = append(actions, chromedp.Click(`a.next.icon`, chromedp.ByQuery))
+	}
+
+	return actions
+}
+
+// requireSession fails with ErrSessionExpired when the site redirected the
+// last navigation to the login page
+func requireSession() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		var location string
+		if err := chromedp.Location(&location).Do(ctx); err != nil {
+			return err
+		}
+		if strings.Contains(location, "/user") {
+			return ErrSessionExpired
+		}
+		return nil
+	})
+}
+
+// parseClassNode extracts the information of a class on the day from its DOM
+// node
+func parseClassNode(ctx context.Context, node *cdp.Node, day models.Day) (*models.ClassSchedule, error) {
 	if node == nil {
 		return nil, fmt.Errorf("node is nil")
 	}
@@ -105,23 +153,27 @@ func parseClassNode(ctx context.Context, node *cdp.Node) (*ClassSchedule, error)
 	// Clean up class type (remove extra whitespace and asterisks)
 	classTypeStr = cleanClassType(classTypeStr)
 
-	return &ClassSchedule{
-		Day:       "", // Day needs to be set from context
+	return &models.ClassSchedule{
+		Day:       day,
 		Hour:      hour,
-		ClassType: ClassType(classTypeStr),
+		ClassType: classTypeStr,
 		Available: hasReservarButton,
 	}, nil
 }
 
 // GetAvailableClasses retrieves all available classes for booking
-// day: Day abbreviation string (e.g., "L" for Monday, "X" for Wednesday)
-func (c *Client) GetAvailableClassesOnly(day string) ([]ClassSchedule, error) {
+// day: the day whose calendar tab is opened (e.g., models.DayMonday), empty for today
+func (c *Client) GetAvailableClassesOnly(day models.Day) ([]models.ClassSchedule, error) {
 	c.logger.Info("Getting available classes", "day", day)
 
 	actions := append([]chromedp.Action{}, getAvailableClasses(true)...)
 
 	if day != "" {
-		actions = append(actions, selectDay(day)...)
+		tab, err := calendarTab(day)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, selectDay(tab)...)
 	}
 
 	actions = append(actions,
@@ -132,33 +184,5 @@ func (c *Client) GetAvailableClassesOnly(day string) ([]ClassSchedule, error) {
 		return nil, fmt.Errorf("failed to navigate: %w", err)
 	}
 
-	// Parse available classes from the page
-	var classes []ClassSchedule
-	err := chromedp.Run(c.ctx,
-		chromedp.ActionFunc(func(ctx context.Context) error {
-			var nodes []*cdp.Node
-			if err := chromedp.Nodes(`//div[contains(@class, 'clase')]`, &nodes, chromedp.BySearch).Do(ctx); err != nil {
-				return err
-			}
-
-			for _, node := range nodes {
-				class, err := parseClassNode(ctx, node)
-				if err != nil {
-					c.logger.Warn("Failed to parse class node", "error", err)
-					continue
-				}
-				if class != nil {
-					classes = append(classes, *class)
-				}
-			}
-			return nil
-		}),
-	)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse classes: %w", err)
-	}
-
-	c.logger.Info("Found available classes", "count", len(classes))
-	return classes, nil
+	return c.readClasses(c.ctx, day)
 }
@@ -1,7 +1,9 @@
 package wodbuster
 
 import (
+	"context"
 	"testing"
+	"time"
 )
 
 func TestGetAvailableClasses(t *testing.T) {
@@ -9,9 +11,9 @@ func TestGetAvailableClasses(t *testing.T) {
 	client := setupTestClient(t)
 	defer client.Close()
 
-	// Test getting classes for a specific day (Monday)
-	// Can use either string or constant
-	classes, err := client.GetAvailableClasses(user, pass, string(DayMonday))
+	// Test getting classes for the next Monday the calendar shows
+	monday, _ := upcomingClassDate(time.Now(), time.Monday)
+	classes, err := client.GetAvailableClasses(context.Background(), user, pass, monday)
 	if err != nil {
 		t.Logf("Error getting available classes: %v", err)
 	}
@@ -3,6 +3,7 @@ package wodbuster
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
@@ -17,14 +18,36 @@ type Client struct {
 	logger  *slog.Logger
 	baseURL string
 	cookies []*http.Cookie // Store session cookies
+	// sessionRestored is set once LoadStoredSession validated the stored
+	// cookies, or PrepareBooking logged in
+	sessionRestored bool
+	// preparedDay is the calendar day PrepareBooking left the browser on
+	preparedDay string
+	// calendarWeek is the Monday of the week the calendar shows, zero when
+	// it is not known
+	calendarWeek time.Time
 }
 
+const (
+	// clientTimeout bounds the lifetime of a client's browser context
+	clientTimeout = 60 * time.Minute
+	// navigationTimeout bounds each step of a booking
+	navigationTimeout = 30 * time.Second
+	// userAgent is presented by both the browser and the HTTP client
+	userAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+)
+
 // Option defines the method to customize the Client.
 type Option func(*Client)
 
 var (
 	ErrMissingBaseURL = errors.New("base URL is required")
 	ErrNotImplemented = errors.New("method not implemented")
+	ErrNoSession      = errors.New("no password provided and no session restored")
+	ErrNotPrepared    = errors.New("booking not prepared")
+	// ErrNavigationTimeout is returned when a page does not load or an element
+	// does not show up in time, which is usually worth retrying
+	ErrNavigationTimeout = errors.New("navigation timed out")
 )
 
 // WithContext allows setting a custom context for the client.
@@ -62,68 +85,50 @@ func WithStoredCookies(cookies []*http.Cookie) Option {
 // WithHeadlessMode configures chromedp for headless operation with anti-detection
 func WithHeadlessMode(headless bool) Option {
 	return func(c *Client) {
-		// Create a new allocator context for this client
-		allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(),
-			append(chromedp.DefaultExecAllocatorOptions[:],
-				chromedp.Flag("headless", headless),
-				chromedp.Flag("no-sandbox", true),
-				chromedp.Flag("disable-gpu", true),
-				chromedp.Flag("disable-dev-shm-usage", true),
-				chromedp.UserAgent("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
-				chromedp.Flag("disable-blink-features", "AutomationControlled"),
-				chromedp.Flag("disable-web-security", true),
-				chromedp.Flag("disable-features", "TranslateUI"),
-			)...)
-
-		// Create browser context with timeout
-		ctx, cancel := chromedp.NewContext(allocCtx)
-		ctx, timeoutCancel := context.WithTimeout(ctx, 60*time.Minute)
-
-		// Cancel the old context if it exists
-		if c.cancel != nil {
-			c.cancel()
-		}
-
-		c.ctx = ctx
-		c.cancel = func() {
-			timeoutCancel()
-			cancel()
-			allocCancel()
-		}
+		c.useDedicatedBrowser(headless)
 	}
 }
 
 // WithDedicatedContext creates a new dedicated browser context for this client instance
 func WithDedicatedContext() Option {
 	return func(c *Client) {
-		// Create a new allocator context for this client
-		allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(),
-			append(chromedp.DefaultExecAllocatorOptions[:],
-				chromedp.Flag("headless", true),
-				chromedp.Flag("no-sandbox", true),
-				chromedp.Flag("disable-gpu", true),
-				chromedp.Flag("disable-dev-shm-usage", true),
-				chromedp.UserAgent("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
-				chromedp.Flag("disable-blink-features", "AutomationControlled"),
-				chromedp.Flag("disable-web-security", true),
-				chromedp.Flag("disable-features", "TranslateUI"),
-			)...)
-
-		// Create browser context with timeout
-		ctx, cancel := chromedp.NewContext(allocCtx)
-		ctx, timeoutCancel := context.WithTimeout(ctx, 60*time.Minute)
-
-		// Cancel the old context if it exists
-		if c.cancel != nil {
-			c.cancel()
-		}
+		c.useDedicatedBrowser(true)
+	}
+}
 
-		c.ctx = ctx
-		c.cancel = func() {
-			timeoutCancel()
-			cancel()
-			allocCancel()
-		}
+// browserOptions returns the Chrome flags shared by every browser the bot starts
+func browserOptions(headless bool) []chromedp.ExecAllocatorOption {
+	return append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", headless),
+		chromedp.Flag("no-sandbox", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("disable-dev-shm-usage", true),
+		chromedp.UserAgent(userAgent),
+		chromedp.Flag("disable-blink-features", "AutomationControlled"),
+		chromedp.Flag("disable-web-security", true),
+		chromedp.Flag("disable-features", "TranslateUI"),
+	)
+}
+
+// useDedicatedBrowser starts the client in its own browser process
+func (c *Client) useDedicatedBrowser(headless bool) {
+	// Create a new allocator context for this client
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), browserOptions(headless)...)
+
+	// Create browser context with timeout
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	ctx, timeoutCancel := context.WithTimeout(ctx, clientTimeout)
+
+	// Cancel the old context if it exists
+	if c.cancel != nil {
+		c.cancel()
+	}
+
+	c.ctx = ctx
+	c.cancel = func() {
+		timeoutCancel()
+		cancel()
+		allocCancel()
 	}
 }
 
@@ -136,7 +141,7 @@ func NewClient(baseURL string, opts ...Option) (*Client, error) {
 	// Create default client with background context and timeout
 	ctx, cancel := chromedp.NewContext(context.Background())
 	// Set a reasonable timeout for operations
-	ctx, timeoutCancel := context.WithTimeout(ctx, 60*time.Minute)
+	ctx, timeoutCancel := context.WithTimeout(ctx, clientTimeout)
 
 	client := &Client{
 		ctx:     ctx,
@@ -157,3 +162,39 @@ func NewClient(baseURL string, opts ...Option) (*Client, error) {
 func (c *Client) Close() {
 	c.cancel()
 }
+
+// run runs the actions in the browser until they are done or ctx is, so a
+// cancelled booking stops in the middle of a step instead of after it
+func (c *Client) run(ctx context.Context, actions ...chromedp.Action) error {
+	// The browser is started on the client's own context: the context of the
+	// first run ending would stop it
+	if err := chromedp.Run(c.ctx); err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	if err := chromedp.Run(runCtx, actions...); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// runBounded runs the actions as run does within timeout, reporting a timeout
+// as ErrNavigationTimeout
+func (c *Client) runBounded(ctx context.Context, timeout time.Duration, actions ...chromedp.Action) error {
+	boundedCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := c.run(boundedCtx, actions...)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return fmt.Errorf("%w: %w", ErrNavigationTimeout, err)
+	}
+	return err
+}
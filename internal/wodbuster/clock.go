@@ -0,0 +1,35 @@
+package wodbuster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// serverTime estimates the site's current time from the Date header of a
+// request to its home page. The header has a one second resolution, so the
+// estimate assumes the middle of that second and adds the response's travel
+// time, taken as half the round trip.
+func serverTime(ctx context.Context, client *http.Client, baseURL string) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, baseURL+"/", nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	sent := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("request to %s failed: %w", req.URL.Path, err)
+	}
+	resp.Body.Close()
+	roundTrip := time.Since(sent)
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid Date header: %w", err)
+	}
+
+	return date.Add(500*time.Millisecond + roundTrip/2), nil
+}
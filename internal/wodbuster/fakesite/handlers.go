@@ -0,0 +1,246 @@
+package fakesite
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Names of the ASP.NET controls posted by the login form
+const (
+	emailField        = "ctl00$ctl00$body$body$CtlLogin$IoEmail"
+	passwordField     = "ctl00$ctl00$body$body$CtlLogin$IoPassword"
+	loginButtonField  = "ctl00$ctl00$body$body$CtlLogin$CtlAceptar"
+	trustTarget       = "ctl00$ctl00$body$body$CtlConfiar$CtlSeguro"
+	dontTrustTarget   = "ctl00$ctl00$body$body$CtlConfiar$CtlNoSeguroConfianza"
+	trustedSessionTTL = 30 * 24 * time.Hour
+)
+
+var dayLabels = []string{"L", "M", "X", "J", "V", "S", "D"}
+
+func (s *Site) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", s.handleLoginPage)
+	mux.HandleFunc("POST /user", s.handleLoginPost)
+	mux.HandleFunc("GET /{$}", s.requireSession(s.handleHome))
+	mux.HandleFunc("GET /athlete/{$}", s.requireSession(s.handleHome))
+	mux.HandleFunc("GET /schedule", s.requireSession(s.handleSchedule))
+	mux.HandleFunc("GET /athlete/handlers/LoadClass.ashx", s.requireSession(s.handleLoadClass))
+	mux.HandleFunc("GET /athlete/handlers/Calendario_Inscribir.ashx", s.requireSession(s.handleClassAction(s.book)))
+	mux.HandleFunc("GET /athlete/handlers/Calendario_Borrar.ashx", s.requireSession(s.handleClassAction(s.cancel)))
+	mux.HandleFunc("GET /athlete/handlers/Calendario_ListaEspera.ashx", s.requireSession(s.handleClassAction(s.joinWaitlist)))
+	return mux
+}
+
+// requireSession redirects requests without a valid session to the login
+// page, like the site's forms authentication does
+func (s *Site) requireSession(next func(http.ResponseWriter, *http.Request, string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		email, ok := s.athlete(r)
+		if !ok {
+			http.Redirect(w, r, "/user?ReturnUrl="+r.URL.Path, http.StatusFound)
+			return
+		}
+		next(w, r, email)
+	}
+}
+
+func (s *Site) handleLoginPage(w http.ResponseWriter, _ *http.Request) {
+	render(w, loginPage, loginData{ViewState: viewState})
+}
+
+// handleLoginPost handles both the credentials form and the "trust this
+// browser" prompt, which post back to the same page
+func (s *Site) handleLoginPost(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("__VIEWSTATE") != viewState {
+		http.Error(w, "invalid view state", http.StatusBadRequest)
+		return
+	}
+
+	switch target := r.PostForm.Get("__EVENTTARGET"); target {
+	case trustTarget, dontTrustTarget:
+		cookie, err := r.Cookie(sessionCookieName)
+		if _, ok := s.athlete(r); err != nil || !ok {
+			http.Redirect(w, r, "/user", http.StatusSeeOther)
+			return
+		}
+		if target == trustTarget {
+			// Trusted browsers keep the session across restarts
+			setSessionCookie(w, cookie.Value, time.Now().Add(trustedSessionTTL))
+		}
+		http.Redirect(w, r, "/athlete/", http.StatusSeeOther)
+		return
+	}
+
+	if _, ok := r.PostForm[loginButtonField]; !ok {
+		http.Error(w, "unknown postback", http.StatusBadRequest)
+		return
+	}
+
+	token, ok := s.logIn(r.PostForm.Get(emailField), r.PostForm.Get(passwordField))
+	if !ok {
+		render(w, loginPage, loginData{
+			ViewState: viewState,
+			Error:     "Usuario o contraseña incorrectos",
+		})
+		return
+	}
+
+	setSessionCookie(w, token, time.Time{})
+	render(w, trustPage, loginData{ViewState: viewState})
+}
+
+func (s *Site) handleHome(w http.ResponseWriter, _ *http.Request, email string) {
+	render(w, homePage, homeData{Email: email})
+}
+
+// handleSchedule renders the calendar of the week of the "fecha" date,
+// showing the classes of that day
+func (s *Site) handleSchedule(w http.ResponseWriter, r *http.Request, email string) {
+	date := today()
+	if value := r.URL.Query().Get("fecha"); value != "" {
+		parsed, err := time.Parse(dateLayout, value)
+		if err != nil {
+			http.Error(w, "invalid date", http.StatusBadRequest)
+			return
+		}
+		date = parsed
+	}
+
+	monday := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	data := scheduleData{
+		PrevURL: scheduleURL(date.AddDate(0, 0, -7)),
+		NextURL: scheduleURL(date.AddDate(0, 0, 7)),
+		Ticks:   strconv.FormatInt(dotNetTicks(date), 10),
+	}
+	for i, label := range dayLabels {
+		day := monday.AddDate(0, 0, i)
+		data.Days = append(data.Days, dayTab{
+			Label:   label,
+			URL:     scheduleURL(day),
+			Current: day.Equal(date),
+		})
+	}
+
+	s.mu.Lock()
+	delay := s.scheduleDelay
+	s.mu.Unlock()
+	select {
+	case <-time.After(delay):
+	case <-r.Context().Done():
+		return
+	}
+
+	s.mu.Lock()
+	for _, class := range s.classesOn(date.Format(dateLayout)) {
+		data.Classes = append(data.Classes, classCard{
+			ID:    class.ID,
+			Name:  class.Name,
+			Hour:  class.Hour,
+			State: s.state(class, email),
+		})
+	}
+	s.mu.Unlock()
+
+	render(w, schedulePage, data)
+}
+
+// handleLoadClass returns the timetable of the "ticks" day, grouped by hour
+func (s *Site) handleLoadClass(w http.ResponseWriter, r *http.Request, email string) {
+	ticks, err := strconv.ParseInt(r.URL.Query().Get("ticks"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid ticks", http.StatusBadRequest)
+		return
+	}
+
+	type entry struct {
+		State string `json:"TipoEstado"`
+		Class struct {
+			ID    int64  `json:"Id"`
+			Name  string `json:"Nombre"`
+			Spots int    `json:"Plazas"`
+		} `json:"Valor"`
+	}
+	type slot struct {
+		Hour    string  `json:"Hora"`
+		Classes []entry `json:"Valores"`
+	}
+	response := struct {
+		Data []slot `json:"Data"`
+	}{Data: []slot{}}
+
+	s.mu.Lock()
+	for _, class := range s.classesOn(ticksDate(ticks).Format(dateLayout)) {
+		hour := class.Hour + ":00"
+		if n := len(response.Data); n == 0 || response.Data[n-1].Hour != hour {
+			response.Data = append(response.Data, slot{Hour: hour})
+		}
+
+		var e entry
+		e.State = s.state(class, email)
+		e.Class.ID = class.ID
+		e.Class.Name = class.Name
+		e.Class.Spots = class.free()
+
+		last := &response.Data[len(response.Data)-1]
+		last.Classes = append(last.Classes, e)
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, response)
+}
+
+// handleClassAction books, cancels or joins the waiting list of the "id" class
+// of the "ticks" day
+func (s *Site) handleClassAction(action func(email string, classID int64, date string) string) func(http.ResponseWriter, *http.Request, string) {
+	return func(w http.ResponseWriter, r *http.Request, email string) {
+		query := r.URL.Query()
+		id, idErr := strconv.ParseInt(query.Get("id"), 10, 64)
+		ticks, ticksErr := strconv.ParseInt(query.Get("ticks"), 10, 64)
+		if idErr != nil || ticksErr != nil {
+			http.Error(w, "invalid class", http.StatusBadRequest)
+			return
+		}
+
+		errorMsg := action(email, id, ticksDate(ticks).Format(dateLayout))
+
+		var response struct {
+			Result struct {
+				Success  bool   `json:"EsCorrecto"`
+				ErrorMsg string `json:"ErrorMsg"`
+			} `json:"Res"`
+		}
+		response.Result.Success = errorMsg == ""
+		response.Result.ErrorMsg = errorMsg
+		writeJSON(w, response)
+	}
+}
+
+func setSessionCookie(w http.ResponseWriter, token string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func scheduleURL(date time.Time) string {
+	return "/schedule?fecha=" + date.Format(dateLayout)
+}
+
+// today returns the current date at midnight UTC, the form in which dates are
+// compared throughout the site
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
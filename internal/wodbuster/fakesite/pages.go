@@ -0,0 +1,175 @@
+package fakesite
+
+import (
+	"html/template"
+	"net/http"
+)
+
+type loginData struct {
+	ViewState string
+	Error     string
+}
+
+type homeData struct {
+	Email string
+}
+
+type scheduleData struct {
+	PrevURL string
+	NextURL string
+	Ticks   string // Kept as a string, .NET ticks overflow JavaScript numbers
+	Days    []dayTab
+	Classes []classCard
+}
+
+type dayTab struct {
+	Label   string
+	URL     string
+	Current bool
+}
+
+type classCard struct {
+	ID    int64
+	Name  string
+	Hour  string
+	State string
+}
+
+func render(w http.ResponseWriter, page *template.Template, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := page.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// aspNetForm is the WebForms boilerplate shared by the login and trust pages
+const aspNetForm = `
+<input type="hidden" name="__EVENTTARGET" id="__EVENTTARGET" value="" />
+<input type="hidden" name="__EVENTARGUMENT" id="__EVENTARGUMENT" value="" />
+<input type="hidden" name="__VIEWSTATE" id="__VIEWSTATE" value="{{.ViewState}}" />
+<script>
+function __doPostBack(eventTarget, eventArgument) {
+	var form = document.getElementById('form1');
+	form.__EVENTTARGET.value = eventTarget;
+	form.__EVENTARGUMENT.value = eventArgument;
+	form.submit();
+}
+</script>`
+
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><title>WodBuster</title></head>
+<body>
+<form method="post" action="/user" id="form1">` + aspNetForm + `
+<div id="body_body_CtlLogin">
+	{{if .Error}}<span class="error">{{.Error}}</span>{{end}}
+	<input name="ctl00$ctl00$body$body$CtlLogin$IoEmail" type="email" id="body_body_CtlLogin_IoEmail" />
+	<input name="ctl00$ctl00$body$body$CtlLogin$IoPassword" type="password" id="body_body_CtlLogin_IoPassword" />
+	<input type="submit" name="ctl00$ctl00$body$body$CtlLogin$CtlAceptar" value="Aceptar" id="body_body_CtlLogin_CtlAceptar" />
+</div>
+</form>
+</body>
+</html>`))
+
+var trustPage = template.Must(template.New("trust").Parse(`<!DOCTYPE html>
+<html>
+<head><title>WodBuster</title></head>
+<body>
+<form method="post" action="/user" id="form1">` + aspNetForm + `
+<div id="body_body_CtlUp">
+	<p>¿Confías en este navegador?</p>
+	<a id="body_body_CtlConfiar_CtlSeguro" class="button" href="javascript:__doPostBack('ctl00$ctl00$body$body$CtlConfiar$CtlSeguro','')">Sí, recordar</a>
+	<a id="body_body_CtlConfiar_CtlNoSeguroConfianza" class="button secondary" href="javascript:__doPostBack('ctl00$ctl00$body$body$CtlConfiar$CtlNoSeguroConfianza','')">No</a>
+</div>
+</form>
+</body>
+</html>`))
+
+var homePage = template.Must(template.New("home").Parse(`<!DOCTYPE html>
+<html>
+<head><title>WodBuster</title></head>
+<body>
+<p>{{.Email}}</p>
+<a href="/schedule">Reservar clases</a>
+</body>
+</html>`))
+
+var schedulePage = template.Must(template.New("schedule").Parse(`<!DOCTYPE html>
+<html>
+<head><title>WodBuster</title></head>
+<body>
+<div id="calendar">
+	<div class="semana">
+		<a class="prev icon" href="{{.PrevURL}}">&lt;</a>
+		{{range .Days}}<a class="dia{{if .Current}} current{{end}}" href="{{.URL}}"><span>{{.Label}}</span></a>
+		{{end}}
+		<a class="next icon" href="{{.NextURL}}">&gt;</a>
+	</div>
+	<div class="listado">
+	{{range .Classes}}
+		<div class="clase">
+			<div class="entrenamientoHead">
+				<div class="namehour">
+					<h3 class="entrenamiento">{{.Name}}</h3>
+					<div class="hora">{{.Hour}}</div>
+				</div>
+			</div>
+			<div class="actionsjs">
+			{{if eq .State "Inscribible"}}
+				<button class="button entrenar" onclick="reservar({{.ID}}, {{$.Ticks}})">Reservar</button>
+			{{else if eq .State "Borrable"}}
+				<button class="button entrenar alert" onclick="borrar({{.ID}}, {{$.Ticks}})">Borrar</button>
+			{{else if eq .State "ListaEspera"}}
+				<button class="button entrenar secondary" onclick="esperar({{.ID}}, {{$.Ticks}})">Lista de espera</button>
+			{{else if eq .State "EnListaEspera"}}
+				<span class="estado">En lista de espera</span>
+			{{else}}
+				<span class="estado">{{.State}}</span>
+			{{end}}
+			</div>
+		</div>
+	{{end}}
+	</div>
+</div>
+
+<div id="confirmacion" class="reveal-modal" style="display: none">
+	<h4>Confirmación Requerida</h4>
+	<p id="pregunta"></p>
+	<button class="button small radius" onclick="aceptar()">Aceptar</button>
+	<button class="button small radius secondary" onclick="cancelar()">Cancelar</button>
+</div>
+
+<script>
+var pending = null;
+
+function confirmar(handler, question, id, ticks) {
+	pending = '/athlete/handlers/' + handler + '?id=' + id + '&ticks=' + ticks;
+	document.getElementById('pregunta').textContent = question;
+	document.getElementById('confirmacion').style.display = 'block';
+}
+
+function reservar(id, ticks) {
+	confirmar('Calendario_Inscribir.ashx', '¿Quieres reservar esta clase?', id, ticks);
+}
+
+function borrar(id, ticks) {
+	confirmar('Calendario_Borrar.ashx', '¿Quieres borrar tu reserva?', id, ticks);
+}
+
+function esperar(id, ticks) {
+	confirmar('Calendario_ListaEspera.ashx', '¿Quieres apuntarte a la lista de espera?', id, ticks);
+}
+
+function cancelar() {
+	pending = null;
+	document.getElementById('confirmacion').style.display = 'none';
+}
+
+function aceptar() {
+	fetch(pending, {credentials: 'same-origin'}).then(function () {
+		location.reload();
+	});
+}
+</script>
+</body>
+</html>`))
@@ -0,0 +1,393 @@
+// Package fakesite serves a local imitation of a WODBuster box site for
+// offline end-to-end tests of the wodbuster clients. It reproduces the pieces
+// the clients rely on: the ASP.NET login form and "trust this browser" prompt,
+// the "Reservar clases" calendar with day tabs, class cards and the booking
+// confirmation dialog, and the JSON handlers behind the calendar.
+//
+// The site state is scriptable, so tests can set up full classes, closed
+// booking windows and expired sessions.
+package fakesite
+
+import (
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Class states reported by the LoadClass handler
+const (
+	StateBookable   = "Inscribible"   // "Reservar" button shown
+	StateBooked     = "Borrable"      // Booked by the current user
+	StateFull       = "Completa"      // No spots left
+	StateClosed     = "Cerrada"       // Booking window not open
+	StateWaitlist   = "ListaEspera"   // Full, "Lista de espera" button shown
+	StateWaitlisted = "EnListaEspera" // Current user is on the waiting list
+)
+
+// Error messages returned by the booking handler
+const (
+	ErrorMsgFull       = "La clase está completa"
+	ErrorMsgClosed     = "Las reservas no están abiertas"
+	ErrorMsgNotFound   = "La clase no existe"
+	ErrorMsgNotBooked  = "No tienes reserva en esta clase"
+	ErrorMsgNoWaitlist = "La clase no tiene lista de espera"
+	ErrorMsgNoCancel   = "Ya no se puede cancelar esta reserva"
+)
+
+const (
+	sessionCookieName = ".WBAuth"
+	viewState         = "fake-viewstate"
+	dateLayout        = "2006-01-02"
+
+	unixEpochTicks = 621355968000000000
+	ticksPerSecond = 10000000
+)
+
+// Class is a class in the site's timetable
+type Class struct {
+	ID        int64
+	Date      string // YYYY-MM-DD
+	Hour      string // HH:MM
+	Name      string // e.g. "Wod", "Open box"
+	Spots     int    // Capacity of the class
+	Attendees []string
+	Waitlist  []string // Athletes waiting for a spot, in order
+}
+
+// free returns the number of spots left
+func (c *Class) free() int {
+	return max(c.Spots-len(c.Attendees), 0)
+}
+
+// remove frees the athlete's spot and hands it to the first athlete on the
+// waiting list. It reports whether the athlete was booked.
+func (c *Class) remove(email string) bool {
+	index := slices.Index(c.Attendees, email)
+	if index < 0 {
+		return false
+	}
+	c.Attendees = slices.Delete(c.Attendees, index, index+1)
+
+	if len(c.Waitlist) > 0 && c.free() > 0 {
+		c.Attendees = append(c.Attendees, c.Waitlist[0])
+		c.Waitlist = c.Waitlist[1:]
+	}
+	return true
+}
+
+// Site is a running fake WODBuster site
+type Site struct {
+	server *httptest.Server
+
+	mu           sync.Mutex
+	users        map[string]string // email -> password
+	sessions     map[string]string // session token -> email
+	classes      []*Class
+	nextID       int64
+	bookingsOpen bool
+	// cancellationsOpen lets athletes cancel their bookings
+	cancellationsOpen bool
+	// waitlists makes full classes offer a waiting list
+	waitlists bool
+	logins    int
+	// scheduleDelay holds back the calendar pages, as a slow site does
+	scheduleDelay time.Duration
+}
+
+// New starts a fake site with no users or classes and bookings open. Close
+// must be called to shut it down.
+func New() *Site {
+	site := &Site{
+		users:             make(map[string]string),
+		sessions:          make(map[string]string),
+		nextID:            1,
+		bookingsOpen:      true,
+		cancellationsOpen: true,
+	}
+	site.server = httptest.NewServer(site.routes())
+	return site
+}
+
+// URL returns the base URL of the site, to be used as the clients' base URL
+func (s *Site) URL() string {
+	return s.server.URL
+}
+
+// Close shuts the site down
+func (s *Site) Close() {
+	s.server.Close()
+}
+
+// AddUser registers an athlete who can log in
+func (s *Site) AddUser(email, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[email] = password
+}
+
+// AddClass adds a class to the timetable of the given date and returns its id.
+// A class with zero spots is full.
+func (s *Site) AddClass(date time.Time, hour, name string, spots int) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	class := &Class{
+		ID:    s.nextID,
+		Date:  date.Format(dateLayout),
+		Hour:  hour,
+		Name:  name,
+		Spots: spots,
+	}
+	s.nextID++
+	s.classes = append(s.classes, class)
+	return class.ID
+}
+
+// AddAttendee books the class for the athlete, bypassing the booking window
+func (s *Site) AddAttendee(classID int64, email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if class := s.class(classID); class != nil && !slices.Contains(class.Attendees, email) {
+		class.Attendees = append(class.Attendees, email)
+	}
+}
+
+// SetBookingsOpen opens or closes the booking window of every class
+func (s *Site) SetBookingsOpen(open bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bookingsOpen = open
+}
+
+// SetCancellationsOpen lets athletes cancel their bookings or not, as the site
+// does once a class is about to start
+func (s *Site) SetCancellationsOpen(open bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancellationsOpen = open
+}
+
+// SetWaitlists makes full classes offer a waiting list or not
+func (s *Site) SetWaitlists(offered bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.waitlists = offered
+}
+
+// SetScheduleDelay makes the calendar pages take the given time to load
+func (s *Site) SetScheduleDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scheduleDelay = delay
+}
+
+// RemoveAttendee frees the athlete's spot in the class, as a cancellation by
+// another athlete does. The first athlete on the waiting list takes it.
+func (s *Site) RemoveAttendee(classID int64, email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if class := s.class(classID); class != nil {
+		class.remove(email)
+	}
+}
+
+// ExpireSessions invalidates every session, as the site does when the
+// authentication cookie expires
+func (s *Site) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.sessions)
+}
+
+// Logins returns how many times athletes logged in
+func (s *Site) Logins() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.logins
+}
+
+// IsBooked reports whether the athlete holds a spot in the class
+func (s *Site) IsBooked(email string, classID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	class := s.class(classID)
+	return class != nil && slices.Contains(class.Attendees, email)
+}
+
+// IsWaitlisted reports whether the athlete is on the class's waiting list
+func (s *Site) IsWaitlisted(email string, classID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	class := s.class(classID)
+	return class != nil && slices.Contains(class.Waitlist, email)
+}
+
+// Attendees returns the athletes booked in the class
+func (s *Site) Attendees(classID int64) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if class := s.class(classID); class != nil {
+		return slices.Clone(class.Attendees)
+	}
+	return nil
+}
+
+// class returns the class with the given id. The caller must hold mu.
+func (s *Site) class(id int64) *Class {
+	for _, class := range s.classes {
+		if class.ID == id {
+			return class
+		}
+	}
+	return nil
+}
+
+// classesOn returns the classes of a date sorted by hour. The caller must hold mu.
+func (s *Site) classesOn(date string) []*Class {
+	var classes []*Class
+	for _, class := range s.classes {
+		if class.Date == date {
+			classes = append(classes, class)
+		}
+	}
+	slices.SortStableFunc(classes, func(a, b *Class) int {
+		switch {
+		case a.Hour < b.Hour:
+			return -1
+		case a.Hour > b.Hour:
+			return 1
+		}
+		return 0
+	})
+	return classes
+}
+
+// state returns the state of the class as seen by the athlete. The caller must hold mu.
+func (s *Site) state(class *Class, email string) string {
+	switch {
+	case slices.Contains(class.Attendees, email):
+		return StateBooked
+	case slices.Contains(class.Waitlist, email):
+		return StateWaitlisted
+	case !s.bookingsOpen:
+		return StateClosed
+	case class.free() == 0 && s.waitlists:
+		return StateWaitlist
+	case class.free() == 0:
+		return StateFull
+	}
+	return StateBookable
+}
+
+// book adds the athlete to the class of the given date, returning the site's
+// error message when the booking is rejected
+func (s *Site) book(email string, classID int64, date string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	class := s.class(classID)
+	if class == nil || class.Date != date {
+		return ErrorMsgNotFound
+	}
+
+	switch s.state(class, email) {
+	case StateBooked:
+		return ""
+	case StateClosed:
+		return ErrorMsgClosed
+	case StateFull, StateWaitlist, StateWaitlisted:
+		return ErrorMsgFull
+	}
+
+	class.Attendees = append(class.Attendees, email)
+	return ""
+}
+
+// joinWaitlist puts the athlete on the waiting list of the full class of the
+// given date, returning the site's error message when it is rejected
+func (s *Site) joinWaitlist(email string, classID int64, date string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	class := s.class(classID)
+	if class == nil || class.Date != date {
+		return ErrorMsgNotFound
+	}
+
+	switch s.state(class, email) {
+	case StateBooked, StateWaitlisted:
+		return ""
+	case StateWaitlist:
+		class.Waitlist = append(class.Waitlist, email)
+		return ""
+	}
+	return ErrorMsgNoWaitlist
+}
+
+// cancel removes the athlete from the class of the given date, returning the
+// site's error message when the cancellation is rejected
+func (s *Site) cancel(email string, classID int64, date string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	class := s.class(classID)
+	if class == nil || class.Date != date {
+		return ErrorMsgNotFound
+	}
+	if !s.cancellationsOpen && slices.Contains(class.Attendees, email) {
+		return ErrorMsgNoCancel
+	}
+
+	if !class.remove(email) {
+		return ErrorMsgNotBooked
+	}
+	return ""
+}
+
+// logIn checks the credentials and opens a session for the athlete
+func (s *Site) logIn(email, password string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.users[email]; !ok || stored != password {
+		return "", false
+	}
+
+	token := rand.Text()
+	s.sessions[token] = email
+	s.logins++
+	return token, true
+}
+
+// athlete returns the email of the athlete whose session the request carries
+func (s *Site) athlete(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return "", false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	email, ok := s.sessions[cookie.Value]
+	return email, ok
+}
+
+// dotNetTicks converts a date to .NET ticks, which is how the site identifies days
+func dotNetTicks(date time.Time) int64 {
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return unixEpochTicks + midnight.Unix()*ticksPerSecond
+}
+
+// ticksDate converts .NET ticks back to a date
+func ticksDate(ticks int64) time.Time {
+	return time.Unix((ticks-unixEpochTicks)/ticksPerSecond, 0).UTC()
+}
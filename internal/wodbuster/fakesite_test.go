@@ -0,0 +1,307 @@
+package wodbuster
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/wodbuster/fakesite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireChrome skips the test when no local Chrome is available
+func requireChrome(t *testing.T) {
+	t.Helper()
+
+	for _, name := range []string{"headless-shell", "headless_shell", "chromium", "chromium-browser", "google-chrome", "google-chrome-stable"} {
+		if _, err := exec.LookPath(name); err == nil {
+			return
+		}
+	}
+	if _, err := os.Stat("/Applications/Google Chrome.app/Contents/MacOS/Google Chrome"); err == nil {
+		return
+	}
+	t.Skip("Chrome is not installed")
+}
+
+// setupFakeSiteClient creates a headless browser client for the fake site.
+// Its operations are bounded so a missing element fails the test instead of
+// waiting for the client's default timeout.
+func setupFakeSiteClient(t *testing.T, site *fakesite.Site) *Client {
+	t.Helper()
+	requireChrome(t)
+
+	client, err := NewClient(site.URL(), WithHeadlessMode(true))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(client.ctx, 90*time.Second)
+	clientCancel := client.cancel
+	client.ctx = ctx
+	client.cancel = func() {
+		cancel()
+		clientCancel()
+	}
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+// dayOf returns the day of the date
+func dayOf(date time.Time) models.Day {
+	return models.DayOf(date.Weekday())
+}
+
+func TestClient_LogInOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+
+	client := setupFakeSiteClient(t, site)
+
+	cookie, err := client.LogIn(context.Background(), "athlete@example.com", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, ".WBAuth", cookie.Name)
+	assert.NotEmpty(t, cookie.Value)
+}
+
+func TestClient_BookClassOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+
+	wednesday := dateInNextWeek(time.Now(), time.Wednesday)
+	site.AddClass(wednesday, "07:00", "Wod", 10)
+	classID := site.AddClass(wednesday, "19:30", "Wod", 10)
+
+	client := setupFakeSiteClient(t, site)
+
+	err := client.BookClass(context.Background(), "athlete@example.com", "secret", models.DayWednesday, string(ClassTypeWod), "19:30")
+	require.NoError(t, err)
+	assert.True(t, site.IsBooked("athlete@example.com", classID))
+}
+
+func TestClient_BookClassWithStoredSessionOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	classID := site.AddClass(dateInNextWeek(time.Now(), time.Friday), "08:00", "Open box", 10)
+
+	login := setupFakeSiteClient(t, site)
+	_, err := login.LogIn(context.Background(), "athlete@example.com", "secret")
+	require.NoError(t, err)
+
+	client := setupFakeSiteClient(t, site)
+	require.NoError(t, client.LoadStoredSession(context.Background(), login.GetCookies()))
+
+	err = client.BookClass(context.Background(), "athlete@example.com", "", models.DayFriday, string(ClassTypeOpenBox), "08:00")
+	require.NoError(t, err)
+	assert.True(t, site.IsBooked("athlete@example.com", classID))
+}
+
+func TestClient_GetAvailableClassesOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+
+	today := time.Now()
+	site.AddClass(today, "07:00", "Wod", 10)
+	site.AddClass(today, "08:00", "HYROX *", 0)
+
+	client := setupFakeSiteClient(t, site)
+
+	classes, err := client.GetAvailableClasses(context.Background(), "athlete@example.com", "secret", today)
+	require.NoError(t, err)
+	require.Len(t, classes, 2)
+
+	assert.Equal(t, dayOf(today), classes[0].Day)
+	assert.Equal(t, "07:00", classes[0].Hour)
+	assert.Equal(t, string(ClassTypeWod), classes[0].ClassType)
+	assert.True(t, classes[0].Available)
+
+	assert.Equal(t, "08:00", classes[1].Hour)
+	assert.Equal(t, string(ClassTypeHyrox), classes[1].ClassType)
+	assert.False(t, classes[1].Available, "full classes have no Reservar button")
+}
+
+func TestClient_RemoveBookingOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+
+	thursday, _ := upcomingClassDate(time.Now(), time.Thursday)
+	classID := site.AddClass(thursday, "18:00", "Wod", 10)
+	site.AddAttendee(classID, "athlete@example.com")
+
+	client := setupFakeSiteClient(t, site)
+
+	err := client.RemoveBooking(context.Background(), "athlete@example.com", "secret", models.DayThursday, string(ClassTypeWod), "18:00")
+	require.NoError(t, err)
+	assert.False(t, site.IsBooked("athlete@example.com", classID))
+}
+
+func TestClient_RemoveBookingRejectedOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	site.SetCancellationsOpen(false)
+
+	thursday, _ := upcomingClassDate(time.Now(), time.Thursday)
+	classID := site.AddClass(thursday, "18:00", "Wod", 10)
+	site.AddAttendee(classID, "athlete@example.com")
+
+	client := setupFakeSiteClient(t, site)
+
+	err := client.RemoveBooking(context.Background(), "athlete@example.com", "secret", models.DayThursday, string(ClassTypeWod), "18:00")
+	assert.ErrorIs(t, err, ErrNavigationTimeout)
+	assert.True(t, site.IsBooked("athlete@example.com", classID))
+}
+
+func TestClient_BurstBookingOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	tuesday := dateInNextWeek(time.Now(), time.Tuesday)
+	classID := site.AddClass(tuesday, "19:00", "Wod", 10)
+	site.SetBookingsOpen(false)
+
+	client := setupFakeSiteClient(t, site)
+	require.NoError(t, client.PrepareBooking(context.Background(), "athlete@example.com", "secret", tuesday))
+
+	_, err := client.TryBookClass(context.Background(), string(ClassTypeWod), "20:00")
+	assert.ErrorIs(t, err, ErrClassNotFound)
+
+	booked, err := client.TryBookClass(context.Background(), string(ClassTypeWod), "19:00")
+	require.NoError(t, err)
+	assert.False(t, booked, "the window is not open yet")
+
+	site.SetBookingsOpen(true)
+	booked, err = client.TryBookClass(context.Background(), string(ClassTypeWod), "19:00")
+	require.NoError(t, err)
+	assert.True(t, booked)
+	assert.True(t, site.IsBooked("athlete@example.com", classID))
+}
+
+func TestClient_BookClassesOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	today := time.Now()
+	wednesday := dateInNextWeek(today, time.Wednesday)
+	todayID := site.AddClass(today, "20:00", "Wod", 10)
+	wednesdayID := site.AddClass(wednesday, "07:00", "Wod", 10)
+
+	client := setupFakeSiteClient(t, site)
+	_, err := client.BookClasses(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrNotPrepared)
+
+	// The classes switch between the weeks the calendar shows
+	require.NoError(t, client.PrepareBooking(context.Background(), "athlete@example.com", "secret", today))
+	results, err := client.BookClasses(context.Background(), []models.ClassRequest{
+		{Date: wednesday, ClassType: string(ClassTypeWod), Hour: "07:00"},
+		{Date: today, ClassType: string(ClassTypeWod), Hour: "20:00"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Booked)
+	assert.True(t, results[1].Booked)
+	assert.True(t, site.IsBooked("athlete@example.com", wednesdayID))
+	assert.True(t, site.IsBooked("athlete@example.com", todayID))
+	assert.Equal(t, 1, site.Logins())
+}
+
+func TestClient_BookClassWithApostropheOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	monday := dateInNextWeek(time.Now(), time.Monday)
+	classID := site.AddClass(monday, "18:00", "Women's WOD", 10)
+
+	client := setupFakeSiteClient(t, site)
+	require.NoError(t, client.PrepareBooking(context.Background(), "athlete@example.com", "secret", monday))
+
+	booked, err := client.TryBookClass(context.Background(), "Women's WOD", "18:00")
+	require.NoError(t, err)
+	assert.True(t, booked)
+	assert.True(t, site.IsBooked("athlete@example.com", classID))
+}
+
+func TestClient_CancelBookingOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	tuesday := dateInNextWeek(time.Now(), time.Tuesday)
+	site.AddClass(tuesday, "19:00", "Wod", 10)
+
+	client := setupFakeSiteClient(t, site)
+	require.NoError(t, client.PrepareBooking(context.Background(), "athlete@example.com", "secret", tuesday))
+
+	// The day takes longer to load than the booking is allowed to run
+	site.SetScheduleDelay(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Second, cancel)
+
+	start := time.Now()
+	_, err := client.TryBookClass(ctx, string(ClassTypeWod), "19:00")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 10*time.Second, "the attempt stops when it is cancelled")
+
+	// The browser outlives the cancelled attempt
+	site.SetScheduleDelay(0)
+	require.NoError(t, client.PrepareBooking(context.Background(), "athlete@example.com", "secret", tuesday))
+}
+
+func TestClient_WaitlistOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	friday := dateInNextWeek(time.Now(), time.Friday)
+	classID := site.AddClass(friday, "07:00", "Wod", 1)
+	site.AddAttendee(classID, "other@example.com")
+	site.SetWaitlists(true)
+
+	client := setupFakeSiteClient(t, site)
+	require.NoError(t, client.PrepareBooking(context.Background(), "athlete@example.com", "secret", friday))
+
+	_, err := client.TryBookClass(context.Background(), string(ClassTypeWod), "07:00")
+	assert.ErrorIs(t, err, ErrClassFull)
+
+	joined, err := client.JoinWaitlist(context.Background(), string(ClassTypeWod), "07:00")
+	require.NoError(t, err)
+	assert.True(t, joined)
+	assert.True(t, site.IsWaitlisted("athlete@example.com", classID))
+}
+
+func TestClient_DeadStoredSessionOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+
+	login := setupFakeSiteClient(t, site)
+	_, err := login.LogIn(context.Background(), "athlete@example.com", "secret")
+	require.NoError(t, err)
+	stored := login.GetCookies()
+	site.ExpireSessions()
+
+	// The dead cookies are dropped instead of being handed back as a session
+	client := setupFakeSiteClient(t, site)
+	err = client.LoadStoredSession(context.Background(), stored)
+	assert.ErrorIs(t, err, ErrSessionExpired)
+	assert.Empty(t, client.GetCookies())
+
+	// Logging in opens a new session instead of reusing the dead one
+	_, err = client.LogIn(context.Background(), "athlete@example.com", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, 2, site.Logins())
+
+	// Neither does a client created with the dead cookies
+	client, err = NewClient(site.URL(), WithHeadlessMode(true), WithStoredCookies(stored))
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.LogIn(context.Background(), "athlete@example.com", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, 3, site.Logins())
+}
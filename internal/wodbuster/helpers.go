@@ -1,5 +1,14 @@
 package wodbuster
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+)
+
 // cleanClassType removes extra whitespace and special characters from class type
 func cleanClassType(classType string) string {
 	// Remove leading/trailing whitespace
@@ -28,3 +37,61 @@ func trimSpace(s string) string {
 
 	return s[start:end]
 }
+
+// upcomingClassDate returns the next date, today included, falling on the
+// weekday and whether it lies in the calendar week after now's
+func upcomingClassDate(now time.Time, weekday time.Weekday) (time.Time, bool) {
+	daysAhead := (int(weekday) - int(now.Weekday()) + 7) % 7
+	daysUntilSunday := (int(time.Sunday) - int(now.Weekday()) + 7) % 7
+	return now.AddDate(0, 0, daysAhead), daysAhead > daysUntilSunday
+}
+
+// calendarDay returns the calendar tab of the date and whether it lies in the
+// week after now's. The calendar only shows the current and the next week.
+func calendarDay(now, date time.Time) (string, bool, error) {
+	weeks := int(weekStart(date).Sub(weekStart(now)).Hours() / (7 * 24))
+	if weeks != 0 && weeks != 1 {
+		return "", false, fmt.Errorf("date %s is not in the current or next week", date.Format("2006-01-02"))
+	}
+
+	return models.DayOf(date.Weekday()).CalendarTab(), weeks == 1, nil
+}
+
+// weekStart returns the Monday of t's calendar week
+func weekStart(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) - int(time.Monday) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
+
+// calendarTab returns the abbreviation of the day's calendar tab
+func calendarTab(day models.Day) (string, error) {
+	tab := day.CalendarTab()
+	if tab == "" {
+		return "", fmt.Errorf("%w: %q", models.ErrInvalidDay, day)
+	}
+	return tab, nil
+}
+
+// bookFirstAvailable makes a single attempt to book the class with try and,
+// when its "Reservar" button is missing, because it is full, gone or not
+// bookable, tries its alternatives in order. Errors of the alternatives are
+// not reported: the class's own outcome is, when none of them is booked.
+func bookFirstAvailable(ctx context.Context, class models.ClassRequest, try func(ctx context.Context, classType, hour string) (bool, error)) models.ClassResult {
+	result := models.ClassResult{ClassRequest: class}
+	result.Booked, result.Err = try(ctx, class.ClassType, class.Hour)
+	if result.Booked || (result.Err != nil && !errors.Is(result.Err, ErrClassFull) && !errors.Is(result.Err, ErrClassNotFound)) {
+		return result
+	}
+
+	for _, alternative := range class.Alternatives {
+		if ctx.Err() != nil {
+			break
+		}
+		if booked, err := try(ctx, alternative.ClassType, alternative.Hour); err == nil && booked {
+			result.Booked, result.Err = true, nil
+			result.BookedAlternative = &alternative
+			return result
+		}
+	}
+	return result
+}
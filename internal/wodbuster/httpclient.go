@@ -0,0 +1,718 @@
+package wodbuster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+)
+
+// Paths and element ids of the WODBuster site used by the HTTP client
+const (
+	loginPath        = "/user"
+	loadClassPath    = "/athlete/handlers/LoadClass.ashx"
+	bookClassPath    = "/athlete/handlers/Calendario_Inscribir.ashx"
+	cancelClassPath  = "/athlete/handlers/Calendario_Borrar.ashx"
+	waitlistPath     = "/athlete/handlers/Calendario_ListaEspera.ashx"
+	sessionCookieKey = ".WBAuth"
+
+	loginEmailID       = "body_body_CtlLogin_IoEmail"
+	loginPasswordID    = "body_body_CtlLogin_IoPassword"
+	loginSubmitID      = "body_body_CtlLogin_CtlAceptar"
+	trustBrowserID     = "body_body_CtlConfiar_CtlSeguro"
+	dontTrustBrowserID = "body_body_CtlConfiar_CtlNoSeguroConfianza"
+)
+
+// Class states reported by the LoadClass handler
+const (
+	classStateBookable   = "Inscribible"   // "Reservar" button shown
+	classStateBooked     = "Borrable"      // Already booked by the user
+	classStateWaitlist   = "ListaEspera"   // Full, "Lista de espera" button shown
+	classStateWaitlisted = "EnListaEspera" // The user is on the waiting list
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrSessionExpired     = errors.New("session expired")
+	ErrClassNotFound      = errors.New("class not found")
+	ErrClassNotBookable   = errors.New("class cannot be booked")
+	ErrBookingNotFound    = errors.New("class is not booked")
+	ErrClassFull          = errors.New("class is full")
+	ErrRequestRejected    = errors.New("request rejected")
+)
+
+// HTTPClient talks to WODBuster with plain HTTP requests: the ASP.NET login
+// form and the JSON handlers behind the calendar. It implements the same
+// operations as Client without driving a browser, so a booking takes a couple
+// of round trips instead of several seconds of page automation.
+type HTTPClient struct {
+	baseURL string
+	logger  *slog.Logger
+	http    *http.Client
+	cookies *cookieRecorder
+	// sessionRestored is set once LoadStoredSession validated the stored
+	// cookies, or PrepareBooking logged in
+	sessionRestored bool
+	// preparedDate is the class date PrepareBooking loaded the timetable of
+	preparedDate time.Time
+}
+
+// HTTPOption defines the method to customize the HTTPClient.
+type HTTPOption func(*HTTPClient)
+
+// WithHTTPLogger allows setting a custom logger for the client.
+func WithHTTPLogger(logger *slog.Logger) HTTPOption {
+	return func(c *HTTPClient) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithHTTPTransport allows sharing a transport, and its open connections,
+// between clients. Each client keeps its own cookie jar.
+func WithHTTPTransport(transport http.RoundTripper) HTTPOption {
+	return func(c *HTTPClient) {
+		if transport != nil {
+			c.cookies.base = transport
+		}
+	}
+}
+
+// WithHTTPTimeout sets the timeout of every request.
+func WithHTTPTimeout(timeout time.Duration) HTTPOption {
+	return func(c *HTTPClient) {
+		c.http.Timeout = timeout
+	}
+}
+
+// NewHTTPClient creates a new WODBuster HTTP client with the given base URL and options
+func NewHTTPClient(baseURL string, opts ...HTTPOption) (*HTTPClient, error) {
+	if baseURL == "" {
+		return nil, ErrMissingBaseURL
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+
+	recorder := &cookieRecorder{base: http.DefaultTransport, cookies: make(map[string]*http.Cookie)}
+	client := &HTTPClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		logger:  slog.Default(),
+		http: &http.Client{
+			Jar:       jar,
+			Transport: recorder,
+			Timeout:   30 * time.Second,
+		},
+		cookies: recorder,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, nil
+}
+
+// LogIn authenticates the user with email and password, trusting this client
+// as a known browser, and returns the session cookie
+func (c *HTTPClient) LogIn(ctx context.Context, email, password string) (*http.Cookie, error) {
+	if email == "" || password == "" {
+		return nil, fmt.Errorf("email and password are required")
+	}
+
+	if err := c.login(ctx, email, password, true); err != nil {
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+
+	sessionCookie := c.cookies.get(sessionCookieKey)
+	if sessionCookie == nil {
+		c.logger.Warn("No session cookie found after successful login")
+		return nil, fmt.Errorf("no session cookie found after login")
+	}
+
+	c.logger.Info("Successfully logged in",
+		"username", email,
+		"url", c.baseURL,
+		"session_cookie_found", true)
+	return sessionCookie, nil
+}
+
+// LoadStoredSession initializes the client with stored cookies and validates the session
+func (c *HTTPClient) LoadStoredSession(ctx context.Context, cookies []*http.Cookie) error {
+	if len(cookies) == 0 {
+		return fmt.Errorf("no cookies provided")
+	}
+
+	siteURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	c.http.Jar.SetCookies(siteURL, cookies)
+	for _, cookie := range cookies {
+		c.cookies.set(cookie)
+	}
+
+	// Loading today's classes only works with an authenticated session
+	if _, err := c.loadClasses(ctx, time.Now()); err != nil {
+		c.sessionRestored = false
+		return fmt.Errorf("session validation failed: %w", err)
+	}
+
+	c.sessionRestored = true
+	c.logger.Info("Session loaded and validated successfully")
+	return nil
+}
+
+// GetCookies returns the cookies set by WODBuster during this client's session
+func (c *HTTPClient) GetCookies() []*http.Cookie {
+	return c.cookies.all()
+}
+
+// BookClass books a class next week for a specific day, class type, and hour.
+// It takes the same arguments as Client.BookClass; an empty password books
+// within the session restored by LoadStoredSession.
+func (c *HTTPClient) BookClass(ctx context.Context, email, password string, day models.Day, classType, hour string) error {
+	if day == "" || classType == "" || hour == "" {
+		return fmt.Errorf("day, classType, and hour are required")
+	}
+	if password == "" && !c.sessionRestored {
+		return ErrNoSession
+	}
+
+	weekday, ok := day.Weekday()
+	if !ok {
+		return fmt.Errorf("%w: %q", models.ErrInvalidDay, day)
+	}
+
+	c.logger.Info("Starting class booking",
+		"day", day,
+		"classType", classType,
+		"hour", hour)
+
+	if password != "" {
+		if err := c.login(ctx, email, password, false); err != nil {
+			return fmt.Errorf("failed to book class: %w", err)
+		}
+	}
+
+	classDate := dateInNextWeek(time.Now(), weekday)
+	if err := c.bookClass(ctx, classDate, classType, hour); err != nil {
+		c.logger.Error("Failed to book class",
+			"error", err,
+			"day", day,
+			"classType", classType,
+			"hour", hour)
+		return fmt.Errorf("failed to book class: %w", err)
+	}
+
+	c.logger.Info("Successfully booked class",
+		"day", day,
+		"classType", classType,
+		"hour", hour)
+
+	return nil
+}
+
+// PrepareBooking logs in, or checks the session restored by LoadStoredSession
+// when the password is empty, and loads the timetable of the class date, so
+// TryBookClass only has to fire the booking itself
+func (c *HTTPClient) PrepareBooking(ctx context.Context, email, password string, classDate time.Time) error {
+	if password == "" && !c.sessionRestored {
+		return ErrNoSession
+	}
+
+	if password != "" {
+		if err := c.login(ctx, email, password, false); err != nil {
+			return fmt.Errorf("failed to prepare booking: %w", err)
+		}
+		c.sessionRestored = true
+	}
+
+	if _, err := c.loadClasses(ctx, classDate); err != nil {
+		return fmt.Errorf("failed to prepare booking: %w", err)
+	}
+
+	c.preparedDate = classDate
+	c.logger.Info("Booking prepared", "date", classDate.Format("2006-01-02"))
+	return nil
+}
+
+// TryBookClass makes a single attempt to book the class on the day loaded by
+// PrepareBooking. It reports false without an error while the class cannot be
+// booked yet, so it can be called in a loop as the booking window opens.
+func (c *HTTPClient) TryBookClass(ctx context.Context, classType, hour string) (bool, error) {
+	if c.preparedDate.IsZero() {
+		return false, ErrNotPrepared
+	}
+
+	timetable, err := c.loadClasses(ctx, c.preparedDate)
+	if err != nil {
+		return false, err
+	}
+
+	class, ok := timetable.find(classType, hour)
+	if !ok {
+		return false, fmt.Errorf("%w: %s at %s", ErrClassNotFound, classType, hour)
+	}
+
+	switch class.State {
+	case classStateBooked:
+		return true, nil
+	case classStateBookable:
+		if err := c.classAction(ctx, bookClassPath, class.Class.ID, c.preparedDate); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if class.Class.Spots == 0 {
+		return false, fmt.Errorf("%w: %s at %s", ErrClassFull, classType, hour)
+	}
+
+	return false, nil
+}
+
+// BookClasses books the classes in the given order within the session opened
+// by PrepareBooking. It behaves as Client.BookClasses: a single attempt per
+// class, without logging in again.
+func (c *HTTPClient) BookClasses(ctx context.Context, classes []models.ClassRequest) ([]models.ClassResult, error) {
+	if c.preparedDate.IsZero() {
+		return nil, ErrNotPrepared
+	}
+
+	results := make([]models.ClassResult, len(classes))
+	for i, class := range classes {
+		results[i] = models.ClassResult{ClassRequest: class}
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		c.preparedDate = class.Date
+		results[i] = bookFirstAvailable(ctx, class, c.TryBookClass)
+	}
+
+	return results, nil
+}
+
+// JoinWaitlist joins the waiting list of the full class on the day loaded by
+// PrepareBooking. It reports false without an error when the class offers no
+// waiting list.
+func (c *HTTPClient) JoinWaitlist(ctx context.Context, classType, hour string) (bool, error) {
+	if c.preparedDate.IsZero() {
+		return false, ErrNotPrepared
+	}
+
+	timetable, err := c.loadClasses(ctx, c.preparedDate)
+	if err != nil {
+		return false, err
+	}
+
+	class, ok := timetable.find(classType, hour)
+	if !ok {
+		return false, fmt.Errorf("%w: %s at %s", ErrClassNotFound, classType, hour)
+	}
+
+	switch class.State {
+	case classStateWaitlisted:
+		return true, nil
+	case classStateWaitlist:
+		if err := c.classAction(ctx, waitlistPath, class.Class.ID, c.preparedDate); err != nil {
+			return false, err
+		}
+		c.logger.Info("Joined waiting list", "classType", classType, "hour", hour)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// ServerTime returns the site's current time, to measure the local clock's skew
+func (c *HTTPClient) ServerTime(ctx context.Context) (time.Time, error) {
+	return serverTime(ctx, c.http, c.baseURL)
+}
+
+// RemoveBooking cancels the user's reservation of a class on the next date
+// falling on the given day. It takes the same arguments as BookClass; an empty
+// password cancels within the session restored by LoadStoredSession.
+func (c *HTTPClient) RemoveBooking(ctx context.Context, email, password string, day models.Day, classType, hour string) error {
+	if day == "" || classType == "" || hour == "" {
+		return fmt.Errorf("day, classType, and hour are required")
+	}
+	if password == "" && !c.sessionRestored {
+		return ErrNoSession
+	}
+
+	weekday, ok := day.Weekday()
+	if !ok {
+		return fmt.Errorf("%w: %q", models.ErrInvalidDay, day)
+	}
+
+	c.logger.Info("Starting booking removal",
+		"day", day,
+		"classType", classType,
+		"hour", hour)
+
+	if password != "" {
+		if err := c.login(ctx, email, password, false); err != nil {
+			return fmt.Errorf("failed to remove booking: %w", err)
+		}
+	}
+
+	classDate, _ := upcomingClassDate(time.Now(), weekday)
+	if err := c.cancelClass(ctx, classDate, classType, hour); err != nil {
+		c.logger.Error("Failed to remove booking",
+			"error", err,
+			"day", day,
+			"classType", classType,
+			"hour", hour)
+		return fmt.Errorf("failed to remove booking: %w", err)
+	}
+
+	c.logger.Info("Successfully removed booking",
+		"day", day,
+		"classType", classType,
+		"hour", hour)
+
+	return nil
+}
+
+// GetAvailableClasses returns the timetable of the class date. It takes the
+// same arguments as PrepareBooking; an empty password reads the timetable
+// within the session restored by LoadStoredSession.
+func (c *HTTPClient) GetAvailableClasses(ctx context.Context, email, password string, classDate time.Time) ([]models.ClassSchedule, error) {
+	if password == "" && !c.sessionRestored {
+		return nil, ErrNoSession
+	}
+
+	day := models.DayOf(classDate.Weekday())
+	c.logger.Info("Getting available classes", "day", day, "date", classDate.Format("2006-01-02"))
+
+	if password != "" {
+		if err := c.login(ctx, email, password, false); err != nil {
+			return nil, fmt.Errorf("failed to get available classes: %w", err)
+		}
+	}
+
+	timetable, err := c.loadClasses(ctx, classDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available classes: %w", err)
+	}
+
+	var classes []models.ClassSchedule
+	for _, slot := range timetable.Data {
+		hour := slot.Hour
+		if len(hour) > len("15:04") {
+			hour = hour[:len("15:04")] // e.g. "07:00:00"
+		}
+		for _, class := range slot.Classes {
+			classes = append(classes, models.ClassSchedule{
+				Day:       day,
+				Hour:      hour,
+				ClassType: cleanClassType(class.Class.Name),
+				Available: class.State == classStateBookable,
+			})
+		}
+	}
+
+	c.logger.Info("Found available classes", "count", len(classes))
+	return classes, nil
+}
+
+// login posts the credentials to the login form and answers the "remember
+// this browser" prompt
+func (c *HTTPClient) login(ctx context.Context, email, password string, rememberBrowser bool) error {
+	pageURL, body, err := c.get(ctx, c.baseURL+loginPath)
+	if err != nil {
+		return err
+	}
+
+	form := parseAspNetForm(pageURL, body)
+	if err := form.setInput(body, loginEmailID, email); err != nil {
+		return err
+	}
+	if err := form.setInput(body, loginPasswordID, password); err != nil {
+		return err
+	}
+	if err := form.click(body, loginSubmitID); err != nil {
+		return err
+	}
+
+	pageURL, body, err = c.post(ctx, form)
+	if err != nil {
+		return err
+	}
+	if hasElement(body, loginPasswordID) {
+		return ErrInvalidCredentials
+	}
+
+	// Known browsers skip the trust prompt
+	trustID := dontTrustBrowserID
+	if rememberBrowser {
+		trustID = trustBrowserID
+	}
+	if !hasElement(body, trustID) {
+		return nil
+	}
+
+	form = parseAspNetForm(pageURL, body)
+	if err := form.click(body, trustID); err != nil {
+		return err
+	}
+	_, _, err = c.post(ctx, form)
+	return err
+}
+
+// bookClass finds the class in the day's timetable and books it
+func (c *HTTPClient) bookClass(ctx context.Context, classDate time.Time, classType, hour string) error {
+	timetable, err := c.loadClasses(ctx, classDate)
+	if err != nil {
+		return err
+	}
+
+	class, ok := timetable.find(classType, hour)
+	if !ok {
+		return fmt.Errorf("%w: %s at %s", ErrClassNotFound, classType, hour)
+	}
+	if class.State == classStateBooked {
+		c.logger.Info("Class is already booked", "classType", classType, "hour", hour)
+		return nil
+	}
+	if class.State != classStateBookable {
+		return fmt.Errorf("%w: %s at %s is %q", ErrClassNotBookable, classType, hour, class.State)
+	}
+
+	return c.classAction(ctx, bookClassPath, class.Class.ID, classDate)
+}
+
+// cancelClass finds the class in the day's timetable and cancels its booking
+func (c *HTTPClient) cancelClass(ctx context.Context, classDate time.Time, classType, hour string) error {
+	timetable, err := c.loadClasses(ctx, classDate)
+	if err != nil {
+		return err
+	}
+
+	class, ok := timetable.find(classType, hour)
+	if !ok {
+		return fmt.Errorf("%w: %s at %s", ErrClassNotFound, classType, hour)
+	}
+	if class.State != classStateBooked {
+		return fmt.Errorf("%w: %s at %s", ErrBookingNotFound, classType, hour)
+	}
+
+	return c.classAction(ctx, cancelClassPath, class.Class.ID, classDate)
+}
+
+// classAction calls the calendar handler that books or cancels a class, or
+// joins its waiting list
+func (c *HTTPClient) classAction(ctx context.Context, handlerPath string, classID int64, classDate time.Time) error {
+	query := url.Values{}
+	query.Set("id", fmt.Sprint(classID))
+	query.Set("ticks", fmt.Sprint(dotNetTicks(classDate)))
+
+	var result classActionResponse
+	if err := c.getJSON(ctx, c.baseURL+handlerPath+"?"+query.Encode(), &result); err != nil {
+		return err
+	}
+	if !result.Result.Success {
+		return fmt.Errorf("%w: %s", ErrRequestRejected, result.Result.ErrorMsg)
+	}
+
+	return nil
+}
+
+// loadClasses fetches the timetable of a single day
+func (c *HTTPClient) loadClasses(ctx context.Context, date time.Time) (loadClassResponse, error) {
+	var timetable loadClassResponse
+	query := url.Values{}
+	query.Set("ticks", fmt.Sprint(dotNetTicks(date)))
+
+	err := c.getJSON(ctx, c.baseURL+loadClassPath+"?"+query.Encode(), &timetable)
+	return timetable, err
+}
+
+func (c *HTTPClient) get(ctx context.Context, pageURL string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
+	return c.do(req)
+}
+
+func (c *HTTPClient) post(ctx context.Context, form aspNetForm) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, form.action, strings.NewReader(form.fields.Encode()))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.do(req)
+}
+
+// do sends the request and returns the final URL after redirects and the body
+func (c *HTTPClient) do(req *http.Request) (string, string, error) {
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("request to %s failed: %w", req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("request to %s failed with status %d", req.URL.Path, resp.StatusCode)
+	}
+
+	return resp.Request.URL.String(), string(body), nil
+}
+
+// getJSON calls one of the site's JSON handlers. Handlers redirect to the
+// login page when the session is not valid.
+func (c *HTTPClient) getJSON(ctx context.Context, handlerURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, handlerURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Requested-With", "XMLHttpRequest")
+
+	finalURL, body, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(finalURL, loginPath) || hasElement(body, loginPasswordID) {
+		return ErrSessionExpired
+	}
+
+	if err := json.Unmarshal([]byte(body), out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// loadClassResponse is the timetable of one day as returned by LoadClass.ashx
+type loadClassResponse struct {
+	Data []struct {
+		Hour    string       `json:"Hora"` // e.g. "07:00:00"
+		Classes []classEntry `json:"Valores"`
+	} `json:"Data"`
+}
+
+type classEntry struct {
+	State string `json:"TipoEstado"`
+	Class struct {
+		ID    int64  `json:"Id"`
+		Name  string `json:"Nombre"`
+		Spots int    `json:"Plazas"`
+	} `json:"Valor"`
+}
+
+// find looks a class up the same way the browser client's XPath does: the
+// hour must match and the class name must contain the class type
+func (r loadClassResponse) find(classType, hour string) (classEntry, bool) {
+	for _, slot := range r.Data {
+		if !strings.HasPrefix(slot.Hour, hour) {
+			continue
+		}
+		for _, class := range slot.Classes {
+			if strings.Contains(cleanClassType(class.Class.Name), classType) {
+				return class, true
+			}
+		}
+	}
+	return classEntry{}, false
+}
+
+// classActionResponse is the result returned by Calendario_Inscribir.ashx and
+// Calendario_Borrar.ashx
+type classActionResponse struct {
+	Result struct {
+		Success  bool   `json:"EsCorrecto"`
+		ErrorMsg string `json:"ErrorMsg"`
+	} `json:"Res"`
+}
+
+// dotNetTicks converts the calendar date to .NET ticks (100ns intervals since
+// 0001-01-01), which is how the site identifies days
+func dotNetTicks(date time.Time) int64 {
+	const (
+		unixEpochTicks = 621355968000000000
+		ticksPerSecond = 10000000
+	)
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return unixEpochTicks + midnight.Unix()*ticksPerSecond
+}
+
+// dateInNextWeek returns the date of the weekday in the week after now's,
+// weeks running from Monday to Sunday like the site's calendar
+func dateInNextWeek(now time.Time, weekday time.Weekday) time.Time {
+	daysSinceMonday := (int(now.Weekday()) - int(time.Monday) + 7) % 7
+	offset := (int(weekday) - int(time.Monday) + 7) % 7
+	return now.AddDate(0, 0, 7-daysSinceMonday+offset)
+}
+
+// cookieRecorder keeps the full Set-Cookie attributes (expiry, flags) that a
+// cookie jar discards, so the session cookie can be stored and restored later
+type cookieRecorder struct {
+	base    http.RoundTripper
+	mu      sync.Mutex
+	cookies map[string]*http.Cookie
+}
+
+func (r *cookieRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Domain == "" {
+			cookie.Domain = req.URL.Hostname()
+		}
+		r.set(cookie)
+	}
+	return resp, nil
+}
+
+func (r *cookieRecorder) set(cookie *http.Cookie) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Logging out or an expired session deletes the cookie
+	if cookie.MaxAge < 0 {
+		delete(r.cookies, cookie.Name)
+		return
+	}
+	r.cookies[cookie.Name] = cookie
+}
+
+func (r *cookieRecorder) get(name string) *http.Cookie {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cookies[name]
+}
+
+func (r *cookieRecorder) all() []*http.Cookie {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cookies := make([]*http.Cookie, 0, len(r.cookies))
+	for _, cookie := range r.cookies {
+		cookies = append(cookies, cookie)
+	}
+	return cookies
+}
@@ -0,0 +1,463 @@
+package wodbuster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/models"
+	"github.com/MihaiLupoiu/wodbuster-bot/internal/wodbuster/fakesite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	client, err := NewHTTPClient("")
+	assert.ErrorIs(t, err, ErrMissingBaseURL)
+	assert.Nil(t, client)
+
+	client, err = NewHTTPClient(testBaseURL + "/")
+	assert.NoError(t, err)
+	assert.Equal(t, testBaseURL, client.baseURL)
+}
+
+func TestHTTPClient_BookClassValidation(t *testing.T) {
+	client, err := NewHTTPClient(testBaseURL)
+	assert.NoError(t, err)
+
+	// Without credentials nor a restored session nothing is sent to the site
+	err = client.BookClass(t.Context(), "test@example.com", "", models.DayMonday, "WOD", "07:00")
+	assert.ErrorIs(t, err, ErrNoSession)
+}
+
+func TestDotNetTicks(t *testing.T) {
+	date := time.Date(2025, 8, 18, 15, 30, 0, 0, time.UTC)
+	assert.Equal(t, int64(638910720000000000), dotNetTicks(date))
+}
+
+func TestDateInNextWeek(t *testing.T) {
+	saturday := time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 8, 17, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2025-08-18", dateInNextWeek(saturday, time.Monday).Format("2006-01-02"))
+	assert.Equal(t, "2025-08-24", dateInNextWeek(saturday, time.Sunday).Format("2006-01-02"))
+	assert.Equal(t, "2025-08-22", dateInNextWeek(sunday, time.Friday).Format("2006-01-02"))
+	assert.Equal(t, "2025-08-25", dateInNextWeek(monday, time.Monday).Format("2006-01-02"))
+}
+
+func TestHTTPClient_LogIn(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+
+	t.Run("valid credentials", func(t *testing.T) {
+		client, err := NewHTTPClient(site.URL())
+		require.NoError(t, err)
+
+		cookie, err := client.LogIn(t.Context(), "athlete@example.com", "secret")
+		require.NoError(t, err)
+		assert.Equal(t, sessionCookieKey, cookie.Name)
+		assert.True(t, cookie.Expires.After(time.Now()), "trusted browsers get a persistent cookie")
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		client, err := NewHTTPClient(site.URL())
+		require.NoError(t, err)
+
+		cookie, err := client.LogIn(t.Context(), "athlete@example.com", "wrong")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		assert.Nil(t, cookie)
+	})
+}
+
+func TestHTTPClient_BookClassOnFakeSite(t *testing.T) {
+	wednesday := dateInNextWeek(time.Now(), time.Wednesday)
+
+	tests := []struct {
+		name    string
+		setup   func(site *fakesite.Site) int64
+		wantErr error
+		booked  bool
+	}{
+		{
+			name: "bookable class",
+			setup: func(site *fakesite.Site) int64 {
+				site.AddClass(wednesday, "07:00", "Wod", 10)
+				return site.AddClass(wednesday, "19:30", "Wod", 10)
+			},
+			booked: true,
+		},
+		{
+			name: "class name with extra info",
+			setup: func(site *fakesite.Site) int64 {
+				return site.AddClass(wednesday, "19:30", "Wod *", 10)
+			},
+			booked: true,
+		},
+		{
+			name: "full class",
+			setup: func(site *fakesite.Site) int64 {
+				return site.AddClass(wednesday, "19:30", "Wod", 0)
+			},
+			wantErr: ErrClassNotBookable,
+		},
+		{
+			name: "booking window closed",
+			setup: func(site *fakesite.Site) int64 {
+				site.SetBookingsOpen(false)
+				return site.AddClass(wednesday, "19:30", "Wod", 10)
+			},
+			wantErr: ErrClassNotBookable,
+		},
+		{
+			name: "class not in timetable",
+			setup: func(site *fakesite.Site) int64 {
+				return site.AddClass(wednesday, "20:30", "Wod", 10)
+			},
+			wantErr: ErrClassNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := fakesite.New()
+			defer site.Close()
+			site.AddUser("athlete@example.com", "secret")
+			classID := tt.setup(site)
+
+			client, err := NewHTTPClient(site.URL())
+			require.NoError(t, err)
+
+			err = client.BookClass(t.Context(), "athlete@example.com", "secret", models.DayWednesday, "Wod", "19:30")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.booked, site.IsBooked("athlete@example.com", classID))
+		})
+	}
+}
+
+func TestHTTPClient_StoredSession(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	classID := site.AddClass(dateInNextWeek(time.Now(), time.Friday), "08:00", "Open box", 10)
+
+	login, err := NewHTTPClient(site.URL())
+	require.NoError(t, err)
+	_, err = login.LogIn(t.Context(), "athlete@example.com", "secret")
+	require.NoError(t, err)
+
+	t.Run("books within the restored session", func(t *testing.T) {
+		client, err := NewHTTPClient(site.URL())
+		require.NoError(t, err)
+		require.NoError(t, client.LoadStoredSession(t.Context(), login.GetCookies()))
+
+		err = client.BookClass(t.Context(), "athlete@example.com", "", models.DayFriday, "Open box", "08:00")
+		require.NoError(t, err)
+		assert.True(t, site.IsBooked("athlete@example.com", classID))
+
+		// Booking again is a no-op
+		err = client.BookClass(t.Context(), "athlete@example.com", "", models.DayFriday, "Open box", "08:00")
+		assert.NoError(t, err)
+		assert.Len(t, site.Attendees(classID), 1)
+	})
+
+	t.Run("expired session", func(t *testing.T) {
+		client, err := NewHTTPClient(site.URL())
+		require.NoError(t, err)
+		require.NoError(t, client.LoadStoredSession(t.Context(), login.GetCookies()))
+
+		site.ExpireSessions()
+
+		err = client.BookClass(t.Context(), "athlete@example.com", "", models.DayFriday, "Open box", "08:00")
+		assert.ErrorIs(t, err, ErrSessionExpired)
+
+		err = client.LoadStoredSession(t.Context(), login.GetCookies())
+		assert.ErrorIs(t, err, ErrSessionExpired)
+	})
+}
+
+func TestHTTPClient_RemoveBookingOnFakeSite(t *testing.T) {
+	thursday, _ := upcomingClassDate(time.Now(), time.Thursday)
+
+	tests := []struct {
+		name    string
+		booked  bool
+		closed  bool // Cancellations no longer allowed
+		wantErr error
+	}{
+		{name: "booked class", booked: true},
+		{name: "class not booked", wantErr: ErrBookingNotFound},
+		{name: "cancellation rejected", booked: true, closed: true, wantErr: ErrRequestRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := fakesite.New()
+			defer site.Close()
+			site.AddUser("athlete@example.com", "secret")
+			classID := site.AddClass(thursday, "18:00", "Wod", 10)
+			if tt.booked {
+				site.AddAttendee(classID, "athlete@example.com")
+			}
+			site.SetCancellationsOpen(!tt.closed)
+
+			client, err := NewHTTPClient(site.URL())
+			require.NoError(t, err)
+
+			err = client.RemoveBooking(t.Context(), "athlete@example.com", "secret", models.DayThursday, "Wod", "18:00")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.closed, site.IsBooked("athlete@example.com", classID))
+		})
+	}
+}
+
+func TestHTTPClient_BurstBookingOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	tuesday := dateInNextWeek(time.Now(), time.Tuesday)
+	classID := site.AddClass(tuesday, "19:00", "Wod", 10)
+	site.AddClass(tuesday, "20:00", "Wod", 0)
+	site.SetBookingsOpen(false)
+
+	client, err := NewHTTPClient(site.URL())
+	require.NoError(t, err)
+
+	_, err = client.TryBookClass(t.Context(), string(ClassTypeWod), "19:00")
+	assert.ErrorIs(t, err, ErrNotPrepared)
+
+	require.NoError(t, client.PrepareBooking(t.Context(), "athlete@example.com", "secret", tuesday))
+
+	booked, err := client.TryBookClass(t.Context(), string(ClassTypeWod), "19:00")
+	require.NoError(t, err)
+	assert.False(t, booked, "the window is not open yet")
+
+	site.SetBookingsOpen(true)
+	booked, err = client.TryBookClass(t.Context(), string(ClassTypeWod), "19:00")
+	require.NoError(t, err)
+	assert.True(t, booked)
+	assert.True(t, site.IsBooked("athlete@example.com", classID))
+
+	_, err = client.TryBookClass(t.Context(), string(ClassTypeHyrox), "19:00")
+	assert.ErrorIs(t, err, ErrClassNotFound)
+
+	_, err = client.TryBookClass(t.Context(), string(ClassTypeWod), "20:00")
+	assert.ErrorIs(t, err, ErrClassFull)
+}
+
+func TestHTTPClient_BookClassesOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	monday := dateInNextWeek(time.Now(), time.Monday)
+	wednesday := dateInNextWeek(time.Now(), time.Wednesday)
+	mondayID := site.AddClass(monday, "07:00", "Wod", 10)
+	wednesdayID := site.AddClass(wednesday, "07:00", "Wod", 10)
+	site.AddClass(wednesday, "20:00", "Wod", 0)
+
+	client, err := NewHTTPClient(site.URL())
+	require.NoError(t, err)
+
+	_, err = client.BookClasses(t.Context(), nil)
+	assert.ErrorIs(t, err, ErrNotPrepared)
+
+	classes := []models.ClassRequest{
+		{Date: monday, ClassType: string(ClassTypeWod), Hour: "07:00"},
+		{Date: wednesday, ClassType: string(ClassTypeWod), Hour: "20:00"},
+		{Date: wednesday, ClassType: string(ClassTypeHyrox), Hour: "07:00"},
+		{Date: wednesday, ClassType: string(ClassTypeWod), Hour: "07:00"},
+	}
+	require.NoError(t, client.PrepareBooking(t.Context(), "athlete@example.com", "secret", monday))
+	results, err := client.BookClasses(t.Context(), classes)
+	require.NoError(t, err)
+	require.Len(t, results, len(classes))
+
+	assert.True(t, results[0].Booked)
+	assert.ErrorIs(t, results[1].Err, ErrClassFull)
+	assert.ErrorIs(t, results[2].Err, ErrClassNotFound)
+	assert.True(t, results[3].Booked)
+	assert.Equal(t, classes[3], results[3].ClassRequest)
+	assert.True(t, site.IsBooked("athlete@example.com", mondayID))
+	assert.True(t, site.IsBooked("athlete@example.com", wednesdayID))
+
+	// The session stays open for the next batch
+	results, err = client.BookClasses(t.Context(), classes[:1])
+	require.NoError(t, err)
+	assert.True(t, results[0].Booked)
+	assert.Equal(t, 1, site.Logins())
+}
+
+func TestHTTPClient_BookClassesAlternativesOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	friday := dateInNextWeek(time.Now(), time.Friday)
+	site.AddClass(friday, "07:00", "Wod", 0)
+	site.AddClass(friday, "08:00", "Wod", 0)
+	openBoxID := site.AddClass(friday, "07:00", "Open box", 10)
+
+	client, err := NewHTTPClient(site.URL())
+	require.NoError(t, err)
+
+	alternatives := []models.ClassAlternative{
+		{Hour: "08:00", ClassType: string(ClassTypeWod)},
+		{Hour: "09:00", ClassType: string(ClassTypeWod)},
+		{Hour: "07:00", ClassType: string(ClassTypeOpenBox)},
+	}
+	classes := []models.ClassRequest{
+		{Date: friday, ClassType: string(ClassTypeWod), Hour: "07:00", Alternatives: alternatives},
+		{Date: friday, ClassType: string(ClassTypeWod), Hour: "07:00", Alternatives: alternatives[:2]},
+	}
+	require.NoError(t, client.PrepareBooking(t.Context(), "athlete@example.com", "secret", friday))
+	results, err := client.BookClasses(t.Context(), classes)
+	require.NoError(t, err)
+	require.Len(t, results, len(classes))
+
+	assert.True(t, results[0].Booked)
+	assert.Equal(t, &alternatives[2], results[0].BookedAlternative)
+	assert.True(t, site.IsBooked("athlete@example.com", openBoxID))
+
+	// The primary's error is reported when no alternative could be booked
+	assert.False(t, results[1].Booked)
+	assert.Nil(t, results[1].BookedAlternative)
+	assert.ErrorIs(t, results[1].Err, ErrClassFull)
+}
+
+func TestHTTPClient_WaitlistOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+	thursday, _ := upcomingClassDate(time.Now(), time.Thursday)
+	classID := site.AddClass(thursday, "07:00", "Wod", 1)
+	site.AddAttendee(classID, "other@example.com")
+
+	client, err := NewHTTPClient(site.URL())
+	require.NoError(t, err)
+	require.NoError(t, client.PrepareBooking(t.Context(), "athlete@example.com", "secret", thursday))
+
+	joined, err := client.JoinWaitlist(t.Context(), string(ClassTypeWod), "07:00")
+	require.NoError(t, err)
+	assert.False(t, joined, "the class offers no waiting list")
+
+	site.SetWaitlists(true)
+	_, err = client.TryBookClass(t.Context(), string(ClassTypeWod), "07:00")
+	assert.ErrorIs(t, err, ErrClassFull)
+
+	joined, err = client.JoinWaitlist(t.Context(), string(ClassTypeWod), "07:00")
+	require.NoError(t, err)
+	assert.True(t, joined)
+	assert.True(t, site.IsWaitlisted("athlete@example.com", classID))
+
+	// The spot freed by another athlete goes to the waiting list
+	site.RemoveAttendee(classID, "other@example.com")
+	booked, err := client.TryBookClass(t.Context(), string(ClassTypeWod), "07:00")
+	require.NoError(t, err)
+	assert.True(t, booked)
+	assert.True(t, site.IsBooked("athlete@example.com", classID))
+}
+
+func TestCalendarDay(t *testing.T) {
+	saturday := time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		date         time.Time
+		wantTab      string
+		wantNextWeek bool
+		wantErr      bool
+	}{
+		{"this week", time.Date(2025, 8, 14, 0, 0, 0, 0, time.UTC), "J", false, false},
+		{"sunday of this week", time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC), "D", false, false},
+		{"next week", time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC), "L", true, false},
+		{"end of next week", time.Date(2025, 8, 24, 0, 0, 0, 0, time.UTC), "D", true, false},
+		{"two weeks ahead", time.Date(2025, 8, 25, 0, 0, 0, 0, time.UTC), "", false, true},
+		{"last week", time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC), "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab, nextWeek, err := calendarDay(saturday, tt.date)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTab, tab)
+			assert.Equal(t, tt.wantNextWeek, nextWeek)
+		})
+	}
+}
+
+func TestHTTPClient_GetAvailableClassesOnFakeSite(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+	site.AddUser("athlete@example.com", "secret")
+
+	today := time.Now()
+	site.AddClass(today, "07:00", "Wod", 10)
+	site.AddClass(today, "08:00", "HYROX *", 0)
+
+	client, err := NewHTTPClient(site.URL())
+	require.NoError(t, err)
+
+	classes, err := client.GetAvailableClasses(t.Context(), "athlete@example.com", "secret", today)
+	require.NoError(t, err)
+	require.Len(t, classes, 2)
+
+	assert.Equal(t, dayOf(today), classes[0].Day)
+	assert.Equal(t, "07:00", classes[0].Hour)
+	assert.Equal(t, string(ClassTypeWod), classes[0].ClassType)
+	assert.True(t, classes[0].Available)
+
+	assert.Equal(t, dayOf(today), classes[1].Day)
+	assert.Equal(t, "08:00", classes[1].Hour)
+	assert.Equal(t, string(ClassTypeHyrox), classes[1].ClassType)
+	assert.False(t, classes[1].Available, "full classes cannot be booked")
+}
+
+func TestHTTPClient_ServerTime(t *testing.T) {
+	site := fakesite.New()
+	defer site.Close()
+
+	client, err := NewHTTPClient(site.URL())
+	require.NoError(t, err)
+
+	serverTime, err := client.ServerTime(t.Context())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), serverTime, 2*time.Second)
+}
+
+func TestUpcomingClassDate(t *testing.T) {
+	saturday := time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC)
+	wednesday := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		weekday      time.Weekday
+		wantDate     string
+		wantNextWeek bool
+	}{
+		{"later this week", wednesday, time.Friday, "2025-08-22", false},
+		{"today", wednesday, time.Wednesday, "2025-08-20", false},
+		{"next week", wednesday, time.Monday, "2025-08-25", true},
+		{"sunday of this week", saturday, time.Sunday, "2025-08-17", false},
+		{"monday after saturday", saturday, time.Monday, "2025-08-18", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, nextWeek := upcomingClassDate(tt.now, tt.weekday)
+			assert.Equal(t, tt.wantDate, date.Format("2006-01-02"))
+			assert.Equal(t, tt.wantNextWeek, nextWeek)
+		})
+	}
+}
@@ -9,8 +9,9 @@ import (
 	"github.com/chromedp/chromedp"
 )
 
-// LogIn authenticates the user with email and password
-// Returns a session cookie on successful login or nil if session was restored from cookies
+// LogIn authenticates the user with email and password and returns the session
+// cookie. Cookies the client was created with are reused instead when they
+// still open the calendar.
 func (c *Client) LogIn(ctx context.Context, email, password string) (*http.Cookie, error) {
 	if email == "" || password == "" {
 		return nil, fmt.Errorf("email and password are required")
@@ -18,21 +19,20 @@ func (c *Client) LogIn(ctx context.Context, email, password string) (*http.Cooki
 
 	// Check if we have valid session cookies first
 	if len(c.cookies) > 0 {
-		if err := c.RestoreCookies(); err != nil {
-			c.logger.Warn("Failed to restore cookies, proceeding with fresh login", "error", err)
-		} else {
-			// Test if session is still valid by navigating to a protected page
-			if err := chromedp.Run(c.ctx, chromedp.Navigate(c.baseURL+"/schedule")); err == nil {
-				c.logger.Info("Session restored successfully", "username", email)
-				return nil, nil
-			}
+		if err := c.restoreSession(ctx); err != nil {
+			c.logger.Warn("Failed to restore session, proceeding with fresh login", "error", err)
+			c.ClearCookies()
+		} else if sessionCookie := c.extractMainSessionCookie(); sessionCookie != nil {
+			c.sessionRestored = true
+			c.logger.Info("Session restored successfully", "username", email)
+			return sessionCookie, nil
 		}
 	}
 
 	actions := login(c.baseURL, email, password)
 	actions = append(actions, rememberBrowser()...)
 
-	if err := chromedp.Run(c.ctx, actions...); err != nil {
+	if err := c.run(ctx, actions...); err != nil {
 		return nil, fmt.Errorf("login failed: %w", err)
 	}
 
@@ -0,0 +1,232 @@
+package wodbuster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/chromedp/chromedp"
+)
+
+var (
+	ErrInvalidPoolSize = errors.New("pool size must be at least 1")
+	ErrPoolClosed      = errors.New("pool is closed")
+)
+
+// Pool hands out Clients that run in isolated browser contexts of one shared
+// Chrome process. Each context has its own tab and cookie jar, so concurrent
+// bookings for different users don't trample each other's navigation.
+type Pool struct {
+	baseURL  string
+	logger   *slog.Logger
+	headless bool
+
+	browserCtx context.Context
+	cancel     context.CancelFunc
+
+	slots   chan struct{} // one token per client currently handed out
+	idle    chan *Client  // warmed-up clients ready to be handed out
+	mu      sync.Mutex
+	started bool
+	closed  bool
+}
+
+// PoolOption defines the method to customize the Pool.
+type PoolOption func(*Pool)
+
+// WithPoolLogger allows setting a custom logger for the pool and its clients.
+func WithPoolLogger(logger *slog.Logger) PoolOption {
+	return func(p *Pool) {
+		if logger != nil {
+			p.logger = logger
+		}
+	}
+}
+
+// WithPoolHeadlessMode configures whether the shared browser runs headless.
+func WithPoolHeadlessMode(headless bool) PoolOption {
+	return func(p *Pool) {
+		p.headless = headless
+	}
+}
+
+// NewPool creates a pool of at most size concurrent clients. The browser
+// process is started lazily on first use or by WarmUp.
+func NewPool(baseURL string, size int, opts ...PoolOption) (*Pool, error) {
+	if baseURL == "" {
+		return nil, ErrMissingBaseURL
+	}
+	if size < 1 {
+		return nil, ErrInvalidPoolSize
+	}
+
+	pool := &Pool{
+		baseURL:  baseURL,
+		logger:   slog.Default(),
+		headless: true,
+		slots:    make(chan struct{}, size),
+		idle:     make(chan *Client, size),
+	}
+
+	for _, opt := range opts {
+		opt(pool)
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), browserOptions(pool.headless)...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+
+	pool.browserCtx = browserCtx
+	pool.cancel = func() {
+		browserCancel()
+		allocCancel()
+	}
+
+	return pool, nil
+}
+
+// Acquire returns a client with its own browser context, blocking while the
+// pool is exhausted. Every acquired client must be handed back with Release.
+func (p *Pool) Acquire(ctx context.Context) (*Client, error) {
+	if p.isClosed() {
+		return nil, ErrPoolClosed
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a free browser context: %w", ctx.Err())
+	}
+
+	var client *Client
+	select {
+	case client = <-p.idle:
+	default:
+		var err error
+		client, err = p.newClient()
+		if err != nil {
+			<-p.slots
+			return nil, err
+		}
+	}
+
+	// Bound the client's lifetime from the moment it is handed out
+	timeoutCtx, timeoutCancel := context.WithTimeout(client.ctx, clientTimeout)
+	cancel := client.cancel
+	client.ctx = timeoutCtx
+	client.cancel = func() {
+		timeoutCancel()
+		cancel()
+	}
+
+	return client, nil
+}
+
+// Release closes the client's browser context, discarding its tab and cookies,
+// and frees its slot in the pool.
+func (p *Pool) Release(client *Client) {
+	if client == nil {
+		return
+	}
+
+	client.Close()
+	<-p.slots
+}
+
+// WarmUp starts the browser and opens up to count isolated contexts ahead of
+// time, so that bookings don't pay the browser start-up cost when the window opens.
+func (p *Pool) WarmUp(ctx context.Context, count int) error {
+	if p.isClosed() {
+		return ErrPoolClosed
+	}
+
+	count = min(count, cap(p.idle)-len(p.idle))
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		client, err := p.newClient()
+		if err != nil {
+			return fmt.Errorf("failed to warm up browser context: %w", err)
+		}
+
+		select {
+		case p.idle <- client:
+		default:
+			// Filled concurrently by another warm-up
+			client.Close()
+			return nil
+		}
+	}
+
+	p.logger.Info("Browser pool warmed up", "idle_contexts", len(p.idle))
+	return nil
+}
+
+// Close discards the idle contexts and stops the shared browser.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	for {
+		select {
+		case client := <-p.idle:
+			client.Close()
+		default:
+			p.cancel()
+			return
+		}
+	}
+}
+
+func (p *Pool) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// startBrowser launches the shared browser process if it is not running yet
+func (p *Pool) startBrowser() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started {
+		return nil
+	}
+	// Running no actions is enough to start the browser
+	if err := chromedp.Run(p.browserCtx); err != nil {
+		return fmt.Errorf("failed to start browser: %w", err)
+	}
+	p.started = true
+	return nil
+}
+
+// newClient opens a fresh tab in a new browser context of the shared browser
+func (p *Pool) newClient() (*Client, error) {
+	// New browser contexts can only be created once the browser is running
+	if err := p.startBrowser(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := chromedp.NewContext(p.browserCtx, chromedp.WithNewBrowserContext())
+
+	// Create the browser context and its tab right away
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open browser context: %w", err)
+	}
+
+	return &Client{
+		ctx:     ctx,
+		cancel:  cancel,
+		baseURL: p.baseURL,
+		logger:  p.logger,
+	}, nil
+}
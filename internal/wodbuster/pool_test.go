@@ -0,0 +1,59 @@
+package wodbuster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPool(t *testing.T) {
+	t.Run("with empty URL", func(t *testing.T) {
+		pool, err := NewPool("", 2)
+		assert.ErrorIs(t, err, ErrMissingBaseURL)
+		assert.Nil(t, pool)
+	})
+
+	t.Run("with invalid size", func(t *testing.T) {
+		pool, err := NewPool(testBaseURL, 0)
+		assert.ErrorIs(t, err, ErrInvalidPoolSize)
+		assert.Nil(t, pool)
+	})
+
+	t.Run("with valid configuration", func(t *testing.T) {
+		pool, err := NewPool(testBaseURL, 2)
+		require.NoError(t, err)
+		pool.Close()
+
+		_, err = pool.Acquire(context.Background())
+		assert.ErrorIs(t, err, ErrPoolClosed)
+	})
+}
+
+func TestPool_AcquireIsBounded(t *testing.T) {
+	pool, err := NewPool(testBaseURL, 2)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// Pre-fill the idle contexts so no browser is needed
+	for i := 0; i < 2; i++ {
+		pool.idle <- &Client{ctx: context.Background(), cancel: func() {}, baseURL: testBaseURL}
+	}
+
+	first, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	second, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = pool.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	pool.Release(first)
+	pool.Release(second)
+	assert.Empty(t, pool.slots)
+}
@@ -30,10 +30,13 @@ func (c *Client) SaveCookies() error {
 					Value:    cookie.Value,
 					Domain:   domain,
 					Path:     path,
-					Expires:  time.Unix(int64(cookie.Expires), 0),
 					Secure:   cookie.Secure,
 					HttpOnly: cookie.HTTPOnly,
 				}
+				// Session cookies have no expiry, they last as long as the browser
+				if !cookie.Session {
+					httpCookie.Expires = time.Unix(int64(cookie.Expires), 0)
+				}
 				cookies = append(cookies, httpCookie)
 			}
 			return nil
@@ -57,15 +60,16 @@ func (c *Client) RestoreCookies() error {
 	return chromedp.Run(c.ctx,
 		chromedp.ActionFunc(func(ctx context.Context) error {
 			for _, cookie := range c.cookies {
-				expires := cdp.TimeSinceEpoch(cookie.Expires)
-				err := network.SetCookie(cookie.Name, cookie.Value).
+				setCookie := network.SetCookie(cookie.Name, cookie.Value).
 					WithDomain(cookie.Domain).
 					WithPath(cookie.Path).
 					WithSecure(cookie.Secure).
-					WithHTTPOnly(cookie.HttpOnly).
-					WithExpires(&expires).
-					Do(ctx)
-				if err != nil {
+					WithHTTPOnly(cookie.HttpOnly)
+				if !cookie.Expires.IsZero() {
+					expires := cdp.TimeSinceEpoch(cookie.Expires)
+					setCookie = setCookie.WithExpires(&expires)
+				}
+				if err := setCookie.Do(ctx); err != nil {
 					return err
 				}
 			}
@@ -74,31 +78,37 @@ func (c *Client) RestoreCookies() error {
 	)
 }
 
-// LoadStoredSession initializes client with cookies from storage and validates session
+// LoadStoredSession initializes client with cookies from storage and validates
+// session. Cookies that don't open the calendar are dropped, so they are not
+// reused or handed back by GetCookies.
 func (c *Client) LoadStoredSession(ctx context.Context, cookies []*http.Cookie) error {
 	if len(cookies) == 0 {
 		return fmt.Errorf("no cookies provided")
 	}
 
 	c.cookies = cookies
+	if err := c.restoreSession(ctx); err != nil {
+		c.ClearCookies()
+		return fmt.Errorf("session validation failed: %w", err)
+	}
 
-	// Restore cookies to browser context
+	c.sessionRestored = true
+	c.logger.Info("Session loaded and validated successfully")
+	return nil
+}
+
+// restoreSession sets the client's cookies in the browser and checks they open
+// the calendar: without a session the site redirects to the login page and the
+// calendar never shows up
+func (c *Client) restoreSession(ctx context.Context) error {
 	if err := c.RestoreCookies(); err != nil {
 		return fmt.Errorf("failed to restore cookies: %w", err)
 	}
-
-	// Validate session by navigating to protected page
-	err := chromedp.Run(c.ctx,
+	return c.runBounded(ctx, navigationTimeout,
 		chromedp.Navigate(c.baseURL+"/schedule"),
+		requireSession(),
 		chromedp.WaitVisible(`#calendar`, chromedp.ByQuery),
 	)
-
-	if err != nil {
-		return fmt.Errorf("session validation failed: %w", err)
-	}
-
-	c.logger.Info("Session loaded and validated successfully")
-	return nil
 }
 
 // GetCookies returns the current stored cookies
@@ -109,6 +119,7 @@ func (c *Client) GetCookies() []*http.Cookie {
 // ClearCookies clears all stored cookies
 func (c *Client) ClearCookies() {
 	c.cookies = nil
+	c.sessionRestored = false
 }
 
 // extractMainSessionCookie finds the main WODBuster session cookie from stored cookies